@@ -1,5 +1,5 @@
 module github.com/tab58/v1/spatial
 
-go 1.15
+go 1.18
 
 require gonum.org/v1/gonum v0.8.2
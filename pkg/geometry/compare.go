@@ -0,0 +1,71 @@
+package geometry
+
+import (
+	"math"
+
+	"github.com/tab58/v1/spatial/pkg/numeric"
+)
+
+// areComponentsEqual returns true if the corresponding components are equal within the tolerance, false if not. The
+// relative criterion is measured against the largest component magnitude of either argument, so small components
+// of large vectors are compared at the scale of the vector.
+func areComponentsEqual(tol numeric.Tolerance, a, b []float64) (bool, error) {
+	if tol.IsInvalid() {
+		return false, numeric.ErrInvalidTol
+	}
+	scale := 0.0
+	for i := range a {
+		scale = math.Max(scale, math.Max(math.Abs(a[i]), math.Abs(b[i])))
+	}
+	for i := range a {
+		if !tol.IsEqualAtScale(a[i], b[i], scale) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// isDeterminantNearZero returns true if the determinant of the row-major matrix a of order n is zero within the
+// tolerance. The relative criterion is measured against the product of the row norms, which bounds the magnitude of
+// the determinant, so the test does not depend on the scale of the matrix. The rows are scaled by powers of two before
+// the determinant is factored, so neither it nor the bound overflows.
+func isDeterminantNearZero(tol numeric.Tolerance, a []float64, n int) (bool, error) {
+	if tol.IsInvalid() {
+		return false, numeric.ErrInvalidTol
+	}
+	err := checkFinite(a)
+	if err != nil {
+		return false, err
+	}
+
+	// |det(a)| = det * 2^exp and the product of the row norms is bound * 2^exp, with bound in [2^-n, 1)
+	b := make([]float64, n*n)
+	bound, exp := 1.0, 0
+	for i := 0; i < n; i++ {
+		row := a[i*n : (i+1)*n]
+		nrm := numeric.NrmN(row...)
+		if nrm == 0 {
+			return true, nil
+		}
+		f, e := math.Frexp(nrm)
+		for j, v := range row {
+			b[i*n+j] = math.Ldexp(v, -e)
+		}
+		bound *= f
+		exp += e
+	}
+	lu, _, _ := luDecompose(b, n)
+	det := 1.0
+	for i := 0; i < n; i++ {
+		det *= lu[i*n+i]
+	}
+	det = math.Abs(det)
+
+	// the relative criterion is unaffected by the common power of two, while the absolute criterion applies to the
+	// unscaled determinant, so the power of two moves to the tolerance instead; the ULP criterion can only hold once
+	// the unscaled determinant has underflowed
+	if det <= tol.Relative*bound || det <= math.Ldexp(tol.Absolute, -exp) {
+		return true, nil
+	}
+	return tol.ULPs > 0 && numeric.ULPDistance(math.Ldexp(det, exp), 0) <= tol.ULPs, nil
+}
@@ -0,0 +1,552 @@
+package geometry
+
+import (
+	"math"
+	"sort"
+
+	"github.com/tab58/v1/spatial/pkg/numeric"
+)
+
+// The kernels in this file operate on square row-major matrices of order n stored in slices, and are shared by the
+// fixed-size matrix types.
+
+// machineEpsilon is the spacing of float64 values at 1.
+const machineEpsilon = 0x1p-52
+
+// maxJacobiSweeps is the maximum number of sweeps of the one-sided Jacobi SVD.
+const maxJacobiSweeps = 64
+
+// checkFinite returns an error if any of the elements is NaN or infinite.
+func checkFinite(a []float64) error {
+	for _, v := range a {
+		if math.IsNaN(v) {
+			return numeric.ErrNaN
+		}
+		if numeric.IsOverflow(v) {
+			return numeric.ErrOverflow
+		}
+	}
+	return nil
+}
+
+// identitySlice returns the identity matrix of order n.
+func identitySlice(n int) []float64 {
+	a := make([]float64, n*n)
+	for i := 0; i < n; i++ {
+		a[i*n+i] = 1
+	}
+	return a
+}
+
+// qrDecompose computes the QR decomposition a = q * r using Householder reflections, where q is orthogonal and r is
+// upper triangular with a non-negative diagonal.
+func qrDecompose(a []float64, n int) ([]float64, []float64) {
+	r := make([]float64, n*n)
+	copy(r, a)
+	q := identitySlice(n)
+
+	v := make([]float64, n)
+	for k := 0; k < n-1; k++ {
+		// reflect x = r[k:, k] onto -sign(x_0) * |x| * e_0
+		norm := 0.0
+		for i := k; i < n; i++ {
+			norm = math.Hypot(norm, r[i*n+k])
+		}
+		if norm == 0 {
+			continue
+		}
+		alpha := -math.Copysign(norm, r[k*n+k])
+		vv := 0.0
+		for i := k; i < n; i++ {
+			v[i] = r[i*n+k]
+		}
+		v[k] -= alpha
+		for i := k; i < n; i++ {
+			vv += v[i] * v[i]
+		}
+		if vv == 0 {
+			continue
+		}
+
+		// r = H * r, q = q * H, with H = I - 2 * v * v^T / (v^T * v)
+		for j := 0; j < n; j++ {
+			s := 0.0
+			for i := k; i < n; i++ {
+				s += v[i] * r[i*n+j]
+			}
+			f := 2 * s / vv
+			for i := k; i < n; i++ {
+				r[i*n+j] -= f * v[i]
+			}
+		}
+		for i := 0; i < n; i++ {
+			s := 0.0
+			for l := k; l < n; l++ {
+				s += q[i*n+l] * v[l]
+			}
+			f := 2 * s / vv
+			for l := k; l < n; l++ {
+				q[i*n+l] -= f * v[l]
+			}
+		}
+		r[k*n+k] = alpha
+		for i := k + 1; i < n; i++ {
+			r[i*n+k] = 0
+		}
+	}
+
+	// make the diagonal of r non-negative so the factorization is unique for nonsingular matrices
+	for k := 0; k < n; k++ {
+		if r[k*n+k] < 0 {
+			for j := k; j < n; j++ {
+				r[k*n+j] = -r[k*n+j]
+			}
+			for i := 0; i < n; i++ {
+				q[i*n+k] = -q[i*n+k]
+			}
+		}
+	}
+	return q, r
+}
+
+// luDecompose computes the LU decomposition with partial pivoting, p * a = l * u. The factors are returned packed in a
+// single matrix, with the unit diagonal of l implied, along with the row permutation, where row i of p * a is row
+// perm[i] of a, and its sign. Singular matrices produce a zero on the diagonal of u.
+func luDecompose(a []float64, n int) ([]float64, []int, float64) {
+	lu := make([]float64, n*n)
+	copy(lu, a)
+	perm := make([]int, n)
+	for i := range perm {
+		perm[i] = i
+	}
+	sign := 1.0
+
+	for k := 0; k < n; k++ {
+		// choose the largest pivot in the column
+		p := k
+		for i := k + 1; i < n; i++ {
+			if math.Abs(lu[i*n+k]) > math.Abs(lu[p*n+k]) {
+				p = i
+			}
+		}
+		if p != k {
+			for j := 0; j < n; j++ {
+				lu[k*n+j], lu[p*n+j] = lu[p*n+j], lu[k*n+j]
+			}
+			perm[k], perm[p] = perm[p], perm[k]
+			sign = -sign
+		}
+
+		pivot := lu[k*n+k]
+		if pivot == 0 {
+			continue
+		}
+		for i := k + 1; i < n; i++ {
+			f := lu[i*n+k] / pivot
+			lu[i*n+k] = f
+			for j := k + 1; j < n; j++ {
+				lu[i*n+j] -= f * lu[k*n+j]
+			}
+		}
+	}
+	return lu, perm, sign
+}
+
+// unpackLU splits the packed LU factors into the permutation matrix p, unit lower triangular l and upper triangular u.
+func unpackLU(lu []float64, perm []int, n int) ([]float64, []float64, []float64) {
+	p := make([]float64, n*n)
+	l := make([]float64, n*n)
+	u := make([]float64, n*n)
+	for i := 0; i < n; i++ {
+		p[i*n+perm[i]] = 1
+		for j := 0; j < n; j++ {
+			switch {
+			case j < i:
+				l[i*n+j] = lu[i*n+j]
+			case j == i:
+				l[i*n+j] = 1
+				u[i*n+j] = lu[i*n+j]
+			default:
+				u[i*n+j] = lu[i*n+j]
+			}
+		}
+	}
+	return p, l, u
+}
+
+// choleskyDecompose computes the lower triangular l with a positive diagonal such that a = l * l^T, reading only
+// the lower triangle of a. numeric.ErrNotPositiveDefinite is returned if a is not positive definite.
+func choleskyDecompose(a []float64, n int) ([]float64, error) {
+	l := make([]float64, n*n)
+	for j := 0; j < n; j++ {
+		d := a[j*n+j]
+		for k := 0; k < j; k++ {
+			d -= l[j*n+k] * l[j*n+k]
+		}
+		if !(d > 0) {
+			return nil, numeric.ErrNotPositiveDefinite
+		}
+		d = math.Sqrt(d)
+		l[j*n+j] = d
+
+		for i := j + 1; i < n; i++ {
+			s := a[i*n+j]
+			for k := 0; k < j; k++ {
+				s -= l[i*n+k] * l[j*n+k]
+			}
+			l[i*n+j] = s / d
+		}
+	}
+	return l, nil
+}
+
+// svdDecompose computes the singular value decomposition a = u * diag(s) * v^T using one-sided Jacobi rotations, where
+// u and v are orthogonal and the singular values s are non-negative and sorted in descending order.
+func svdDecompose(a []float64, n int) ([]float64, []float64, []float64, error) {
+	w := make([]float64, n*n)
+	copy(w, a)
+	v := identitySlice(n)
+
+	// rotate pairs of columns of w until all are mutually orthogonal; w = a * v
+	converged := false
+	for sweep := 0; sweep < maxJacobiSweeps && !converged; sweep++ {
+		converged = true
+		for p := 0; p < n-1; p++ {
+			for q := p + 1; q < n; q++ {
+				alpha, beta, gamma := 0.0, 0.0, 0.0
+				for i := 0; i < n; i++ {
+					wp, wq := w[i*n+p], w[i*n+q]
+					alpha += wp * wp
+					beta += wq * wq
+					gamma += wp * wq
+				}
+				// the columns are orthogonal to working precision
+				if gamma == 0 || math.Abs(gamma) <= float64(n)*machineEpsilon*math.Sqrt(alpha)*math.Sqrt(beta) {
+					continue
+				}
+				converged = false
+
+				zeta := (beta - alpha) / (2 * gamma)
+				t := 1 / (math.Abs(zeta) + math.Hypot(1, zeta))
+				if zeta < 0 {
+					t = -t
+				}
+				c := 1 / math.Hypot(1, t)
+				s := c * t
+				for i := 0; i < n; i++ {
+					wp, wq := w[i*n+p], w[i*n+q]
+					w[i*n+p] = c*wp - s*wq
+					w[i*n+q] = s*wp + c*wq
+					vp, vq := v[i*n+p], v[i*n+q]
+					v[i*n+p] = c*vp - s*vq
+					v[i*n+q] = s*vp + c*vq
+				}
+			}
+		}
+	}
+	if !converged {
+		return nil, nil, nil, numeric.ErrNotConverged
+	}
+
+	// the singular values are the column norms; sort them in descending order
+	sv := make([]float64, n)
+	order := make([]int, n)
+	for j := 0; j < n; j++ {
+		norm := 0.0
+		for i := 0; i < n; i++ {
+			norm = math.Hypot(norm, w[i*n+j])
+		}
+		sv[j] = norm
+		order[j] = j
+	}
+	sort.SliceStable(order, func(i, j int) bool { return sv[order[i]] > sv[order[j]] })
+
+	u := make([]float64, n*n)
+	vs := make([]float64, n*n)
+	s := make([]float64, n)
+	valid := make([]bool, n)
+	// columns below the rounding error of the largest singular value carry no direction
+	tiny := sv[order[0]] * float64(n) * machineEpsilon
+	for k, j := range order {
+		s[k] = sv[j]
+		for i := 0; i < n; i++ {
+			vs[i*n+k] = v[i*n+j]
+		}
+		if sv[j] > tiny && sv[j] > 0 {
+			valid[k] = true
+			for i := 0; i < n; i++ {
+				u[i*n+k] = w[i*n+j] / sv[j]
+			}
+		}
+	}
+	completeOrthonormalColumns(u, valid, n)
+	return u, s, vs, nil
+}
+
+// completeOrthonormalColumns replaces the invalid columns of the matrix, whose valid columns are orthonormal, so that
+// all columns form an orthonormal basis.
+func completeOrthonormalColumns(u []float64, valid []bool, n int) {
+	col := make([]float64, n)
+	best := make([]float64, n)
+	for k := 0; k < n; k++ {
+		if valid[k] {
+			continue
+		}
+
+		// orthogonalize each canonical axis against the valid columns and keep the largest remainder
+		bestNorm := -1.0
+		for e := 0; e < n; e++ {
+			for i := range col {
+				col[i] = 0
+			}
+			col[e] = 1
+			for pass := 0; pass < 2; pass++ {
+				for j := 0; j < n; j++ {
+					if !valid[j] {
+						continue
+					}
+					d := 0.0
+					for i := 0; i < n; i++ {
+						d += u[i*n+j] * col[i]
+					}
+					for i := 0; i < n; i++ {
+						col[i] -= d * u[i*n+j]
+					}
+				}
+			}
+			norm := 0.0
+			for _, c := range col {
+				norm = math.Hypot(norm, c)
+			}
+			if norm > bestNorm {
+				bestNorm = norm
+				copy(best, col)
+			}
+		}
+		for i := 0; i < n; i++ {
+			u[i*n+k] = best[i] / bestNorm
+		}
+		valid[k] = true
+	}
+}
+
+// matrixRank counts the singular values, sorted in descending order, that are not zero within the tolerance at the
+// scale of the largest one.
+func matrixRank(s []float64, tol numeric.Tolerance) (int, error) {
+	if tol.IsInvalid() {
+		return 0, numeric.ErrInvalidTol
+	}
+	rank := 0
+	for _, v := range s {
+		if !tol.IsEqualAtScale(v, 0, s[0]) {
+			rank++
+		}
+	}
+	return rank, nil
+}
+
+// conditionNumber computes the 2-norm condition number from singular values sorted in descending order, which is
+// infinite for singular matrices.
+func conditionNumber(s []float64) float64 {
+	smin := s[len(s)-1]
+	if smin == 0 {
+		return math.Inf(1)
+	}
+	return s[0] / smin
+}
+
+// luSolve solves a * x = b for the n x nrhs row-major right-hand sides b, given the packed LU factors of a, and
+// returns x. The factors must be nonsingular.
+func luSolve(lu []float64, perm []int, n int, b []float64, nrhs int) []float64 {
+	x := make([]float64, n*nrhs)
+	for i := 0; i < n; i++ {
+		copy(x[i*nrhs:(i+1)*nrhs], b[perm[i]*nrhs:(perm[i]+1)*nrhs])
+	}
+	for c := 0; c < nrhs; c++ {
+		// forward substitution with the unit lower triangle, then back substitution with the upper
+		for i := 1; i < n; i++ {
+			s := x[i*nrhs+c]
+			for k := 0; k < i; k++ {
+				s -= lu[i*n+k] * x[k*nrhs+c]
+			}
+			x[i*nrhs+c] = s
+		}
+		for i := n - 1; i >= 0; i-- {
+			s := x[i*nrhs+c]
+			for k := i + 1; k < n; k++ {
+				s -= lu[i*n+k] * x[k*nrhs+c]
+			}
+			x[i*nrhs+c] = s / lu[i*n+i]
+		}
+	}
+	return x
+}
+
+// norm1 computes the 1-norm of the matrix, the largest absolute column sum.
+func norm1(a []float64, n int) float64 {
+	norm := 0.0
+	for j := 0; j < n; j++ {
+		s := 0.0
+		for i := 0; i < n; i++ {
+			s += math.Abs(a[i*n+j])
+		}
+		norm = math.Max(norm, s)
+	}
+	return norm
+}
+
+// checkDeterminant returns an error if the determinant computed by cofactor expansion is zero or overflowed.
+func checkDeterminant(det float64) error {
+	if det == 0 {
+		return numeric.ErrSingularMatrix
+	}
+	if numeric.IsOverflow(det) || math.IsNaN(det) {
+		return numeric.ErrOverflow
+	}
+	return nil
+}
+
+// setInverse copies the inverse of the row-major matrix a of order n, computed by cofactor expansion, to a.
+// numeric.ErrSingularMatrix is returned if the matrix is singular to working precision, which is when its reciprocal
+// 1-norm condition number is below the machine epsilon.
+func setInverse(a, inv []float64, n int) error {
+	for _, v := range inv {
+		if numeric.IsOverflow(v) {
+			return numeric.ErrOverflow
+		}
+	}
+	rcond := 1 / (norm1(a, n) * norm1(inv, n))
+	// the negated comparison also rejects the NaN left by cancelling overflowed cofactors
+	if !(rcond >= machineEpsilon) {
+		return numeric.ErrSingularMatrix
+	}
+	copy(a, inv)
+	return nil
+}
+
+// luSolveTranspose solves a^T * x = b for a single right-hand side b, given the packed LU factors of a, and returns
+// x. The factors must be nonsingular.
+func luSolveTranspose(lu []float64, perm []int, n int, b []float64) []float64 {
+	// a = P^T * L * U, so solve U^T * w = b, then L^T * v = w, then P * x = v
+	v := make([]float64, n)
+	copy(v, b)
+	for i := 0; i < n; i++ {
+		s := v[i]
+		for k := 0; k < i; k++ {
+			s -= lu[k*n+i] * v[k]
+		}
+		v[i] = s / lu[i*n+i]
+	}
+	for i := n - 1; i >= 0; i-- {
+		s := v[i]
+		for k := i + 1; k < n; k++ {
+			s -= lu[k*n+i] * v[k]
+		}
+		v[i] = s
+	}
+	x := make([]float64, n)
+	for i := 0; i < n; i++ {
+		x[perm[i]] = v[i]
+	}
+	return x
+}
+
+// luInverseNorm1Estimate estimates the 1-norm of a^-1 from the packed LU factors of a without forming the inverse,
+// using Hager's method with Higham's refinements as in LAPACK's dlacn2. The estimate is a lower bound that is rarely
+// more than a factor of 3 below the true norm. The factors must be nonsingular.
+func luInverseNorm1Estimate(lu []float64, perm []int, n int) float64 {
+	x := make([]float64, n)
+	for i := range x {
+		x[i] = 1 / float64(n)
+	}
+
+	// climb the convex function ||a^-1 * x||_1 over the unit 1-norm ball, whose maximum is at a vertex e_j
+	est := 0.0
+	j := -1
+	for iter := 0; iter < 5; iter++ {
+		y := luSolve(lu, perm, n, x, 1)
+		e := 0.0
+		for i := range y {
+			e += math.Abs(y[i])
+			// the subgradient sign(y), with sign(0) = 1
+			if y[i] >= 0 {
+				y[i] = 1
+			} else {
+				y[i] = -1
+			}
+		}
+		if iter > 0 && e <= est {
+			break
+		}
+		est = e
+
+		z := luSolveTranspose(lu, perm, n, y)
+		next := 0
+		for i := range z {
+			if math.Abs(z[i]) > math.Abs(z[next]) {
+				next = i
+			}
+		}
+		if iter > 0 && (next == j || math.Abs(z[next]) <= z[j]) {
+			break
+		}
+		j = next
+		for i := range x {
+			x[i] = 0
+		}
+		x[j] = 1
+	}
+
+	// an alternating test vector catches the matrices for which the iteration stalls early
+	for i := range x {
+		x[i] = 1 + float64(i)/math.Max(float64(n-1), 1)
+		if i%2 == 1 {
+			x[i] = -x[i]
+		}
+	}
+	alt := 0.0
+	for _, v := range luSolve(lu, perm, n, x, 1) {
+		alt += math.Abs(v)
+	}
+	return math.Max(est, 2*alt/float64(3*n))
+}
+
+// luConditionEstimate estimates the 1-norm condition number ||a|| * ||a^-1|| from the packed LU factors of a without
+// forming the inverse. It is infinite for singular matrices.
+func luConditionEstimate(a, lu []float64, perm []int, n int) float64 {
+	for i := 0; i < n; i++ {
+		if lu[i*n+i] == 0 {
+			return math.Inf(1)
+		}
+	}
+	c := norm1(a, n) * luInverseNorm1Estimate(lu, perm, n)
+	if math.IsNaN(c) {
+		return math.Inf(1)
+	}
+	return c
+}
+
+// solveSystem solves a * x = b for the n x nrhs row-major right-hand sides b using LU decomposition with partial
+// pivoting. numeric.ErrSingularMatrix is returned if a is singular to working precision, which is when its
+// estimated reciprocal 1-norm condition number is below the machine epsilon.
+func solveSystem(a []float64, n int, b []float64, nrhs int) ([]float64, error) {
+	err := checkFinite(a)
+	if err != nil {
+		return nil, err
+	}
+	err = checkFinite(b)
+	if err != nil {
+		return nil, err
+	}
+
+	lu, perm, _ := luDecompose(a, n)
+	if 1/luConditionEstimate(a, lu, perm, n) < machineEpsilon {
+		return nil, numeric.ErrSingularMatrix
+	}
+	x := luSolve(lu, perm, n, b, nrhs)
+	err = checkFinite(x)
+	if err != nil {
+		return nil, err
+	}
+	return x, nil
+}
@@ -0,0 +1,171 @@
+package geometry
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/tab58/v1/spatial/pkg/numeric"
+)
+
+// factors holds the row-major elements of the decompositions of a matrix.
+type factors struct {
+	q, r    []float64
+	p, l, u []float64
+	chol    []float64
+	su, sv  []float64
+	s       []float64
+}
+
+// decompose computes the decompositions of the row-major n x n matrix a with the fixed-size matrix type of order n.
+// The Cholesky factor is computed for the symmetric positive definite matrix spd.
+func decompose(t *testing.T, a, spd []float64, n int) factors {
+	t.Helper()
+	var f factors
+	var err error
+	switch n {
+	case 2:
+		m, c := &Matrix2D{}, &Matrix2D{}
+		copy(m.elements[:], a)
+		copy(c.elements[:], spd)
+		Q, R, qerr := m.QR()
+		P, L, U, lerr := m.LU()
+		C, cerr := c.Cholesky()
+		SU, S, SV, serr := m.SVD()
+		if err = firstError(qerr, lerr, cerr, serr); err == nil {
+			f = factors{Q.elements[:], R.elements[:], P.elements[:], L.elements[:], U.elements[:], C.elements[:],
+				SU.elements[:], SV.elements[:], []float64{S.X, S.Y}}
+		}
+	case 3:
+		m, c := &Matrix3D{}, &Matrix3D{}
+		copy(m.elements[:], a)
+		copy(c.elements[:], spd)
+		Q, R, qerr := m.QR()
+		P, L, U, lerr := m.LU()
+		C, cerr := c.Cholesky()
+		SU, S, SV, serr := m.SVD()
+		if err = firstError(qerr, lerr, cerr, serr); err == nil {
+			f = factors{Q.elements[:], R.elements[:], P.elements[:], L.elements[:], U.elements[:], C.elements[:],
+				SU.elements[:], SV.elements[:], []float64{S.X, S.Y, S.Z}}
+		}
+	case 4:
+		m, c := &Matrix4D{}, &Matrix4D{}
+		copy(m.elements[:], a)
+		copy(c.elements[:], spd)
+		Q, R, qerr := m.QR()
+		P, L, U, lerr := m.LU()
+		C, cerr := c.Cholesky()
+		SU, S, SV, serr := m.SVD()
+		if err = firstError(qerr, lerr, cerr, serr); err == nil {
+			f = factors{Q.elements[:], R.elements[:], P.elements[:], L.elements[:], U.elements[:], C.elements[:],
+				SU.elements[:], SV.elements[:], []float64{S.X, S.Y, S.Z, S.W}}
+		}
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+// firstError returns the first non-nil error.
+func firstError(errs ...error) error {
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// transposeElements returns the transpose of the row-major n x n matrix a.
+func transposeElements(a []float64, n int) []float64 {
+	out := make([]float64, n*n)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			out[n*j+i] = a[n*i+j]
+		}
+	}
+	return out
+}
+
+// checkTriangular checks that the row-major n x n matrix a is zero below (lower false) or above (lower true) the
+// diagonal.
+func checkTriangular(t *testing.T, name string, a []float64, n int, lower bool) {
+	t.Helper()
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if (lower && j > i || !lower && j < i) && a[n*i+j] != 0 {
+				t.Fatalf("%s: %v is not triangular", name, a)
+			}
+		}
+	}
+}
+
+func TestDecompositionReconstruction(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		for n := 2; n <= 4; n++ {
+			a := randomElements(r, n)
+			spd := multiplyElements(a, transposeElements(a, n), n)
+			for j := 0; j < n; j++ {
+				spd[n*j+j] += 1
+			}
+			f := decompose(t, a, spd, n)
+
+			checkClose(t, "Q * R", multiplyElements(f.q, f.r, n), a, 1e-14)
+			checkClose(t, "Q^T * Q", multiplyElements(transposeElements(f.q, n), f.q, n), identitySlice(n), 1e-14)
+			checkTriangular(t, "R", f.r, n, false)
+
+			checkClose(t, "P * A", multiplyElements(f.p, a, n), multiplyElements(f.l, f.u, n), 1e-14)
+			checkTriangular(t, "L", f.l, n, true)
+			checkTriangular(t, "U", f.u, n, false)
+			for j := 0; j < n; j++ {
+				if f.l[n*j+j] != 1 {
+					t.Fatalf("L = %v does not have a unit diagonal", f.l)
+				}
+			}
+
+			checkClose(t, "L * L^T", multiplyElements(f.chol, transposeElements(f.chol, n), n), spd, 1e-13)
+			checkTriangular(t, "Cholesky factor", f.chol, n, true)
+
+			us := make([]float64, n*n)
+			for j := range us {
+				us[j] = f.su[j] * f.s[j%n]
+			}
+			checkClose(t, "U * S * V^T", multiplyElements(us, transposeElements(f.sv, n), n), a, 1e-14)
+			checkClose(t, "U^T * U", multiplyElements(transposeElements(f.su, n), f.su, n), identitySlice(n), 1e-14)
+			checkClose(t, "V^T * V", multiplyElements(transposeElements(f.sv, n), f.sv, n), identitySlice(n), 1e-14)
+			for j := 1; j < n; j++ {
+				if !(f.s[j] >= 0 && f.s[j] <= f.s[j-1]) {
+					t.Fatalf("singular values %v are not non-negative and descending", f.s)
+				}
+			}
+		}
+	}
+}
+
+func TestSVDRankDeficient(t *testing.T) {
+	// the third row is the sum of the first two, so the smallest singular value is zero up to rounding
+	m := &Matrix3D{elements: [9]float64{1, 2, 3, 4, 5, 6, 5, 7, 9}}
+	U, s, V, err := m.SVD()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Z > 1e-15*s.X {
+		t.Errorf("smallest singular value %v, want about 0", s.Z)
+	}
+	u, v := U.elements[:], V.elements[:]
+	checkClose(t, "U^T * U", multiplyElements(transposeElements(u, 3), u, 3), identitySlice(3), 1e-14)
+	checkClose(t, "V^T * V", multiplyElements(transposeElements(v, 3), v, 3), identitySlice(3), 1e-14)
+
+	rank, err := m.Rank(numeric.RelativeTolerance(1e-12))
+	if err != nil || rank != 2 {
+		t.Errorf("Rank = %v, %v, want 2", rank, err)
+	}
+}
+
+func TestCholeskyNotPositiveDefinite(t *testing.T) {
+	m := &Matrix2D{elements: [4]float64{1, 2, 2, 1}}
+	if _, err := m.Cholesky(); err != numeric.ErrNotPositiveDefinite {
+		t.Errorf("Cholesky of an indefinite matrix: got %v, want %v", err, numeric.ErrNotPositiveDefinite)
+	}
+}
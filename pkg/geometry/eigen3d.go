@@ -0,0 +1,258 @@
+package geometry
+
+import (
+	"math"
+
+	"github.com/tab58/v1/spatial/pkg/numeric"
+)
+
+// maxEigenJacobiSweeps is the maximum number of sweeps of the Jacobi eigenvalue fallback.
+const maxEigenJacobiSweeps = 50
+
+// eigenResidualTol is the largest residual |A * v - lambda * v|, relative to the largest element of A, that the
+// analytic eigen-solver accepts before falling back to Jacobi rotations.
+const eigenResidualTol = 1e-13
+
+// SymmetricEigen computes the eigenvalues of the symmetric matrix in ascending order along with an orthonormal,
+// right-handed basis of the corresponding eigenvectors. Only the upper triangle of the matrix is read.
+// The eigenvalues are found analytically and checked, falling back to Jacobi rotations when the analytic solution is
+// inaccurate, such as for nearly repeated eigenvalues.
+func (m *Matrix3D) SymmetricEigen() ([3]float64, [3]*Vector3D, error) {
+	err := checkFinite(m.elements[:])
+	if err != nil {
+		return [3]float64{}, [3]*Vector3D{}, err
+	}
+
+	// scale to a unit largest element to avoid overflow in the characteristic polynomial
+	e := m.elements
+	a := [9]float64{e[0], e[1], e[2], e[1], e[4], e[5], e[2], e[5], e[8]}
+	scale := 0.0
+	for _, v := range a {
+		scale = math.Max(scale, math.Abs(v))
+	}
+	if scale == 0 {
+		return [3]float64{}, [3]*Vector3D{{X: 1}, {Y: 1}, {Z: 1}}, nil
+	}
+	for i := range a {
+		a[i] /= scale
+	}
+
+	values, vectors, ok := analyticSymmetricEigen(a)
+	if !ok {
+		values, vectors, err = jacobiSymmetricEigen(a)
+		if err != nil {
+			return [3]float64{}, [3]*Vector3D{}, err
+		}
+	}
+
+	// sort ascending and make the basis right-handed
+	for i := 1; i < 3; i++ {
+		for j := i; j > 0 && values[j] < values[j-1]; j-- {
+			values[j], values[j-1] = values[j-1], values[j]
+			vectors[j], vectors[j-1] = vectors[j-1], vectors[j]
+		}
+	}
+	c, err := vectors[0].Cross(vectors[1])
+	if err != nil {
+		return [3]float64{}, [3]*Vector3D{}, err
+	}
+	d, err := c.Dot(vectors[2])
+	if err != nil {
+		return [3]float64{}, [3]*Vector3D{}, err
+	}
+	if d < 0 {
+		vectors[2].Negate()
+	}
+	for i := range values {
+		values[i] *= scale
+	}
+	return values, vectors, nil
+}
+
+// symmetricApply computes A * v for the row-major symmetric matrix.
+func symmetricApply(a [9]float64, v *Vector3D) *Vector3D {
+	return &Vector3D{
+		X: a[0]*v.X + a[1]*v.Y + a[2]*v.Z,
+		Y: a[3]*v.X + a[4]*v.Y + a[5]*v.Z,
+		Z: a[6]*v.X + a[7]*v.Y + a[8]*v.Z,
+	}
+}
+
+// unitPerpendicular returns a unit vector perpendicular to the unit vector v.
+func unitPerpendicular(v *Vector3D) *Vector3D {
+	if math.Abs(v.X) > math.Abs(v.Y) {
+		l := math.Hypot(v.X, v.Z)
+		return &Vector3D{X: -v.Z / l, Y: 0, Z: v.X / l}
+	}
+	l := math.Hypot(v.Y, v.Z)
+	return &Vector3D{X: 0, Y: v.Z / l, Z: -v.Y / l}
+}
+
+// nullVector3D returns a unit vector spanning the null space of the rank 2 matrix A - lambda * I, taken as the largest
+// cross product of its rows, or false if the rows are too close to zero.
+func nullVector3D(a [9]float64, lambda float64) (*Vector3D, bool) {
+	r0 := &Vector3D{X: a[0] - lambda, Y: a[1], Z: a[2]}
+	r1 := &Vector3D{X: a[3], Y: a[4] - lambda, Z: a[5]}
+	r2 := &Vector3D{X: a[6], Y: a[7], Z: a[8] - lambda}
+
+	var best *Vector3D
+	bestLen := 0.0
+	for _, pair := range [3][2]*Vector3D{{r0, r1}, {r0, r2}, {r1, r2}} {
+		c, err := pair[0].Cross(pair[1])
+		if err != nil {
+			continue
+		}
+		l, err := c.Length()
+		if err == nil && l > bestLen {
+			best, bestLen = c, l
+		}
+	}
+	if best == nil {
+		return nil, false
+	}
+	err := best.Scale(1 / bestLen)
+	if err != nil {
+		return nil, false
+	}
+	return best, true
+}
+
+// analyticSymmetricEigen computes the eigen-decomposition of the scaled symmetric matrix from the trigonometric
+// solution of its characteristic polynomial, returning false if the result fails the residual check.
+func analyticSymmetricEigen(a [9]float64) ([3]float64, [3]*Vector3D, bool) {
+	p1 := a[1]*a[1] + a[2]*a[2] + a[5]*a[5]
+	if p1 == 0 {
+		return [3]float64{a[0], a[4], a[8]}, [3]*Vector3D{{X: 1}, {Y: 1}, {Z: 1}}, true
+	}
+
+	// the eigenvalues of B = (A - q * I) / p are 2 * cos(phi + 2*k*pi/3), where det(B) = 2 * cos(3 * phi)
+	q := (a[0] + a[4] + a[8]) / 3
+	d0, d1, d2 := a[0]-q, a[4]-q, a[8]-q
+	p := math.Sqrt((d0*d0 + d1*d1 + d2*d2 + 2*p1) / 6)
+	b := &Matrix3D{elements: [9]float64{d0 / p, a[1] / p, a[2] / p, a[3] / p, d1 / p, a[5] / p, a[6] / p, a[7] / p, d2 / p}}
+	r := math.Max(-1, math.Min(1, b.Determinant()/2))
+	phi := math.Acos(r) / 3
+	hi := q + 2*p*math.Cos(phi)
+	lo := q + 2*p*math.Cos(phi+2*math.Pi/3)
+	mid := 3*q - hi - lo
+
+	// find the eigenvector of the eigenvalue farthest from the others first, then the second within its
+	// orthogonal complement, which stays well defined when the other two eigenvalues are repeated
+	l1, l2 := hi, mid
+	if hi-mid < mid-lo {
+		l1 = lo
+	}
+	v1, ok := nullVector3D(a, l1)
+	if !ok {
+		return [3]float64{}, [3]*Vector3D{}, false
+	}
+	u := unitPerpendicular(v1)
+	w, err := v1.Cross(u)
+	if err != nil {
+		return [3]float64{}, [3]*Vector3D{}, false
+	}
+
+	// null vector of the 2x2 projection of A - l2 * I onto span(u, w)
+	au, aw := symmetricApply(a, u), symmetricApply(a, w)
+	m00, _ := u.Dot(au)
+	m01, _ := u.Dot(aw)
+	m11, _ := w.Dot(aw)
+	m00 -= l2
+	m11 -= l2
+	x, y := m01, -m00
+	if math.Hypot(m11, m01) > math.Hypot(x, y) {
+		x, y = m11, -m01
+	}
+	if x == 0 && y == 0 {
+		x = 1
+	}
+	v2 := &Vector3D{X: x*u.X + y*w.X, Y: x*u.Y + y*w.Y, Z: x*u.Z + y*w.Z}
+	err = v2.Normalize()
+	if err != nil {
+		return [3]float64{}, [3]*Vector3D{}, false
+	}
+	v3, err := v1.Cross(v2)
+	if err != nil {
+		return [3]float64{}, [3]*Vector3D{}, false
+	}
+
+	// refine the eigenvalues by Rayleigh quotients and check the residuals
+	vectors := [3]*Vector3D{v1, v2, v3}
+	values := [3]float64{}
+	for i, v := range vectors {
+		av := symmetricApply(a, v)
+		lambda, _ := v.Dot(av)
+		res := &Vector3D{X: av.X - lambda*v.X, Y: av.Y - lambda*v.Y, Z: av.Z - lambda*v.Z}
+		l, err := res.Length()
+		if err != nil || l > eigenResidualTol {
+			return [3]float64{}, [3]*Vector3D{}, false
+		}
+		values[i] = lambda
+	}
+	return values, vectors, true
+}
+
+// jacobiSymmetricEigen computes the eigen-decomposition of the scaled symmetric matrix by cyclic Jacobi rotations.
+func jacobiSymmetricEigen(a [9]float64) ([3]float64, [3]*Vector3D, error) {
+	v := [9]float64{1, 0, 0, 0, 1, 0, 0, 0, 1}
+
+	// the rotations preserve the Frobenius norm, so the convergence threshold is fixed up front
+	norm2 := 0.0
+	for _, x := range a {
+		norm2 += x * x
+	}
+	for sweep := 0; sweep < maxEigenJacobiSweeps && !isNearlyDiagonal(a, norm2); sweep++ {
+		for _, pq := range [3][2]int{{0, 1}, {0, 2}, {1, 2}} {
+			p, q := pq[0], pq[1]
+			apq := a[3*p+q]
+			if apq == 0 {
+				continue
+			}
+
+			// rotation annihilating a[p][q]
+			theta := (a[3*q+q] - a[3*p+p]) / (2 * apq)
+			t := 1 / (math.Abs(theta) + math.Hypot(1, theta))
+			if theta < 0 {
+				t = -t
+			}
+			c := 1 / math.Hypot(1, t)
+			s := t * c
+
+			// A = J^T * A * J, V = V * J
+			for k := 0; k < 3; k++ {
+				akp, akq := a[3*k+p], a[3*k+q]
+				a[3*k+p] = c*akp - s*akq
+				a[3*k+q] = s*akp + c*akq
+			}
+			for k := 0; k < 3; k++ {
+				apk, aqk := a[3*p+k], a[3*q+k]
+				a[3*p+k] = c*apk - s*aqk
+				a[3*q+k] = s*apk + c*aqk
+			}
+			a[3*p+q], a[3*q+p] = 0, 0
+			for k := 0; k < 3; k++ {
+				vkp, vkq := v[3*k+p], v[3*k+q]
+				v[3*k+p] = c*vkp - s*vkq
+				v[3*k+q] = s*vkp + c*vkq
+			}
+		}
+	}
+	if !isNearlyDiagonal(a, norm2) {
+		return [3]float64{}, [3]*Vector3D{}, numeric.ErrNotConverged
+	}
+
+	values := [3]float64{a[0], a[4], a[8]}
+	vectors := [3]*Vector3D{
+		{X: v[0], Y: v[3], Z: v[6]},
+		{X: v[1], Y: v[4], Z: v[7]},
+		{X: v[2], Y: v[5], Z: v[8]},
+	}
+	return values, vectors, nil
+}
+
+// isNearlyDiagonal reports whether the off-diagonal elements of the symmetric matrix are negligible, which is when
+// their squares sum to at most the squared machine epsilon times the squared Frobenius norm norm2.
+func isNearlyDiagonal(a [9]float64, norm2 float64) bool {
+	off := a[1]*a[1] + a[2]*a[2] + a[5]*a[5]
+	return off <= machineEpsilon*machineEpsilon*norm2
+}
@@ -0,0 +1,115 @@
+package geometry
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// symmetricWithEigenvalues returns the row-major elements of R * diag(values) * R^T for a random rotation R.
+func symmetricWithEigenvalues(r *rand.Rand, values [3]float64) [9]float64 {
+	m, err := randomUnitQuaternion(r).ToMatrix3D()
+	if err != nil {
+		panic(err)
+	}
+	rot := m.elements[:]
+	d := make([]float64, 9)
+	for i := range d {
+		d[i] = rot[i] * values[i%3]
+	}
+	a := [9]float64{}
+	copy(a[:], multiplyElements(d, transposeElements(rot, 3), 3))
+	return a
+}
+
+// checkEigen checks that the values and vectors are an ascending, right-handed orthonormal eigen-decomposition of the
+// symmetric matrix a to within tol relative to its largest eigenvalue.
+func checkEigen(t *testing.T, a [9]float64, values [3]float64, vectors [3]*Vector3D, tol float64) {
+	t.Helper()
+	scale := math.Max(math.Abs(values[0]), math.Abs(values[2]))
+	for i, v := range vectors {
+		av := symmetricApply(a, v)
+		checkClose(t, "A * v", vectorSlice(av), []float64{values[i] * v.X, values[i] * v.Y, values[i] * v.Z}, tol*scale)
+		for j, w := range vectors {
+			d, err := v.Dot(w)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := 0.0
+			if i == j {
+				want = 1
+			}
+			checkClose(t, "v . w", []float64{d}, []float64{want}, 1e-14)
+		}
+	}
+	if !(values[0] <= values[1] && values[1] <= values[2]) {
+		t.Fatalf("eigenvalues %v are not ascending", values)
+	}
+	c, err := vectors[0].Cross(vectors[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkClose(t, "v0 x v1", vectorSlice(c), vectorSlice(vectors[2]), 1e-14)
+}
+
+func TestSymmetricEigen(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		values := [3]float64{r.NormFloat64(), r.NormFloat64(), r.NormFloat64()}
+		switch i % 4 {
+		case 1:
+			// repeated eigenvalues
+			values[1] = values[0]
+		case 2:
+			// nearly repeated eigenvalues
+			values[1] = values[0] * (1 + 1e-9)
+		case 3:
+			// widely scaled matrices
+			s := math.Pow(10, float64(r.Intn(400)-200))
+			values = [3]float64{s * values[0], s * values[1], s * values[2]}
+		}
+		a := symmetricWithEigenvalues(r, values)
+		m := &Matrix3D{elements: a}
+		got, vectors, err := m.SymmetricEigen()
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkEigen(t, a, got, vectors, 1e-13)
+	}
+
+	got, vectors, err := (&Matrix3D{}).SymmetricEigen()
+	if err != nil || got != [3]float64{} {
+		t.Errorf("SymmetricEigen(0) = %v, %v", got, err)
+	}
+	checkEigen(t, [9]float64{}, got, vectors, 0)
+}
+
+func TestJacobiSymmetricEigen(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	for i := 0; i < 1000; i++ {
+		values := [3]float64{r.NormFloat64(), r.NormFloat64(), r.NormFloat64()}
+		if i%2 == 1 {
+			values[2] = values[1]
+		}
+		a := symmetricWithEigenvalues(r, values)
+		got, vectors, err := jacobiSymmetricEigen(a)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for j, v := range vectors {
+			av := symmetricApply(a, v)
+			checkClose(t, "A * v", vectorSlice(av), []float64{got[j] * v.X, got[j] * v.Y, got[j] * v.Z}, 1e-14)
+		}
+	}
+
+	// tiny off-diagonal elements are relative to the norm of the matrix, not absolute
+	a := [9]float64{1e-30, 1e-31, 0, 1e-31, 2e-30, 0, 0, 0, 3e-30}
+	got, vectors, err := jacobiSymmetricEigen(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for j, v := range vectors {
+		av := symmetricApply(a, v)
+		checkClose(t, "A * v", vectorSlice(av), []float64{got[j] * v.X, got[j] * v.Y, got[j] * v.Z}, 1e-44)
+	}
+}
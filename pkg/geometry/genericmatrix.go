@@ -0,0 +1,635 @@
+package geometry
+
+import (
+	"math"
+
+	"github.com/tab58/v1/spatial/pkg/numeric"
+)
+
+// Mat2 is a row-major representation of a 2x2 matrix with elements of type T. It has the element access, arithmetic,
+// products, Transpose, Determinant and Invert of Matrix2D, but none of its decompositions or matrix functions; use
+// ToMatrix2D for those.
+type Mat2[T Float] struct {
+	elements [4]T
+}
+
+// Mat3 is a row-major representation of a 3x3 matrix with elements of type T, with the same subset of the operations
+// of Matrix3D as Mat2 has of Matrix2D. The eigen-decomposition and rotation tests also need ToMatrix3D.
+type Mat3[T Float] struct {
+	elements [9]T
+}
+
+// Mat4 is a row-major representation of a 4x4 matrix with elements of type T, with the same subset of the operations
+// of Matrix4D as Mat2 has of Matrix2D.
+type Mat4[T Float] struct {
+	elements [16]T
+}
+
+// Matrix2F is a 2x2 matrix with float32 elements.
+type Matrix2F = Mat2[float32]
+
+// Matrix3F is a 3x3 matrix with float32 elements.
+type Matrix3F = Mat3[float32]
+
+// Matrix4F is a 4x4 matrix with float32 elements.
+type Matrix4F = Mat4[float32]
+
+// Mat2FromMatrix2D converts the matrix to a Mat2 with elements of type T.
+func Mat2FromMatrix2D[T Float](m *Matrix2D) *Mat2[T] {
+	out := &Mat2[T]{}
+	convertElements(out.elements[:], m.elements[:])
+	return out
+}
+
+// Mat3FromMatrix3D converts the matrix to a Mat3 with elements of type T.
+func Mat3FromMatrix3D[T Float](m *Matrix3D) *Mat3[T] {
+	out := &Mat3[T]{}
+	convertElements(out.elements[:], m.elements[:])
+	return out
+}
+
+// Mat4FromMatrix4D converts the matrix to a Mat4 with elements of type T.
+func Mat4FromMatrix4D[T Float](m *Matrix4D) *Mat4[T] {
+	out := &Mat4[T]{}
+	convertElements(out.elements[:], m.elements[:])
+	return out
+}
+
+// ConvertMat2 converts the matrix to a Mat2 with elements of type U.
+func ConvertMat2[U, T Float](m *Mat2[T]) *Mat2[U] {
+	out := &Mat2[U]{}
+	convertElements(out.elements[:], m.elements[:])
+	return out
+}
+
+// ConvertMat3 converts the matrix to a Mat3 with elements of type U.
+func ConvertMat3[U, T Float](m *Mat3[T]) *Mat3[U] {
+	out := &Mat3[U]{}
+	convertElements(out.elements[:], m.elements[:])
+	return out
+}
+
+// ConvertMat4 converts the matrix to a Mat4 with elements of type U.
+func ConvertMat4[U, T Float](m *Mat4[T]) *Mat4[U] {
+	out := &Mat4[U]{}
+	convertElements(out.elements[:], m.elements[:])
+	return out
+}
+
+// ToMatrix2D converts the matrix to a Matrix2D.
+func (m *Mat2[T]) ToMatrix2D() *Matrix2D {
+	out := &Matrix2D{}
+	convertElements(out.elements[:], m.elements[:])
+	return out
+}
+
+// ToMatrix3D converts the matrix to a Matrix3D.
+func (m *Mat3[T]) ToMatrix3D() *Matrix3D {
+	out := &Matrix3D{}
+	convertElements(out.elements[:], m.elements[:])
+	return out
+}
+
+// ToMatrix4D converts the matrix to a Matrix4D.
+func (m *Mat4[T]) ToMatrix4D() *Matrix4D {
+	out := &Matrix4D{}
+	convertElements(out.elements[:], m.elements[:])
+	return out
+}
+
+// convertElements copies the elements of src to dst, converting them to the element type of dst.
+func convertElements[U, T Float](dst []U, src []T) {
+	for i, v := range src {
+		dst[i] = U(v)
+	}
+}
+
+// Rows returns the number of rows in the matrix.
+func (m *Mat2[T]) Rows() uint { return 2 }
+
+// Cols returns the number of columns in the matrix.
+func (m *Mat2[T]) Cols() uint { return 2 }
+
+// Rows returns the number of rows in the matrix.
+func (m *Mat3[T]) Rows() uint { return 3 }
+
+// Cols returns the number of columns in the matrix.
+func (m *Mat3[T]) Cols() uint { return 3 }
+
+// Rows returns the number of rows in the matrix.
+func (m *Mat4[T]) Rows() uint { return 4 }
+
+// Cols returns the number of columns in the matrix.
+func (m *Mat4[T]) Cols() uint { return 4 }
+
+// Identity sets the matrix to the identity matrix.
+func (m *Mat2[T]) Identity() {
+	m.elements = [4]T{1, 0, 0, 1}
+}
+
+// Identity sets the matrix to the identity matrix.
+func (m *Mat3[T]) Identity() {
+	m.elements = [9]T{1, 0, 0, 0, 1, 0, 0, 0, 1}
+}
+
+// Identity sets the matrix to the identity matrix.
+func (m *Mat4[T]) Identity() {
+	m.elements = [16]T{1, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1}
+}
+
+// Clone returns a deep copy of the matrix.
+func (m *Mat2[T]) Clone() *Mat2[T] {
+	return &Mat2[T]{elements: m.elements}
+}
+
+// Clone returns a deep copy of the matrix.
+func (m *Mat3[T]) Clone() *Mat3[T] {
+	return &Mat3[T]{elements: m.elements}
+}
+
+// Clone returns a deep copy of the matrix.
+func (m *Mat4[T]) Clone() *Mat4[T] {
+	return &Mat4[T]{elements: m.elements}
+}
+
+// Copy copies the elements of the given matrix to this one.
+func (m *Mat2[T]) Copy(mat *Mat2[T]) {
+	m.elements = mat.elements
+}
+
+// Copy copies the elements of the given matrix to this one.
+func (m *Mat3[T]) Copy(mat *Mat3[T]) {
+	m.elements = mat.elements
+}
+
+// Copy copies the elements of the given matrix to this one.
+func (m *Mat4[T]) Copy(mat *Mat4[T]) {
+	m.elements = mat.elements
+}
+
+// Elements returns the row-major elements of the matrix.
+func (m *Mat2[T]) Elements() [4]T {
+	return m.elements
+}
+
+// Elements returns the row-major elements of the matrix.
+func (m *Mat3[T]) Elements() [9]T {
+	return m.elements
+}
+
+// Elements returns the row-major elements of the matrix.
+func (m *Mat4[T]) Elements() [16]T {
+	return m.elements
+}
+
+// SetElements sets the row-major elements of the matrix.
+func (m *Mat2[T]) SetElements(elements [4]T) {
+	m.elements = elements
+}
+
+// SetElements sets the row-major elements of the matrix.
+func (m *Mat3[T]) SetElements(elements [9]T) {
+	m.elements = elements
+}
+
+// SetElements sets the row-major elements of the matrix.
+func (m *Mat4[T]) SetElements(elements [16]T) {
+	m.elements = elements
+}
+
+// ElementAt returns the value of the element at the given indices.
+func (m *Mat2[T]) ElementAt(i, j uint) (T, error) {
+	if i >= 2 || j >= 2 {
+		return 0, numeric.ErrMatrixOutOfRange
+	}
+	return m.elements[i*2+j], nil
+}
+
+// ElementAt returns the value of the element at the given indices.
+func (m *Mat3[T]) ElementAt(i, j uint) (T, error) {
+	if i >= 3 || j >= 3 {
+		return 0, numeric.ErrMatrixOutOfRange
+	}
+	return m.elements[i*3+j], nil
+}
+
+// ElementAt returns the value of the element at the given indices.
+func (m *Mat4[T]) ElementAt(i, j uint) (T, error) {
+	if i >= 4 || j >= 4 {
+		return 0, numeric.ErrMatrixOutOfRange
+	}
+	return m.elements[i*4+j], nil
+}
+
+// SetElementAt sets the value of the element at the given indices.
+func (m *Mat2[T]) SetElementAt(i, j uint, value T) error {
+	if i >= 2 || j >= 2 {
+		return numeric.ErrMatrixOutOfRange
+	}
+	m.elements[i*2+j] = value
+	return nil
+}
+
+// SetElementAt sets the value of the element at the given indices.
+func (m *Mat3[T]) SetElementAt(i, j uint, value T) error {
+	if i >= 3 || j >= 3 {
+		return numeric.ErrMatrixOutOfRange
+	}
+	m.elements[i*3+j] = value
+	return nil
+}
+
+// SetElementAt sets the value of the element at the given indices.
+func (m *Mat4[T]) SetElementAt(i, j uint, value T) error {
+	if i >= 4 || j >= 4 {
+		return numeric.ErrMatrixOutOfRange
+	}
+	m.elements[i*4+j] = value
+	return nil
+}
+
+// multiplyGeneric computes a * b for row-major matrices of order n, writing the product to out.
+func multiplyGeneric[T Float](out, a, b []T, n int) error {
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			var s T
+			for k := 0; k < n; k++ {
+				s += a[i*n+k] * b[k*n+j]
+			}
+			out[i*n+j] = s
+		}
+	}
+	if anyOverflow(out...) {
+		return numeric.ErrOverflow
+	}
+	return nil
+}
+
+// Premultiply left-multiplies the given matrix with this one, m = mat * m.
+func (m *Mat2[T]) Premultiply(mat *Mat2[T]) error {
+	var out [4]T
+	err := multiplyGeneric(out[:], mat.elements[:], m.elements[:], 2)
+	if err != nil {
+		return err
+	}
+	m.elements = out
+	return nil
+}
+
+// Premultiply left-multiplies the given matrix with this one, m = mat * m.
+func (m *Mat3[T]) Premultiply(mat *Mat3[T]) error {
+	var out [9]T
+	err := multiplyGeneric(out[:], mat.elements[:], m.elements[:], 3)
+	if err != nil {
+		return err
+	}
+	m.elements = out
+	return nil
+}
+
+// Premultiply left-multiplies the given matrix with this one, m = mat * m.
+func (m *Mat4[T]) Premultiply(mat *Mat4[T]) error {
+	var out [16]T
+	err := multiplyGeneric(out[:], mat.elements[:], m.elements[:], 4)
+	if err != nil {
+		return err
+	}
+	m.elements = out
+	return nil
+}
+
+// Postmultiply right-multiplies the given matrix with this one, m = m * mat.
+func (m *Mat2[T]) Postmultiply(mat *Mat2[T]) error {
+	var out [4]T
+	err := multiplyGeneric(out[:], m.elements[:], mat.elements[:], 2)
+	if err != nil {
+		return err
+	}
+	m.elements = out
+	return nil
+}
+
+// Postmultiply right-multiplies the given matrix with this one, m = m * mat.
+func (m *Mat3[T]) Postmultiply(mat *Mat3[T]) error {
+	var out [9]T
+	err := multiplyGeneric(out[:], m.elements[:], mat.elements[:], 3)
+	if err != nil {
+		return err
+	}
+	m.elements = out
+	return nil
+}
+
+// Postmultiply right-multiplies the given matrix with this one, m = m * mat.
+func (m *Mat4[T]) Postmultiply(mat *Mat4[T]) error {
+	var out [16]T
+	err := multiplyGeneric(out[:], m.elements[:], mat.elements[:], 4)
+	if err != nil {
+		return err
+	}
+	m.elements = out
+	return nil
+}
+
+// transposeGeneric transposes the row-major matrix of order n in-place.
+func transposeGeneric[T Float](a []T, n int) {
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			a[i*n+j], a[j*n+i] = a[j*n+i], a[i*n+j]
+		}
+	}
+}
+
+// Transpose transposes the matrix in-place.
+func (m *Mat2[T]) Transpose() {
+	transposeGeneric(m.elements[:], 2)
+}
+
+// Transpose transposes the matrix in-place.
+func (m *Mat3[T]) Transpose() {
+	transposeGeneric(m.elements[:], 3)
+}
+
+// Transpose transposes the matrix in-place.
+func (m *Mat4[T]) Transpose() {
+	transposeGeneric(m.elements[:], 4)
+}
+
+// Determinant calculates the determinant of the matrix.
+func (m *Mat2[T]) Determinant() T {
+	a := m.elements
+	return a[0]*a[3] - a[1]*a[2]
+}
+
+// Determinant calculates the determinant of the matrix.
+func (m *Mat3[T]) Determinant() T {
+	a := m.elements
+	a00, a01, a02 := a[0], a[1], a[2]
+	a10, a11, a12 := a[3], a[4], a[5]
+	a20, a21, a22 := a[6], a[7], a[8]
+	return a00*(a22*a11-a12*a21) + a01*(-a22*a10+a12*a20) + a02*(a21*a10-a11*a20)
+}
+
+// Determinant calculates the determinant of the matrix.
+func (m *Mat4[T]) Determinant() T {
+	a := m.elements
+	a00, a01, a02, a03 := a[0], a[1], a[2], a[3]
+	a10, a11, a12, a13 := a[4], a[5], a[6], a[7]
+	a20, a21, a22, a23 := a[8], a[9], a[10], a[11]
+	a30, a31, a32, a33 := a[12], a[13], a[14], a[15]
+
+	b0 := a00*a11 - a01*a10
+	b1 := a00*a12 - a02*a10
+	b2 := a01*a12 - a02*a11
+	b3 := a20*a31 - a21*a30
+	b4 := a20*a32 - a22*a30
+	b5 := a21*a32 - a22*a31
+	b6 := a00*b5 - a01*b4 + a02*b3
+	b7 := a10*b5 - a11*b4 + a12*b3
+	b8 := a20*b2 - a21*b1 + a22*b0
+	b9 := a30*b2 - a31*b1 + a32*b0
+	return a13*b6 - a03*b7 + a33*b8 - a23*b9
+}
+
+// norm1Generic computes the 1-norm of the row-major matrix of order n, the largest absolute column sum.
+func norm1Generic[T Float](a []T, n int) float64 {
+	norm := 0.0
+	for j := 0; j < n; j++ {
+		s := 0.0
+		for i := 0; i < n; i++ {
+			s += math.Abs(float64(a[i*n+j]))
+		}
+		norm = math.Max(norm, s)
+	}
+	return norm
+}
+
+// checkFiniteGeneric returns an error if any element is NaN or infinite.
+func checkFiniteGeneric[T Float](a []T) error {
+	for _, v := range a {
+		if math.IsNaN(float64(v)) {
+			return numeric.ErrNaN
+		}
+	}
+	if anyOverflow(a...) {
+		return numeric.ErrOverflow
+	}
+	return nil
+}
+
+// checkDeterminantGeneric returns an error if the determinant computed by cofactor expansion is zero or overflowed.
+func checkDeterminantGeneric[T Float](det T) error {
+	if det == 0 {
+		return numeric.ErrSingularMatrix
+	}
+	if anyOverflow(det) || math.IsNaN(float64(det)) {
+		return numeric.ErrOverflow
+	}
+	return nil
+}
+
+// setInverseGeneric copies the inverse of the row-major matrix a of order n, computed by cofactor expansion, to a.
+// numeric.ErrSingularMatrix is returned if the matrix is singular to the working precision of T, which is when its
+// reciprocal 1-norm condition number is below the machine epsilon of T.
+func setInverseGeneric[T Float](a, inv []T, n int) error {
+	if anyOverflow(inv...) {
+		return numeric.ErrOverflow
+	}
+	rcond := 1 / (norm1Generic(a, n) * norm1Generic(inv, n))
+	// the negated comparison also rejects the NaN left by cancelling overflowed cofactors
+	if !(rcond >= epsilon[T]()) {
+		return numeric.ErrSingularMatrix
+	}
+	copy(a, inv)
+	return nil
+}
+
+// Invert inverts this matrix in-place.
+func (m *Mat2[T]) Invert() error {
+	a := m.elements
+	err := checkFiniteGeneric(a[:])
+	if err != nil {
+		return err
+	}
+	det := a[0]*a[3] - a[2]*a[1]
+	err = checkDeterminantGeneric(det)
+	if err != nil {
+		return err
+	}
+	det = 1 / det
+
+	out := [4]T{a[3] * det, -a[1] * det, -a[2] * det, a[0] * det}
+	return setInverseGeneric(m.elements[:], out[:], 2)
+}
+
+// Invert inverts this matrix in-place.
+func (m *Mat3[T]) Invert() error {
+	a := m.elements
+	err := checkFiniteGeneric(a[:])
+	if err != nil {
+		return err
+	}
+	a00, a01, a02 := a[0], a[1], a[2]
+	a10, a11, a12 := a[3], a[4], a[5]
+	a20, a21, a22 := a[6], a[7], a[8]
+
+	b01 := a22*a11 - a12*a21
+	b11 := -a22*a10 + a12*a20
+	b21 := a21*a10 - a11*a20
+	det := a00*b01 + a01*b11 + a02*b21
+	err = checkDeterminantGeneric(det)
+	if err != nil {
+		return err
+	}
+	det = 1 / det
+
+	out := [9]T{}
+	out[0] = b01 * det
+	out[1] = (-a22*a01 + a02*a21) * det
+	out[2] = (a12*a01 - a02*a11) * det
+	out[3] = b11 * det
+	out[4] = (a22*a00 - a02*a20) * det
+	out[5] = (-a12*a00 + a02*a10) * det
+	out[6] = b21 * det
+	out[7] = (-a21*a00 + a01*a20) * det
+	out[8] = (a11*a00 - a01*a10) * det
+	return setInverseGeneric(m.elements[:], out[:], 3)
+}
+
+// Invert inverts this matrix in-place.
+func (m *Mat4[T]) Invert() error {
+	a := m.elements
+	err := checkFiniteGeneric(a[:])
+	if err != nil {
+		return err
+	}
+	a00, a01, a02, a03 := a[0], a[1], a[2], a[3]
+	a10, a11, a12, a13 := a[4], a[5], a[6], a[7]
+	a20, a21, a22, a23 := a[8], a[9], a[10], a[11]
+	a30, a31, a32, a33 := a[12], a[13], a[14], a[15]
+
+	b00 := a00*a11 - a01*a10
+	b01 := a00*a12 - a02*a10
+	b02 := a00*a13 - a03*a10
+	b03 := a01*a12 - a02*a11
+	b04 := a01*a13 - a03*a11
+	b05 := a02*a13 - a03*a12
+	b06 := a20*a31 - a21*a30
+	b07 := a20*a32 - a22*a30
+	b08 := a20*a33 - a23*a30
+	b09 := a21*a32 - a22*a31
+	b10 := a21*a33 - a23*a31
+	b11 := a22*a33 - a23*a32
+
+	det := b00*b11 - b01*b10 + b02*b09 + b03*b08 - b04*b07 + b05*b06
+	err = checkDeterminantGeneric(det)
+	if err != nil {
+		return err
+	}
+	det = 1 / det
+
+	out := [16]T{}
+	out[0] = (a11*b11 - a12*b10 + a13*b09) * det
+	out[1] = (a02*b10 - a01*b11 - a03*b09) * det
+	out[2] = (a31*b05 - a32*b04 + a33*b03) * det
+	out[3] = (a22*b04 - a21*b05 - a23*b03) * det
+	out[4] = (a12*b08 - a10*b11 - a13*b07) * det
+	out[5] = (a00*b11 - a02*b08 + a03*b07) * det
+	out[6] = (a32*b02 - a30*b05 - a33*b01) * det
+	out[7] = (a20*b05 - a22*b02 + a23*b01) * det
+	out[8] = (a10*b10 - a11*b08 + a13*b06) * det
+	out[9] = (a01*b08 - a00*b10 - a03*b06) * det
+	out[10] = (a30*b04 - a31*b02 + a33*b00) * det
+	out[11] = (a21*b02 - a20*b04 - a23*b00) * det
+	out[12] = (a11*b07 - a10*b09 - a12*b06) * det
+	out[13] = (a00*b09 - a01*b07 + a02*b06) * det
+	out[14] = (a31*b01 - a30*b03 - a32*b00) * det
+	out[15] = (a20*b03 - a21*b01 + a22*b00) * det
+	return setInverseGeneric(m.elements[:], out[:], 4)
+}
+
+// scaleGeneric multiplies the elements by the given scalar, leaving them unchanged on overflow.
+func scaleGeneric[T Float](a []T, z T) error {
+	out := make([]T, len(a))
+	for i, v := range a {
+		out[i] = v * z
+	}
+	if anyOverflow(out...) {
+		return numeric.ErrOverflow
+	}
+	copy(a, out)
+	return nil
+}
+
+// Scale multiplies the elements of the matrix by the given scalar.
+func (m *Mat2[T]) Scale(z T) error {
+	return scaleGeneric(m.elements[:], z)
+}
+
+// Scale multiplies the elements of the matrix by the given scalar.
+func (m *Mat3[T]) Scale(z T) error {
+	return scaleGeneric(m.elements[:], z)
+}
+
+// Scale multiplies the elements of the matrix by the given scalar.
+func (m *Mat4[T]) Scale(z T) error {
+	return scaleGeneric(m.elements[:], z)
+}
+
+// addScaledGeneric adds f * b to a, leaving a unchanged on overflow.
+func addScaledGeneric[T Float](a, b []T, f T) error {
+	out := make([]T, len(a))
+	for i, v := range a {
+		out[i] = v + f*b[i]
+	}
+	if anyOverflow(out...) {
+		return numeric.ErrOverflow
+	}
+	copy(a, out)
+	return nil
+}
+
+// Add adds the elements of the given matrix to the elements of this matrix.
+func (m *Mat2[T]) Add(mat *Mat2[T]) error {
+	return addScaledGeneric(m.elements[:], mat.elements[:], 1)
+}
+
+// Add adds the elements of the given matrix to the elements of this matrix.
+func (m *Mat3[T]) Add(mat *Mat3[T]) error {
+	return addScaledGeneric(m.elements[:], mat.elements[:], 1)
+}
+
+// Add adds the elements of the given matrix to the elements of this matrix.
+func (m *Mat4[T]) Add(mat *Mat4[T]) error {
+	return addScaledGeneric(m.elements[:], mat.elements[:], 1)
+}
+
+// Sub subtracts the elements of the given matrix from the elements of this matrix.
+func (m *Mat2[T]) Sub(mat *Mat2[T]) error {
+	return addScaledGeneric(m.elements[:], mat.elements[:], -1)
+}
+
+// Sub subtracts the elements of the given matrix from the elements of this matrix.
+func (m *Mat3[T]) Sub(mat *Mat3[T]) error {
+	return addScaledGeneric(m.elements[:], mat.elements[:], -1)
+}
+
+// Sub subtracts the elements of the given matrix from the elements of this matrix.
+func (m *Mat4[T]) Sub(mat *Mat4[T]) error {
+	return addScaledGeneric(m.elements[:], mat.elements[:], -1)
+}
+
+// IsEqualTo returns true if the matrix elements are equal within a tolerance of each other, false if not.
+func (m *Mat2[T]) IsEqualTo(mat *Mat2[T], tol numeric.Tolerance) (bool, error) {
+	return genericIsEqual(m.elements[:], mat.elements[:], tol)
+}
+
+// IsEqualTo returns true if the matrix elements are equal within a tolerance of each other, false if not.
+func (m *Mat3[T]) IsEqualTo(mat *Mat3[T], tol numeric.Tolerance) (bool, error) {
+	return genericIsEqual(m.elements[:], mat.elements[:], tol)
+}
+
+// IsEqualTo returns true if the matrix elements are equal within a tolerance of each other, false if not.
+func (m *Mat4[T]) IsEqualTo(mat *Mat4[T], tol numeric.Tolerance) (bool, error) {
+	return genericIsEqual(m.elements[:], mat.elements[:], tol)
+}
@@ -0,0 +1,56 @@
+package geometry
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestMatPrecisionRoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		m := &Matrix4D{}
+		copy(m.elements[:], randomElements(r, 4))
+
+		// narrowing rounds each element to float32, and widening back is exact
+		f := Mat4FromMatrix4D[float32](m)
+		got := f.ToMatrix4D()
+		for j, e := range got.elements {
+			if !(math.Abs(e-m.elements[j]) <= math.Abs(m.elements[j])*float32Eps/2) {
+				t.Fatalf("ToMatrix4D(Mat4FromMatrix4D[float32](%v)) = %v", m.elements, got.elements)
+			}
+		}
+		if back := Mat4FromMatrix4D[float32](got); back.Elements() != f.Elements() {
+			t.Fatalf("float32 -> float64 -> float32 changed %v to %v", f.Elements(), back.Elements())
+		}
+		if back := ConvertMat4[float32](ConvertMat4[float64](f)); back.Elements() != f.Elements() {
+			t.Fatalf("ConvertMat4 round trip changed %v to %v", f.Elements(), back.Elements())
+		}
+
+		// the float32 inverse agrees with the float64 inverse of the rounded matrix to float32 precision
+		cond, err := got.ConditionNumber()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cond > 1e3 {
+			continue
+		}
+		if err := f.Invert(); err != nil {
+			t.Fatal(err)
+		}
+		if err := got.Invert(); err != nil {
+			t.Fatal(err)
+		}
+		inv := f.ToMatrix4D()
+		checkClose(t, "float32 Invert", inv.elements[:], got.elements[:], 16*float32Eps*cond*maxAbs(got.elements[:]))
+	}
+
+	m2 := &Matrix2D{elements: [4]float64{0.1, 2, -3, 1e-300}}
+	if got := Mat2FromMatrix2D[float64](m2).ToMatrix2D(); got.Elements() != m2.Elements() {
+		t.Errorf("Mat2FromMatrix2D[float64] round trip = %v, want %v", got.Elements(), m2.Elements())
+	}
+	m3 := &Matrix3D{elements: [9]float64{1, 2, 3, 4, 5, 6, 7, 8, 10}}
+	if got := ConvertMat3[float64](Mat3FromMatrix3D[float32](m3)).ToMatrix3D(); got.Elements() != m3.Elements() {
+		t.Errorf("integer elements changed through float32 to %v, want %v", got.Elements(), m3.Elements())
+	}
+}
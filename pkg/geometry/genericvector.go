@@ -0,0 +1,476 @@
+package geometry
+
+import (
+	"math"
+
+	"github.com/tab58/v1/spatial/pkg/numeric"
+)
+
+// Float is the set of element types of the generic vector and matrix types. The generic types cover the core
+// arithmetic of their float64 counterparts for storage and rendering paths; anything else goes through a conversion.
+type Float interface {
+	~float32 | ~float64
+}
+
+// epsilon returns the machine epsilon of the element type.
+func epsilon[T Float]() float64 {
+	// the explicit conversion rounds the sum to the precision of T
+	if T(T(1)+T(0x1p-30)) == 1 {
+		return 0x1p-23
+	}
+	return 0x1p-52
+}
+
+// anyOverflow returns true if any of the values has overflowed, false if not.
+func anyOverflow[T Float](values ...T) bool {
+	for _, v := range values {
+		if numeric.IsOverflow(float64(v)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Vec2 is a representation of a vector in 2 dimensions with elements of type T. Unlike Vector2D, it has no angle,
+// parallelism or perpendicularity queries, rotation, Slerp, reader/writer interfaces or point type; use ToVector2D.
+type Vec2[T Float] struct {
+	X T
+	Y T
+}
+
+// Vec3 is a representation of a vector in 3 dimensions with elements of type T. Unlike Vector3D, it has no AngleTo,
+// IsParallelTo or IsPerpendicularTo, extended-precision products, rotation, Slerp, reader/writer interfaces or point
+// type; use ToVector3D.
+type Vec3[T Float] struct {
+	X T
+	Y T
+	Z T
+}
+
+// Vec4 is a representation of a vector in 4 dimensions with elements of type T. Unlike Vector4D, it has no AngleTo,
+// IsParallelTo or IsPerpendicularTo, projection to 3D or reader/writer interfaces; use ToVector4D.
+type Vec4[T Float] struct {
+	X T
+	Y T
+	Z T
+	W T
+}
+
+// Vector2F is a 2D vector with float32 elements.
+type Vector2F = Vec2[float32]
+
+// Vector3F is a 3D vector with float32 elements.
+type Vector3F = Vec3[float32]
+
+// Vector4F is a 4D vector with float32 elements.
+type Vector4F = Vec4[float32]
+
+// Vec2FromVector2D converts the vector to a Vec2 with elements of type T.
+func Vec2FromVector2D[T Float](v Vector2DReader) *Vec2[T] {
+	x, y := v.GetComponents()
+	return &Vec2[T]{X: T(x), Y: T(y)}
+}
+
+// Vec3FromVector3D converts the vector to a Vec3 with elements of type T.
+func Vec3FromVector3D[T Float](v Vector3DReader) *Vec3[T] {
+	x, y, z := v.GetComponents()
+	return &Vec3[T]{X: T(x), Y: T(y), Z: T(z)}
+}
+
+// Vec4FromVector4D converts the vector to a Vec4 with elements of type T.
+func Vec4FromVector4D[T Float](v Vector4DReader) *Vec4[T] {
+	x, y, z, w := v.GetComponents()
+	return &Vec4[T]{X: T(x), Y: T(y), Z: T(z), W: T(w)}
+}
+
+// ConvertVec2 converts the vector to a Vec2 with elements of type U.
+func ConvertVec2[U, T Float](v *Vec2[T]) *Vec2[U] {
+	return &Vec2[U]{X: U(v.X), Y: U(v.Y)}
+}
+
+// ConvertVec3 converts the vector to a Vec3 with elements of type U.
+func ConvertVec3[U, T Float](v *Vec3[T]) *Vec3[U] {
+	return &Vec3[U]{X: U(v.X), Y: U(v.Y), Z: U(v.Z)}
+}
+
+// ConvertVec4 converts the vector to a Vec4 with elements of type U.
+func ConvertVec4[U, T Float](v *Vec4[T]) *Vec4[U] {
+	return &Vec4[U]{X: U(v.X), Y: U(v.Y), Z: U(v.Z), W: U(v.W)}
+}
+
+// ToVector2D converts the vector to a Vector2D.
+func (v *Vec2[T]) ToVector2D() *Vector2D {
+	return &Vector2D{X: float64(v.X), Y: float64(v.Y)}
+}
+
+// ToVector3D converts the vector to a Vector3D.
+func (v *Vec3[T]) ToVector3D() *Vector3D {
+	return &Vector3D{X: float64(v.X), Y: float64(v.Y), Z: float64(v.Z)}
+}
+
+// ToVector4D converts the vector to a Vector4D.
+func (v *Vec4[T]) ToVector4D() *Vector4D {
+	return &Vector4D{X: float64(v.X), Y: float64(v.Y), Z: float64(v.Z), W: float64(v.W)}
+}
+
+// GetComponents returns the components of the vector.
+func (v *Vec2[T]) GetComponents() (T, T) {
+	return v.X, v.Y
+}
+
+// GetComponents returns the components of the vector.
+func (v *Vec3[T]) GetComponents() (T, T, T) {
+	return v.X, v.Y, v.Z
+}
+
+// GetComponents returns the components of the vector.
+func (v *Vec4[T]) GetComponents() (T, T, T, T) {
+	return v.X, v.Y, v.Z, v.W
+}
+
+// SetComponents sets the components of the vector.
+func (v *Vec2[T]) SetComponents(x, y T) {
+	v.X, v.Y = x, y
+}
+
+// SetComponents sets the components of the vector.
+func (v *Vec3[T]) SetComponents(x, y, z T) {
+	v.X, v.Y, v.Z = x, y, z
+}
+
+// SetComponents sets the components of the vector.
+func (v *Vec4[T]) SetComponents(x, y, z, w T) {
+	v.X, v.Y, v.Z, v.W = x, y, z, w
+}
+
+// Clone returns a copy of the vector.
+func (v *Vec2[T]) Clone() *Vec2[T] {
+	return &Vec2[T]{X: v.X, Y: v.Y}
+}
+
+// Clone returns a copy of the vector.
+func (v *Vec3[T]) Clone() *Vec3[T] {
+	return &Vec3[T]{X: v.X, Y: v.Y, Z: v.Z}
+}
+
+// Clone returns a copy of the vector.
+func (v *Vec4[T]) Clone() *Vec4[T] {
+	return &Vec4[T]{X: v.X, Y: v.Y, Z: v.Z, W: v.W}
+}
+
+// Dot computes the dot product of this vector with the given vector.
+func (v *Vec2[T]) Dot(w *Vec2[T]) (T, error) {
+	d := v.X*w.X + v.Y*w.Y
+	if anyOverflow(d) {
+		return 0, numeric.ErrOverflow
+	}
+	return d, nil
+}
+
+// Dot computes the dot product of this vector with the given vector.
+func (v *Vec3[T]) Dot(w *Vec3[T]) (T, error) {
+	d := v.X*w.X + v.Y*w.Y + v.Z*w.Z
+	if anyOverflow(d) {
+		return 0, numeric.ErrOverflow
+	}
+	return d, nil
+}
+
+// Dot computes the dot product of this vector with the given vector.
+func (v *Vec4[T]) Dot(w *Vec4[T]) (T, error) {
+	d := v.X*w.X + v.Y*w.Y + v.Z*w.Z + v.W*w.W
+	if anyOverflow(d) {
+		return 0, numeric.ErrOverflow
+	}
+	return d, nil
+}
+
+// Cross computes the cross product of this vector with the given vector.
+func (v *Vec3[T]) Cross(w *Vec3[T]) (*Vec3[T], error) {
+	x := v.Y*w.Z - v.Z*w.Y
+	y := v.Z*w.X - v.X*w.Z
+	z := v.X*w.Y - v.Y*w.X
+	if anyOverflow(x, y, z) {
+		return nil, numeric.ErrOverflow
+	}
+	return &Vec3[T]{X: x, Y: y, Z: z}, nil
+}
+
+// Length computes the length of the vector.
+func (v *Vec2[T]) Length() (T, error) {
+	return genericLength(v.X, v.Y)
+}
+
+// Length computes the length of the vector.
+func (v *Vec3[T]) Length() (T, error) {
+	return genericLength(v.X, v.Y, v.Z)
+}
+
+// Length computes the length of the vector.
+func (v *Vec4[T]) Length() (T, error) {
+	return genericLength(v.X, v.Y, v.Z, v.W)
+}
+
+// genericLength computes the Euclidean norm of the components in float64, avoiding intermediate overflow.
+func genericLength[T Float](c ...T) (T, error) {
+	r := 0.0
+	for _, v := range c {
+		r = math.Hypot(r, float64(v))
+	}
+	l := T(r)
+	if anyOverflow(l) {
+		return 0, numeric.ErrOverflow
+	}
+	return l, nil
+}
+
+// Negate negates the vector components.
+func (v *Vec2[T]) Negate() {
+	v.X, v.Y = -v.X, -v.Y
+}
+
+// Negate negates the vector components.
+func (v *Vec3[T]) Negate() {
+	v.X, v.Y, v.Z = -v.X, -v.Y, -v.Z
+}
+
+// Negate negates the vector components.
+func (v *Vec4[T]) Negate() {
+	v.X, v.Y, v.Z, v.W = -v.X, -v.Y, -v.Z, -v.W
+}
+
+// Add adds the given vector to this one.
+func (v *Vec2[T]) Add(w *Vec2[T]) error {
+	x, y := v.X+w.X, v.Y+w.Y
+	if anyOverflow(x, y) {
+		return numeric.ErrOverflow
+	}
+	v.SetComponents(x, y)
+	return nil
+}
+
+// Add adds the given vector to this one.
+func (v *Vec3[T]) Add(w *Vec3[T]) error {
+	x, y, z := v.X+w.X, v.Y+w.Y, v.Z+w.Z
+	if anyOverflow(x, y, z) {
+		return numeric.ErrOverflow
+	}
+	v.SetComponents(x, y, z)
+	return nil
+}
+
+// Add adds the given vector to this one.
+func (v *Vec4[T]) Add(w *Vec4[T]) error {
+	x, y, z, ww := v.X+w.X, v.Y+w.Y, v.Z+w.Z, v.W+w.W
+	if anyOverflow(x, y, z, ww) {
+		return numeric.ErrOverflow
+	}
+	v.SetComponents(x, y, z, ww)
+	return nil
+}
+
+// Sub subtracts the given vector from this one.
+func (v *Vec2[T]) Sub(w *Vec2[T]) error {
+	x, y := v.X-w.X, v.Y-w.Y
+	if anyOverflow(x, y) {
+		return numeric.ErrOverflow
+	}
+	v.SetComponents(x, y)
+	return nil
+}
+
+// Sub subtracts the given vector from this one.
+func (v *Vec3[T]) Sub(w *Vec3[T]) error {
+	x, y, z := v.X-w.X, v.Y-w.Y, v.Z-w.Z
+	if anyOverflow(x, y, z) {
+		return numeric.ErrOverflow
+	}
+	v.SetComponents(x, y, z)
+	return nil
+}
+
+// Sub subtracts the given vector from this one.
+func (v *Vec4[T]) Sub(w *Vec4[T]) error {
+	x, y, z, ww := v.X-w.X, v.Y-w.Y, v.Z-w.Z, v.W-w.W
+	if anyOverflow(x, y, z, ww) {
+		return numeric.ErrOverflow
+	}
+	v.SetComponents(x, y, z, ww)
+	return nil
+}
+
+// Scale scales the vector by the given factor.
+func (v *Vec2[T]) Scale(f T) error {
+	x, y := v.X*f, v.Y*f
+	if anyOverflow(x, y) {
+		return numeric.ErrOverflow
+	}
+	v.SetComponents(x, y)
+	return nil
+}
+
+// Scale scales the vector by the given factor.
+func (v *Vec3[T]) Scale(f T) error {
+	x, y, z := v.X*f, v.Y*f, v.Z*f
+	if anyOverflow(x, y, z) {
+		return numeric.ErrOverflow
+	}
+	v.SetComponents(x, y, z)
+	return nil
+}
+
+// Scale scales the vector by the given factor.
+func (v *Vec4[T]) Scale(f T) error {
+	x, y, z, w := v.X*f, v.Y*f, v.Z*f, v.W*f
+	if anyOverflow(x, y, z, w) {
+		return numeric.ErrOverflow
+	}
+	v.SetComponents(x, y, z, w)
+	return nil
+}
+
+// Normalize scales the vector to unit length.
+func (v *Vec2[T]) Normalize() error {
+	l, err := v.Length()
+	if err != nil {
+		return err
+	}
+	if l == 0 {
+		return numeric.ErrDivideByZero
+	}
+	v.SetComponents(v.X/l, v.Y/l)
+	return nil
+}
+
+// Normalize scales the vector to unit length.
+func (v *Vec3[T]) Normalize() error {
+	l, err := v.Length()
+	if err != nil {
+		return err
+	}
+	if l == 0 {
+		return numeric.ErrDivideByZero
+	}
+	v.SetComponents(v.X/l, v.Y/l, v.Z/l)
+	return nil
+}
+
+// Normalize scales the vector to unit length.
+func (v *Vec4[T]) Normalize() error {
+	l, err := v.Length()
+	if err != nil {
+		return err
+	}
+	if l == 0 {
+		return numeric.ErrDivideByZero
+	}
+	v.SetComponents(v.X/l, v.Y/l, v.Z/l, v.W/l)
+	return nil
+}
+
+// IsEqualTo returns true if the vector components are equal within a tolerance of each other, false if not.
+func (v *Vec2[T]) IsEqualTo(w *Vec2[T], tol numeric.Tolerance) (bool, error) {
+	return genericIsEqual([]T{v.X, v.Y}, []T{w.X, w.Y}, tol)
+}
+
+// IsEqualTo returns true if the vector components are equal within a tolerance of each other, false if not.
+func (v *Vec3[T]) IsEqualTo(w *Vec3[T], tol numeric.Tolerance) (bool, error) {
+	return genericIsEqual([]T{v.X, v.Y, v.Z}, []T{w.X, w.Y, w.Z}, tol)
+}
+
+// IsEqualTo returns true if the vector components are equal within a tolerance of each other, false if not.
+func (v *Vec4[T]) IsEqualTo(w *Vec4[T], tol numeric.Tolerance) (bool, error) {
+	return genericIsEqual([]T{v.X, v.Y, v.Z, v.W}, []T{w.X, w.Y, w.Z, w.W}, tol)
+}
+
+// genericIsEqual returns true if the corresponding elements are equal within the tolerance. The relative criterion
+// is measured against the largest magnitude of either argument and the ULP criterion counts values of type T.
+func genericIsEqual[T Float](a, b []T, tol numeric.Tolerance) (bool, error) {
+	if tol.IsInvalid() {
+		return false, numeric.ErrInvalidTol
+	}
+	scale := 0.0
+	for i := range a {
+		scale = math.Max(scale, math.Max(math.Abs(float64(a[i])), math.Abs(float64(b[i]))))
+	}
+	isFloat32 := epsilon[T]() > 0x1p-52
+	for i := range a {
+		isEqual := tol.IsEqualAtScale(float64(a[i]), float64(b[i]), scale)
+		if isFloat32 {
+			isEqual = tol.IsEqualAtScaleFloat32(float32(a[i]), float32(b[i]), scale)
+		}
+		if !isEqual {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// Lerp linearly interpolates this vector towards the given vector, where t = 0 leaves this vector unchanged and t = 1 gives w.
+func (v *Vec2[T]) Lerp(w *Vec2[T], t T) error {
+	x, y := v.X+t*(w.X-v.X), v.Y+t*(w.Y-v.Y)
+	if anyOverflow(x, y) {
+		return numeric.ErrOverflow
+	}
+	v.SetComponents(x, y)
+	return nil
+}
+
+// Lerp linearly interpolates this vector towards the given vector, where t = 0 leaves this vector unchanged and t = 1 gives w.
+func (v *Vec3[T]) Lerp(w *Vec3[T], t T) error {
+	x, y, z := v.X+t*(w.X-v.X), v.Y+t*(w.Y-v.Y), v.Z+t*(w.Z-v.Z)
+	if anyOverflow(x, y, z) {
+		return numeric.ErrOverflow
+	}
+	v.SetComponents(x, y, z)
+	return nil
+}
+
+// Lerp linearly interpolates this vector towards the given vector, where t = 0 leaves this vector unchanged and t = 1 gives w.
+func (v *Vec4[T]) Lerp(w *Vec4[T], t T) error {
+	x, y, z, ww := v.X+t*(w.X-v.X), v.Y+t*(w.Y-v.Y), v.Z+t*(w.Z-v.Z), v.W+t*(w.W-v.W)
+	if anyOverflow(x, y, z, ww) {
+		return numeric.ErrOverflow
+	}
+	v.SetComponents(x, y, z, ww)
+	return nil
+}
+
+// MatrixTransform transforms this vector by left-multiplying the given matrix.
+func (v *Vec2[T]) MatrixTransform(m *Mat2[T]) error {
+	e := m.elements
+	x := e[0]*v.X + e[1]*v.Y
+	y := e[2]*v.X + e[3]*v.Y
+	if anyOverflow(x, y) {
+		return numeric.ErrOverflow
+	}
+	v.SetComponents(x, y)
+	return nil
+}
+
+// MatrixTransform transforms this vector by left-multiplying the given matrix.
+func (v *Vec3[T]) MatrixTransform(m *Mat3[T]) error {
+	e := m.elements
+	x := e[0]*v.X + e[1]*v.Y + e[2]*v.Z
+	y := e[3]*v.X + e[4]*v.Y + e[5]*v.Z
+	z := e[6]*v.X + e[7]*v.Y + e[8]*v.Z
+	if anyOverflow(x, y, z) {
+		return numeric.ErrOverflow
+	}
+	v.SetComponents(x, y, z)
+	return nil
+}
+
+// MatrixTransform transforms this vector by left-multiplying the given matrix.
+func (v *Vec4[T]) MatrixTransform(m *Mat4[T]) error {
+	e := m.elements
+	x := e[0]*v.X + e[1]*v.Y + e[2]*v.Z + e[3]*v.W
+	y := e[4]*v.X + e[5]*v.Y + e[6]*v.Z + e[7]*v.W
+	z := e[8]*v.X + e[9]*v.Y + e[10]*v.Z + e[11]*v.W
+	w := e[12]*v.X + e[13]*v.Y + e[14]*v.Z + e[15]*v.W
+	if anyOverflow(x, y, z, w) {
+		return numeric.ErrOverflow
+	}
+	v.SetComponents(x, y, z, w)
+	return nil
+}
@@ -0,0 +1,76 @@
+package geometry
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// float32Eps is the machine epsilon of float32.
+const float32Eps = 0x1p-23
+
+func TestVecPrecisionRoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		v := randomVector3D(r)
+		if err := v.Scale(math.Pow(10, float64(r.Intn(60)-30))); err != nil {
+			t.Fatal(err)
+		}
+		l, err := v.Length()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// narrowing rounds each component to float32, and widening back is exact
+		f := Vec3FromVector3D[float32](v)
+		got := f.ToVector3D()
+		for j, c := range vectorSlice(got) {
+			want := vectorSlice(v)[j]
+			if !(math.Abs(c-want) <= math.Abs(want)*float32Eps/2) {
+				t.Fatalf("ToVector3D(Vec3FromVector3D[float32](%v)) = %v", v, got)
+			}
+		}
+		if back := Vec3FromVector3D[float32](got); *back != *f {
+			t.Fatalf("float32 -> float64 -> float32 changed %v to %v", f, back)
+		}
+		if back := ConvertVec3[float32](ConvertVec3[float64](f)); *back != *f {
+			t.Fatalf("ConvertVec3 round trip changed %v to %v", f, back)
+		}
+
+		// the float32 operations agree with the float64 ones to float32 precision
+		w := randomVector3D(r)
+		fw := Vec3FromVector3D[float32](w)
+		c, err := v.Cross(w)
+		if err != nil {
+			t.Fatal(err)
+		}
+		fc, err := f.Cross(fw)
+		if err != nil {
+			t.Fatal(err)
+		}
+		wl, err := w.Length()
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkClose(t, "float32 Cross", vectorSlice(fc.ToVector3D()), vectorSlice(c), 4*float32Eps*l*wl)
+		if err := v.Normalize(); err != nil {
+			t.Fatal(err)
+		}
+		if err := f.Normalize(); err != nil {
+			t.Fatal(err)
+		}
+		checkClose(t, "float32 Normalize", vectorSlice(f.ToVector3D()), vectorSlice(v), 4*float32Eps)
+	}
+
+	v2 := &Vector2D{X: 0.1, Y: -3}
+	if got := Vec2FromVector2D[float64](v2).ToVector2D(); *got != *v2 {
+		t.Errorf("Vec2FromVector2D[float64] round trip = %v, want %v", got, v2)
+	}
+	v4 := &Vector4D{X: 0.1, Y: -3, Z: 1e300, W: 5e-324}
+	if got := Vec4FromVector4D[float64](v4).ToVector4D(); *got != *v4 {
+		t.Errorf("Vec4FromVector4D[float64] round trip = %v, want %v", got, v4)
+	}
+	if got := Vec4FromVector4D[float32](v4); !math.IsInf(float64(got.Z), 1) || got.W != 0 {
+		t.Errorf("Vec4FromVector4D[float32](%v) = %v, want +Inf and 0 beyond the float32 range", v4, got)
+	}
+}
@@ -0,0 +1,82 @@
+package geometry
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// checkClose checks that the corresponding elements of got and want differ by at most tol.
+func checkClose(t *testing.T, name string, got, want []float64, tol float64) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("%s: got %d elements, want %d", name, len(got), len(want))
+	}
+	for i := range got {
+		if !(math.Abs(got[i]-want[i]) <= tol) {
+			t.Fatalf("%s: got %v, want %v", name, got, want)
+		}
+	}
+}
+
+// randomUnitQuaternion returns a uniformly distributed unit quaternion.
+func randomUnitQuaternion(r *rand.Rand) *Quaternion {
+	q := &Quaternion{X: r.NormFloat64(), Y: r.NormFloat64(), Z: r.NormFloat64(), W: r.NormFloat64()}
+	if err := q.Normalize(); err != nil {
+		panic(err)
+	}
+	return q
+}
+
+// randomVector3D returns a vector with standard normal components.
+func randomVector3D(r *rand.Rand) *Vector3D {
+	return &Vector3D{X: r.NormFloat64(), Y: r.NormFloat64(), Z: r.NormFloat64()}
+}
+
+// quaternionSlice returns the components of the quaternion with the sign that makes w non-negative, since q and -q
+// represent the same rotation.
+func quaternionSlice(q *Quaternion) []float64 {
+	if q.W < 0 {
+		return []float64{-q.X, -q.Y, -q.Z, -q.W}
+	}
+	return []float64{q.X, q.Y, q.Z, q.W}
+}
+
+// vectorSlice returns the components of the vector.
+func vectorSlice(v Vector3DReader) []float64 {
+	x, y, z := v.GetComponents()
+	return []float64{x, y, z}
+}
+
+// multiplyElements returns the row-major product a * b of two n x n matrices.
+func multiplyElements(a, b []float64, n int) []float64 {
+	out := make([]float64, n*n)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			for k := 0; k < n; k++ {
+				out[n*i+j] += a[n*i+k] * b[n*k+j]
+			}
+		}
+	}
+	return out
+}
+
+// randomElements returns the row-major elements of an n x n matrix with standard normal entries.
+func randomElements(r *rand.Rand, n int) []float64 {
+	e := make([]float64, n*n)
+	for i := range e {
+		e[i] = r.NormFloat64()
+	}
+	return e
+}
+
+// multiplyVector returns the product a * x of the row-major n x n matrix a and the vector x.
+func multiplyVector(a, x []float64, n int) []float64 {
+	out := make([]float64, n)
+	for i := 0; i < n; i++ {
+		for k := 0; k < n; k++ {
+			out[i] += a[n*i+k] * x[k]
+		}
+	}
+	return out
+}
@@ -0,0 +1,139 @@
+package geometry
+
+import (
+	"github.com/tab58/v1/spatial/pkg/numeric"
+)
+
+// IntervalMatrix3D is a row-major representation of a 3x3 matrix whose elements are intervals. Its operations round
+// outward, so the result contains every result of the operation applied to matrices within the operands.
+type IntervalMatrix3D struct {
+	elements [9]numeric.Interval
+}
+
+// NewIntervalMatrix3D creates an interval matrix containing only the given matrix.
+func NewIntervalMatrix3D(m *Matrix3D) *IntervalMatrix3D {
+	out := &IntervalMatrix3D{}
+	for i, v := range m.elements {
+		out.elements[i] = numeric.NewIntervalPoint(v)
+	}
+	return out
+}
+
+// Rows returns the number of rows in the matrix.
+func (m *IntervalMatrix3D) Rows() uint { return 3 }
+
+// Cols returns the number of columns in the matrix.
+func (m *IntervalMatrix3D) Cols() uint { return 3 }
+
+// Clone returns a deep copy of the matrix.
+func (m *IntervalMatrix3D) Clone() *IntervalMatrix3D {
+	return &IntervalMatrix3D{elements: m.elements}
+}
+
+// ElementAt returns the value of the element at the given indices.
+func (m *IntervalMatrix3D) ElementAt(i, j uint) (numeric.Interval, error) {
+	if i >= 3 || j >= 3 {
+		return numeric.Interval{}, numeric.ErrMatrixOutOfRange
+	}
+	return m.elements[i*3+j], nil
+}
+
+// SetElementAt sets the value of the element at the given indices.
+func (m *IntervalMatrix3D) SetElementAt(i, j uint, value numeric.Interval) error {
+	if i >= 3 || j >= 3 {
+		return numeric.ErrMatrixOutOfRange
+	}
+	m.elements[i*3+j] = value
+	return nil
+}
+
+// Midpoint returns the matrix of the midpoints of the elements.
+func (m *IntervalMatrix3D) Midpoint() *Matrix3D {
+	out := &Matrix3D{}
+	for i, a := range m.elements {
+		out.elements[i] = a.Midpoint()
+	}
+	return out
+}
+
+// Contains returns true if the given matrix lies within the interval matrix, false if not.
+func (m *IntervalMatrix3D) Contains(mat *Matrix3D) bool {
+	for i, a := range m.elements {
+		if !a.Contains(mat.elements[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// multiplyIntervalMatrices computes a * b.
+func multiplyIntervalMatrices(a, b [9]numeric.Interval) ([9]numeric.Interval, error) {
+	out := [9]numeric.Interval{}
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			s := a[i*3].Mul(b[j])
+			for k := 1; k < 3; k++ {
+				s = s.Add(a[i*3+k].Mul(b[k*3+j]))
+			}
+			out[i*3+j] = s
+		}
+	}
+	if areAnyIntervalsOverflow(out[:]...) {
+		return out, numeric.ErrOverflow
+	}
+	return out, nil
+}
+
+// Premultiply left-multiplies the given matrix with this one, m = mat * m.
+func (m *IntervalMatrix3D) Premultiply(mat *IntervalMatrix3D) error {
+	res, err := multiplyIntervalMatrices(mat.elements, m.elements)
+	if err != nil {
+		return err
+	}
+	m.elements = res
+	return nil
+}
+
+// Postmultiply right-multiplies the given matrix with this one, m = m * mat.
+func (m *IntervalMatrix3D) Postmultiply(mat *IntervalMatrix3D) error {
+	res, err := multiplyIntervalMatrices(m.elements, mat.elements)
+	if err != nil {
+		return err
+	}
+	m.elements = res
+	return nil
+}
+
+// Transpose transposes the matrix in-place.
+func (m *IntervalMatrix3D) Transpose() {
+	a := &m.elements
+	a[1], a[3] = a[3], a[1]
+	a[2], a[6] = a[6], a[2]
+	a[5], a[7] = a[7], a[5]
+}
+
+// Scale multiplies the elements of the matrix by the given interval.
+func (m *IntervalMatrix3D) Scale(f numeric.Interval) error {
+	out := m.elements
+	for i := range out {
+		out[i] = out[i].Mul(f)
+	}
+	if areAnyIntervalsOverflow(out[:]...) {
+		return numeric.ErrOverflow
+	}
+	m.elements = out
+	return nil
+}
+
+// Determinant calculates an interval containing the determinant of every matrix within the interval matrix.
+func (m *IntervalMatrix3D) Determinant() (numeric.Interval, error) {
+	a := m.elements
+	b01 := a[4].Mul(a[8]).Sub(a[5].Mul(a[7]))
+	b11 := a[5].Mul(a[6]).Sub(a[3].Mul(a[8]))
+	b21 := a[3].Mul(a[7]).Sub(a[4].Mul(a[6]))
+	det := a[0].Mul(b01).Add(a[1].Mul(b11)).Add(a[2].Mul(b21))
+	if det.IsOverflow() {
+		return numeric.Interval{}, numeric.ErrOverflow
+	}
+	return det, nil
+}
@@ -0,0 +1,145 @@
+package geometry
+
+import (
+	"github.com/tab58/v1/spatial/pkg/numeric"
+)
+
+// IntervalVector3D is a 3D vector whose components are intervals. Its operations round outward, so the result
+// contains every result of the operation applied to vectors within the operands.
+type IntervalVector3D struct {
+	X numeric.Interval
+	Y numeric.Interval
+	Z numeric.Interval
+}
+
+// NewIntervalVector3D creates an interval vector containing only the given vector.
+func NewIntervalVector3D(v Vector3DReader) *IntervalVector3D {
+	x, y, z := v.GetComponents()
+	return &IntervalVector3D{
+		X: numeric.NewIntervalPoint(x),
+		Y: numeric.NewIntervalPoint(y),
+		Z: numeric.NewIntervalPoint(z),
+	}
+}
+
+// GetComponents returns the components of the vector.
+func (v *IntervalVector3D) GetComponents() (numeric.Interval, numeric.Interval, numeric.Interval) {
+	return v.X, v.Y, v.Z
+}
+
+// SetComponents sets the components of the vector.
+func (v *IntervalVector3D) SetComponents(x, y, z numeric.Interval) {
+	v.X, v.Y, v.Z = x, y, z
+}
+
+// Clone returns a copy of the vector.
+func (v *IntervalVector3D) Clone() *IntervalVector3D {
+	return &IntervalVector3D{X: v.X, Y: v.Y, Z: v.Z}
+}
+
+// Midpoint returns the vector of the midpoints of the components.
+func (v *IntervalVector3D) Midpoint() *Vector3D {
+	return &Vector3D{X: v.X.Midpoint(), Y: v.Y.Midpoint(), Z: v.Z.Midpoint()}
+}
+
+// Contains returns true if the given vector lies within the interval vector, false if not.
+func (v *IntervalVector3D) Contains(w Vector3DReader) bool {
+	x, y, z := w.GetComponents()
+	return v.X.Contains(x) && v.Y.Contains(y) && v.Z.Contains(z)
+}
+
+// areAnyIntervalsOverflow returns true if any of the intervals has overflowed, false if not.
+func areAnyIntervalsOverflow(intervals ...numeric.Interval) bool {
+	for _, a := range intervals {
+		if a.IsOverflow() {
+			return true
+		}
+	}
+	return false
+}
+
+// Dot computes the dot product between this vector and another interval vector.
+func (v *IntervalVector3D) Dot(w *IntervalVector3D) (numeric.Interval, error) {
+	r := v.X.Mul(w.X).Add(v.Y.Mul(w.Y)).Add(v.Z.Mul(w.Z))
+	if r.IsOverflow() {
+		return numeric.Interval{}, numeric.ErrOverflow
+	}
+	return r, nil
+}
+
+// Cross computes the cross product between this vector and another interval vector.
+func (v *IntervalVector3D) Cross(w *IntervalVector3D) (*IntervalVector3D, error) {
+	ux := v.Y.Mul(w.Z).Sub(v.Z.Mul(w.Y))
+	uy := v.Z.Mul(w.X).Sub(v.X.Mul(w.Z))
+	uz := v.X.Mul(w.Y).Sub(v.Y.Mul(w.X))
+	if areAnyIntervalsOverflow(ux, uy, uz) {
+		return nil, numeric.ErrOverflow
+	}
+	return &IntervalVector3D{X: ux, Y: uy, Z: uz}, nil
+}
+
+// LengthSquared computes the squared length of the vector.
+func (v *IntervalVector3D) LengthSquared() (numeric.Interval, error) {
+	r := v.X.Sqr().Add(v.Y.Sqr()).Add(v.Z.Sqr())
+	if r.IsOverflow() {
+		return numeric.Interval{}, numeric.ErrOverflow
+	}
+	return r, nil
+}
+
+// Length computes the length of the vector.
+func (v *IntervalVector3D) Length() (numeric.Interval, error) {
+	l2, err := v.LengthSquared()
+	if err != nil {
+		return numeric.Interval{}, err
+	}
+	return l2.Sqrt()
+}
+
+// Negate negates the vector components.
+func (v *IntervalVector3D) Negate() {
+	v.SetComponents(v.X.Neg(), v.Y.Neg(), v.Z.Neg())
+}
+
+// Add adds the given vector to this one.
+func (v *IntervalVector3D) Add(w *IntervalVector3D) error {
+	x, y, z := v.X.Add(w.X), v.Y.Add(w.Y), v.Z.Add(w.Z)
+	if areAnyIntervalsOverflow(x, y, z) {
+		return numeric.ErrOverflow
+	}
+	v.SetComponents(x, y, z)
+	return nil
+}
+
+// Sub subtracts the given vector from this one.
+func (v *IntervalVector3D) Sub(w *IntervalVector3D) error {
+	x, y, z := v.X.Sub(w.X), v.Y.Sub(w.Y), v.Z.Sub(w.Z)
+	if areAnyIntervalsOverflow(x, y, z) {
+		return numeric.ErrOverflow
+	}
+	v.SetComponents(x, y, z)
+	return nil
+}
+
+// Scale scales the vector by the given interval factor.
+func (v *IntervalVector3D) Scale(f numeric.Interval) error {
+	x, y, z := v.X.Mul(f), v.Y.Mul(f), v.Z.Mul(f)
+	if areAnyIntervalsOverflow(x, y, z) {
+		return numeric.ErrOverflow
+	}
+	v.SetComponents(x, y, z)
+	return nil
+}
+
+// MatrixTransform transforms this vector by left-multiplying the given interval matrix.
+func (v *IntervalVector3D) MatrixTransform(m *IntervalMatrix3D) error {
+	e := m.elements
+	x := e[0].Mul(v.X).Add(e[1].Mul(v.Y)).Add(e[2].Mul(v.Z))
+	y := e[3].Mul(v.X).Add(e[4].Mul(v.Y)).Add(e[5].Mul(v.Z))
+	z := e[6].Mul(v.X).Add(e[7].Mul(v.Y)).Add(e[8].Mul(v.Z))
+	if areAnyIntervalsOverflow(x, y, z) {
+		return numeric.ErrOverflow
+	}
+	v.SetComponents(x, y, z)
+	return nil
+}
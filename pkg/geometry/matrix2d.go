@@ -1,8 +1,6 @@
 package geometry
 
 import (
-	"math"
-
 	"github.com/tab58/v1/spatial/pkg/numeric"
 	"gonum.org/v1/gonum/blas/blas64"
 )
@@ -154,6 +152,7 @@ func (m *Matrix2D) Sub(mat *Matrix2D) error {
 	return nil
 }
 
+// multiply2DMatrices computes the row-major product b * a.
 func multiply2DMatrices(a, b [4]float64) ([4]float64, error) {
 	a0 := a[0]
 	a1 := a[1]
@@ -173,9 +172,9 @@ func multiply2DMatrices(a, b [4]float64) ([4]float64, error) {
 	return out, nil
 }
 
-// Premultiply left-multiplies the given matrix with this one.
+// Premultiply left-multiplies the given matrix with this one (m = mat * m).
 func (m *Matrix2D) Premultiply(mat *Matrix2D) error {
-	res, err := multiply2DMatrices(mat.elements, m.elements)
+	res, err := multiply2DMatrices(m.elements, mat.elements)
 	if err != nil {
 		return err
 	}
@@ -183,9 +182,9 @@ func (m *Matrix2D) Premultiply(mat *Matrix2D) error {
 	return nil
 }
 
-// Postmultiply right-multiplies the given matrix with this one.
+// Postmultiply right-multiplies the given matrix with this one (m = m * mat).
 func (m *Matrix2D) Postmultiply(mat *Matrix2D) error {
-	res, err := multiply2DMatrices(m.elements, mat.elements)
+	res, err := multiply2DMatrices(mat.elements, m.elements)
 	if err != nil {
 		return err
 	}
@@ -196,12 +195,17 @@ func (m *Matrix2D) Postmultiply(mat *Matrix2D) error {
 // Invert inverts this matrix in-place.
 func (m *Matrix2D) Invert() error {
 	a := m.elements
+	err := checkFinite(a[:])
+	if err != nil {
+		return err
+	}
 	a0, a1, a2, a3 := a[0], a[1], a[2], a[3]
 
 	// Calculate the determinant
 	det := a0*a3 - a2*a1
-	if math.Abs(det) < 1e-13 {
-		return numeric.ErrSingularMatrix
+	err = checkDeterminant(det)
+	if err != nil {
+		return err
 	}
 	det = 1.0 / det
 
@@ -210,9 +214,7 @@ func (m *Matrix2D) Invert() error {
 	out[1] = -a1 * det
 	out[2] = -a2 * det
 	out[3] = a0 * det
-	m.elements = out
-
-	return nil
+	return setInverse(m.elements[:], out[:], 2)
 }
 
 // Determinant calculates the determinant of the matrix.
@@ -221,6 +223,22 @@ func (m *Matrix2D) Determinant() float64 {
 	return a[0]*a[3] - a[2]*a[1]
 }
 
+// DeterminantExtended calculates the determinant of the matrix in double-double precision, rounding the result to a
+// float64.
+func (m *Matrix2D) DeterminantExtended() (float64, error) {
+	a := m.elements
+	err := checkFinite(a[:])
+	if err != nil {
+		return 0, err
+	}
+
+	det := numeric.DifferenceOfProducts(a[0], a[3], a[2], a[1])
+	if det.IsOverflow() {
+		return 0, numeric.ErrOverflow
+	}
+	return det.Float64(), nil
+}
+
 // Adjoint calculates the adjoint/adjugate matrix.
 func (m *Matrix2D) Adjoint() *Matrix2D {
 	a := m.elements
@@ -249,11 +267,176 @@ func (m *Matrix2D) IsSingular() bool {
 	return m.Determinant() == 0
 }
 
-// IsNearSingular returns true if the matrix determinant is equal or below the given tolerance, false if not.
-func (m *Matrix2D) IsNearSingular(tol float64) (bool, error) {
-	if numeric.IsInvalidTolerance(tol) {
-		return false, numeric.ErrInvalidTol
+// IsNearSingular returns true if the matrix determinant is zero within the given tolerance, false if not. The relative
+// criterion is measured against the product of the row norms, the largest determinant of a matrix with those rows.
+func (m *Matrix2D) IsNearSingular(tol numeric.Tolerance) (bool, error) {
+	return isDeterminantNearZero(tol, m.elements[:], 2)
+}
+
+// QR computes the QR decomposition m = Q * R using Householder reflections, where Q is orthogonal and R is upper
+// triangular with a non-negative diagonal.
+func (m *Matrix2D) QR() (*Matrix2D, *Matrix2D, error) {
+	err := checkFinite(m.elements[:])
+	if err != nil {
+		return nil, nil, err
+	}
+	q, r := qrDecompose(m.elements[:], 2)
+	Q, R := &Matrix2D{}, &Matrix2D{}
+	copy(Q.elements[:], q)
+	copy(R.elements[:], r)
+	return Q, R, nil
+}
+
+// LU computes the LU decomposition with partial pivoting, P * m = L * U, where P is a permutation matrix, L is unit
+// lower triangular and U is upper triangular. A singular matrix produces a zero on the diagonal of U.
+func (m *Matrix2D) LU() (*Matrix2D, *Matrix2D, *Matrix2D, error) {
+	err := checkFinite(m.elements[:])
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	lu, perm, _ := luDecompose(m.elements[:], 2)
+	p, l, u := unpackLU(lu, perm, 2)
+	P, L, U := &Matrix2D{}, &Matrix2D{}, &Matrix2D{}
+	copy(P.elements[:], p)
+	copy(L.elements[:], l)
+	copy(U.elements[:], u)
+	return P, L, U, nil
+}
+
+// Cholesky computes the lower triangular matrix L with a positive diagonal such that m = L * L^T. Only the lower
+// triangle of the matrix is read, and numeric.ErrNotPositiveDefinite is returned if it is not positive definite.
+func (m *Matrix2D) Cholesky() (*Matrix2D, error) {
+	err := checkFinite(m.elements[:])
+	if err != nil {
+		return nil, err
+	}
+	l, err := choleskyDecompose(m.elements[:], 2)
+	if err != nil {
+		return nil, err
+	}
+	L := &Matrix2D{}
+	copy(L.elements[:], l)
+	return L, nil
+}
+
+// SVD computes the singular value decomposition m = U * diag(s) * V^T, where U and V are orthogonal and the singular
+// values s are non-negative and in descending order.
+func (m *Matrix2D) SVD() (*Matrix2D, *Vector2D, *Matrix2D, error) {
+	err := checkFinite(m.elements[:])
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	u, s, v, err := svdDecompose(m.elements[:], 2)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	U, V := &Matrix2D{}, &Matrix2D{}
+	copy(U.elements[:], u)
+	copy(V.elements[:], v)
+	return U, &Vector2D{X: s[0], Y: s[1]}, V, nil
+}
+
+// singularValues computes the singular values of the matrix in descending order.
+func (m *Matrix2D) singularValues() ([]float64, error) {
+	err := checkFinite(m.elements[:])
+	if err != nil {
+		return nil, err
+	}
+	_, s, _, err := svdDecompose(m.elements[:], 2)
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Rank returns the numerical rank of the matrix, the number of singular values that are not zero within the tolerance
+// at the scale of the largest singular value.
+func (m *Matrix2D) Rank(tol numeric.Tolerance) (int, error) {
+	s, err := m.singularValues()
+	if err != nil {
+		return 0, err
+	}
+	return matrixRank(s, tol)
+}
+
+// ConditionNumber returns the 2-norm condition number of the matrix, the ratio of its largest to smallest singular
+// value, which is infinite for a singular matrix.
+func (m *Matrix2D) ConditionNumber() (float64, error) {
+	s, err := m.singularValues()
+	if err != nil {
+		return 0, err
+	}
+	return conditionNumber(s), nil
+}
+
+// Solve solves the linear system m * x = b for x using LU decomposition with partial pivoting, without forming the
+// inverse. numeric.ErrSingularMatrix is returned if the matrix is singular to working precision, which is when its
+// estimated reciprocal 1-norm condition number is below the machine epsilon.
+func (m *Matrix2D) Solve(b Vector2DReader) (*Vector2D, error) {
+	x, err := solveSystem(m.elements[:], 2, []float64{b.GetX(), b.GetY()}, 1)
+	if err != nil {
+		return nil, err
+	}
+	return &Vector2D{X: x[0], Y: x[1]}, nil
+}
+
+// SolveMatrix solves the linear system m * X = B for X, each column of B being a separate right-hand side.
+// The same conditions as Solve apply.
+func (m *Matrix2D) SolveMatrix(b *Matrix2D) (*Matrix2D, error) {
+	x, err := solveSystem(m.elements[:], 2, b.elements[:], 2)
+	if err != nil {
+		return nil, err
+	}
+	X := &Matrix2D{}
+	copy(X.elements[:], x)
+	return X, nil
+}
+
+// ConditionEstimate estimates the 1-norm condition number of the matrix from its LU decomposition without forming the
+// inverse. The estimate is a lower bound that is rarely more than a factor of 3 below the true 1-norm condition
+// number, and is infinite for a singular matrix.
+func (m *Matrix2D) ConditionEstimate() (float64, error) {
+	err := checkFinite(m.elements[:])
+	if err != nil {
+		return 0, err
 	}
+	lu, perm, _ := luDecompose(m.elements[:], 2)
+	return luConditionEstimate(m.elements[:], lu, perm, 2), nil
+}
 
-	return math.Abs(m.Determinant()) <= tol, nil
+// Exp computes the matrix exponential e^m by scaling and squaring with a Pade approximant.
+func (m *Matrix2D) Exp() (*Matrix2D, error) {
+	e, err := expSlice(m.elements[:], 2)
+	if err != nil {
+		return nil, err
+	}
+	E := &Matrix2D{}
+	copy(E.elements[:], e)
+	return E, nil
+}
+
+// Log computes the principal matrix logarithm, the matrix L with eigenvalues of imaginary part in (-pi, pi) such
+// that e^L = m, by inverse scaling and squaring. numeric.ErrNegativeEigenvalue is returned if the matrix has a negative
+// real eigenvalue and numeric.ErrSingularMatrix if it has a zero eigenvalue.
+func (m *Matrix2D) Log() (*Matrix2D, error) {
+	l, err := logSlice(m.elements[:], 2)
+	if err != nil {
+		return nil, err
+	}
+	L := &Matrix2D{}
+	copy(L.elements[:], l)
+	return L, nil
+}
+
+// Sqrt computes the principal matrix square root, the matrix S with eigenvalues of positive real part such that
+// S * S = m, by the Denman-Beavers iteration. The same errors as Log are returned, and numeric.ErrNotConverged if the
+// iteration fails to converge.
+func (m *Matrix2D) Sqrt() (*Matrix2D, error) {
+	s, err := sqrtSlice(m.elements[:], 2)
+	if err != nil {
+		return nil, err
+	}
+	S := &Matrix2D{}
+	copy(S.elements[:], s)
+	return S, nil
 }
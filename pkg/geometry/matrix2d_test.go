@@ -0,0 +1,56 @@
+package geometry
+
+import (
+	"math"
+	"testing"
+
+	"github.com/tab58/v1/spatial/pkg/numeric"
+)
+
+func TestMatrix2DDeterminantExtended(t *testing.T) {
+	m := &Matrix2D{elements: [4]float64{1 + 0x1p-30, 1 - 0x1p-30, 1 - 0x1p-30, 1 + 0x1p-30}}
+	det, err := m.DeterminantExtended()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := 0x1p-28; det != want {
+		t.Errorf("DeterminantExtended = %v, want %v", det, want)
+	}
+
+	tests := []struct {
+		name     string
+		elements [4]float64
+		want     error
+	}{
+		{"product", [4]float64{1e200, 0, 0, 1e200}, numeric.ErrOverflow},
+		{"cancelling", [4]float64{1e300, 1e10, 1e300, 1e10}, numeric.ErrOverflow},
+		{"NaN", [4]float64{math.NaN(), 0, 0, 1}, numeric.ErrNaN},
+	}
+	for _, tt := range tests {
+		m := &Matrix2D{elements: tt.elements}
+		if _, err := m.DeterminantExtended(); err != tt.want {
+			t.Errorf("DeterminantExtended %s: got %v, want %v", tt.name, err, tt.want)
+		}
+	}
+}
+
+func TestMatrix2DMultiplicationOrder(t *testing.T) {
+	a := &Matrix2D{elements: [4]float64{1, 2, 3, 4}}
+	b := &Matrix2D{elements: [4]float64{0, 1, 1, 0}}
+
+	// b * a swaps the rows of a, a * b swaps its columns
+	m := a.Clone()
+	if err := m.Premultiply(b); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := m.Elements(), [4]float64{3, 4, 1, 2}; got != want {
+		t.Errorf("Premultiply = %v, want %v", got, want)
+	}
+	m = a.Clone()
+	if err := m.Postmultiply(b); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := m.Elements(), [4]float64{2, 1, 4, 3}; got != want {
+		t.Errorf("Postmultiply = %v, want %v", got, want)
+	}
+}
@@ -1,8 +1,6 @@
 package geometry
 
 import (
-	"math"
-
 	"github.com/tab58/v1/spatial/pkg/numeric"
 	"gonum.org/v1/gonum/blas/blas64"
 )
@@ -161,6 +159,7 @@ func (m *Matrix3D) Sub(mat *Matrix3D) error {
 	return nil
 }
 
+// multiply3DMatrices computes the row-major product b * a.
 func multiply3DMatrices(a, b [9]float64) ([9]float64, error) {
 	a00, a01, a02 := a[0], a[1], a[2]
 	a10, a11, a12 := a[3], a[4], a[5]
@@ -189,9 +188,9 @@ func multiply3DMatrices(a, b [9]float64) ([9]float64, error) {
 	return out, nil
 }
 
-// Premultiply left-multiplies the given matrix with this one.
+// Premultiply left-multiplies the given matrix with this one (m = mat * m).
 func (m *Matrix3D) Premultiply(mat *Matrix3D) error {
-	res, err := multiply3DMatrices(mat.elements, m.elements)
+	res, err := multiply3DMatrices(m.elements, mat.elements)
 	if err != nil {
 		return err
 	}
@@ -199,9 +198,9 @@ func (m *Matrix3D) Premultiply(mat *Matrix3D) error {
 	return nil
 }
 
-// Postmultiply right-multiplies the given matrix with this one.
+// Postmultiply right-multiplies the given matrix with this one (m = m * mat).
 func (m *Matrix3D) Postmultiply(mat *Matrix3D) error {
-	res, err := multiply3DMatrices(m.elements, mat.elements)
+	res, err := multiply3DMatrices(mat.elements, m.elements)
 	if err != nil {
 		return err
 	}
@@ -212,6 +211,10 @@ func (m *Matrix3D) Postmultiply(mat *Matrix3D) error {
 // Invert inverts this matrix in-place.
 func (m *Matrix3D) Invert() error {
 	a := m.elements
+	err := checkFinite(a[:])
+	if err != nil {
+		return err
+	}
 	a00, a01, a02 := a[0], a[1], a[2]
 	a10, a11, a12 := a[3], a[4], a[5]
 	a20, a21, a22 := a[6], a[7], a[8]
@@ -222,8 +225,9 @@ func (m *Matrix3D) Invert() error {
 
 	// Calculate the determinant
 	det := a00*b01 + a01*b11 + a02*b21
-	if math.Abs(det) < 1e-13 {
-		return numeric.ErrSingularMatrix
+	err = checkDeterminant(det)
+	if err != nil {
+		return err
 	}
 	det = 1.0 / det
 
@@ -237,9 +241,7 @@ func (m *Matrix3D) Invert() error {
 	out[6] = b21 * det
 	out[7] = (-a21*a00 + a01*a20) * det
 	out[8] = (a11*a00 - a01*a10) * det
-	m.elements = out
-
-	return nil
+	return setInverse(m.elements[:], out[:], 3)
 }
 
 // Determinant calculates the determinant of the matrix.
@@ -255,6 +257,30 @@ func (m *Matrix3D) Determinant() float64 {
 	return res
 }
 
+// DeterminantExtended calculates the determinant of the matrix in double-double precision, rounding the result to a
+// float64.
+func (m *Matrix3D) DeterminantExtended() (float64, error) {
+	a := m.elements
+	err := checkFinite(a[:])
+	if err != nil {
+		return 0, err
+	}
+
+	b01 := numeric.DifferenceOfProducts(a[4], a[8], a[5], a[7])
+	b11 := numeric.DifferenceOfProducts(a[5], a[6], a[3], a[8])
+	b21 := numeric.DifferenceOfProducts(a[3], a[7], a[4], a[6])
+	det := b01.MulFloat64(a[0]).Add(b11.MulFloat64(a[1])).Add(b21.MulFloat64(a[2]))
+	if det.IsOverflow() {
+		return 0, numeric.ErrOverflow
+	}
+	return det.Float64(), nil
+}
+
+// DeterminantBounds calculates an interval that is guaranteed to contain the exact determinant of the matrix.
+func (m *Matrix3D) DeterminantBounds() (numeric.Interval, error) {
+	return NewIntervalMatrix3D(m).Determinant()
+}
+
 // Adjoint calculates the adjoint/adjugate matrix.
 func (m *Matrix3D) Adjoint() *Matrix3D {
 	a := m.elements
@@ -295,11 +321,240 @@ func (m *Matrix3D) IsSingular() bool {
 	return m.Determinant() == 0
 }
 
-// IsNearSingular returns true if the matrix determinant is equal or below the given tolerance, false if not.
-func (m *Matrix3D) IsNearSingular(tol float64) (bool, error) {
-	if numeric.IsInvalidTolerance(tol) {
+// IsNearSingular returns true if the matrix determinant is zero within the given tolerance, false if not. The relative
+// criterion is measured against the product of the row norms, the largest determinant of a matrix with those rows.
+func (m *Matrix3D) IsNearSingular(tol numeric.Tolerance) (bool, error) {
+	return isDeterminantNearZero(tol, m.elements[:], 3)
+}
+
+// QR computes the QR decomposition m = Q * R using Householder reflections, where Q is orthogonal and R is upper
+// triangular with a non-negative diagonal.
+func (m *Matrix3D) QR() (*Matrix3D, *Matrix3D, error) {
+	err := checkFinite(m.elements[:])
+	if err != nil {
+		return nil, nil, err
+	}
+	q, r := qrDecompose(m.elements[:], 3)
+	Q, R := &Matrix3D{}, &Matrix3D{}
+	copy(Q.elements[:], q)
+	copy(R.elements[:], r)
+	return Q, R, nil
+}
+
+// LU computes the LU decomposition with partial pivoting, P * m = L * U, where P is a permutation matrix, L is unit
+// lower triangular and U is upper triangular. A singular matrix produces a zero on the diagonal of U.
+func (m *Matrix3D) LU() (*Matrix3D, *Matrix3D, *Matrix3D, error) {
+	err := checkFinite(m.elements[:])
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	lu, perm, _ := luDecompose(m.elements[:], 3)
+	p, l, u := unpackLU(lu, perm, 3)
+	P, L, U := &Matrix3D{}, &Matrix3D{}, &Matrix3D{}
+	copy(P.elements[:], p)
+	copy(L.elements[:], l)
+	copy(U.elements[:], u)
+	return P, L, U, nil
+}
+
+// Cholesky computes the lower triangular matrix L with a positive diagonal such that m = L * L^T. Only the lower
+// triangle of the matrix is read, and numeric.ErrNotPositiveDefinite is returned if it is not positive definite.
+func (m *Matrix3D) Cholesky() (*Matrix3D, error) {
+	err := checkFinite(m.elements[:])
+	if err != nil {
+		return nil, err
+	}
+	l, err := choleskyDecompose(m.elements[:], 3)
+	if err != nil {
+		return nil, err
+	}
+	L := &Matrix3D{}
+	copy(L.elements[:], l)
+	return L, nil
+}
+
+// SVD computes the singular value decomposition m = U * diag(s) * V^T, where U and V are orthogonal and the singular
+// values s are non-negative and in descending order.
+func (m *Matrix3D) SVD() (*Matrix3D, *Vector3D, *Matrix3D, error) {
+	err := checkFinite(m.elements[:])
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	u, s, v, err := svdDecompose(m.elements[:], 3)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	U, V := &Matrix3D{}, &Matrix3D{}
+	copy(U.elements[:], u)
+	copy(V.elements[:], v)
+	return U, &Vector3D{X: s[0], Y: s[1], Z: s[2]}, V, nil
+}
+
+// singularValues computes the singular values of the matrix in descending order.
+func (m *Matrix3D) singularValues() ([]float64, error) {
+	err := checkFinite(m.elements[:])
+	if err != nil {
+		return nil, err
+	}
+	_, s, _, err := svdDecompose(m.elements[:], 3)
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Rank returns the numerical rank of the matrix, the number of singular values that are not zero within the tolerance
+// at the scale of the largest singular value.
+func (m *Matrix3D) Rank(tol numeric.Tolerance) (int, error) {
+	s, err := m.singularValues()
+	if err != nil {
+		return 0, err
+	}
+	return matrixRank(s, tol)
+}
+
+// ConditionNumber returns the 2-norm condition number of the matrix, the ratio of its largest to smallest singular
+// value, which is infinite for a singular matrix.
+func (m *Matrix3D) ConditionNumber() (float64, error) {
+	s, err := m.singularValues()
+	if err != nil {
+		return 0, err
+	}
+	return conditionNumber(s), nil
+}
+
+// Solve solves the linear system m * x = b for x using LU decomposition with partial pivoting, without forming the
+// inverse. numeric.ErrSingularMatrix is returned if the matrix is singular to working precision, which is when its
+// estimated reciprocal 1-norm condition number is below the machine epsilon.
+func (m *Matrix3D) Solve(b Vector3DReader) (*Vector3D, error) {
+	x, err := solveSystem(m.elements[:], 3, []float64{b.GetX(), b.GetY(), b.GetZ()}, 1)
+	if err != nil {
+		return nil, err
+	}
+	return &Vector3D{X: x[0], Y: x[1], Z: x[2]}, nil
+}
+
+// SolveMatrix solves the linear system m * X = B for X, each column of B being a separate right-hand side.
+// The same conditions as Solve apply.
+func (m *Matrix3D) SolveMatrix(b *Matrix3D) (*Matrix3D, error) {
+	x, err := solveSystem(m.elements[:], 3, b.elements[:], 3)
+	if err != nil {
+		return nil, err
+	}
+	X := &Matrix3D{}
+	copy(X.elements[:], x)
+	return X, nil
+}
+
+// ConditionEstimate estimates the 1-norm condition number of the matrix from its LU decomposition without forming the
+// inverse. The estimate is a lower bound that is rarely more than a factor of 3 below the true 1-norm condition
+// number, and is infinite for a singular matrix.
+func (m *Matrix3D) ConditionEstimate() (float64, error) {
+	err := checkFinite(m.elements[:])
+	if err != nil {
+		return 0, err
+	}
+	lu, perm, _ := luDecompose(m.elements[:], 3)
+	return luConditionEstimate(m.elements[:], lu, perm, 3), nil
+}
+
+// IsOrthogonal returns true if the columns of the matrix are orthonormal, that is m^T * m equals the identity within
+// the tolerance element-wise, false if not.
+func (m *Matrix3D) IsOrthogonal(tol numeric.Tolerance) (bool, error) {
+	if tol.IsInvalid() {
 		return false, numeric.ErrInvalidTol
 	}
 
-	return math.Abs(m.Determinant()) <= tol, nil
+	a := m.elements
+	for i := 0; i < 3; i++ {
+		for j := i; j < 3; j++ {
+			d := a[i]*a[j] + a[3+i]*a[3+j] + a[6+i]*a[6+j]
+			want := 0.0
+			if i == j {
+				want = 1
+			}
+			// compared at the scale of the unit columns, so that the zero products are not measured against zero
+			if !tol.IsEqualAtScale(d, want, 1) {
+				return false, nil
+			}
+		}
+	}
+	return true, nil
+}
+
+// IsRotation returns true if the matrix is orthogonal with a determinant of 1 within the tolerance, false if not.
+func (m *Matrix3D) IsRotation(tol numeric.Tolerance) (bool, error) {
+	isOrthogonal, err := m.IsOrthogonal(tol)
+	if err != nil || !isOrthogonal {
+		return false, err
+	}
+	return tol.IsEqual(m.Determinant(), 1), nil
+}
+
+// NearestRotation returns the rotation matrix closest to the matrix in the Frobenius norm. For matrices with a positive
+// determinant, this is the orthogonal factor of the polar decomposition.
+func (m *Matrix3D) NearestRotation() (*Matrix3D, error) {
+	err := checkFinite(m.elements[:])
+	if err != nil {
+		return nil, err
+	}
+	u, _, v, err := svdDecompose(m.elements[:], 3)
+	if err != nil {
+		return nil, err
+	}
+
+	// R = U * diag(1, 1, d) * V^T, with d = det(U * V^T) = +/-1
+	U := &Matrix3D{}
+	V := &Matrix3D{}
+	copy(U.elements[:], u)
+	copy(V.elements[:], v)
+	if U.Determinant()*V.Determinant() < 0 {
+		for i := 0; i < 3; i++ {
+			U.elements[3*i+2] = -U.elements[3*i+2]
+		}
+	}
+	r := [9]float64{}
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			r[3*i+j] = U.elements[3*i]*V.elements[3*j] + U.elements[3*i+1]*V.elements[3*j+1] + U.elements[3*i+2]*V.elements[3*j+2]
+		}
+	}
+	return &Matrix3D{elements: r}, nil
+}
+
+// Exp computes the matrix exponential e^m by scaling and squaring with a Pade approximant.
+func (m *Matrix3D) Exp() (*Matrix3D, error) {
+	e, err := expSlice(m.elements[:], 3)
+	if err != nil {
+		return nil, err
+	}
+	E := &Matrix3D{}
+	copy(E.elements[:], e)
+	return E, nil
+}
+
+// Log computes the principal matrix logarithm, the matrix L with eigenvalues of imaginary part in (-pi, pi) such
+// that e^L = m, by inverse scaling and squaring. numeric.ErrNegativeEigenvalue is returned if the matrix has a negative
+// real eigenvalue and numeric.ErrSingularMatrix if it has a zero eigenvalue.
+func (m *Matrix3D) Log() (*Matrix3D, error) {
+	l, err := logSlice(m.elements[:], 3)
+	if err != nil {
+		return nil, err
+	}
+	L := &Matrix3D{}
+	copy(L.elements[:], l)
+	return L, nil
+}
+
+// Sqrt computes the principal matrix square root, the matrix S with eigenvalues of positive real part such that
+// S * S = m, by the Denman-Beavers iteration. The same errors as Log are returned, and numeric.ErrNotConverged if the
+// iteration fails to converge.
+func (m *Matrix3D) Sqrt() (*Matrix3D, error) {
+	s, err := sqrtSlice(m.elements[:], 3)
+	if err != nil {
+		return nil, err
+	}
+	S := &Matrix3D{}
+	copy(S.elements[:], s)
+	return S, nil
 }
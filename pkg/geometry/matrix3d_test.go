@@ -0,0 +1,168 @@
+package geometry
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/tab58/v1/spatial/pkg/numeric"
+)
+
+func TestMatrix3DDeterminantExtended(t *testing.T) {
+	m := &Matrix3D{elements: [9]float64{1 + 0x1p-30, 1 - 0x1p-30, 0, 1 - 0x1p-30, 1 + 0x1p-30, 0, 0, 0, 1}}
+	det, err := m.DeterminantExtended()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := 0x1p-28; det != want {
+		t.Errorf("DeterminantExtended = %v, want %v", det, want)
+	}
+
+	tests := []struct {
+		name     string
+		elements [9]float64
+		want     error
+	}{
+		{"product", [9]float64{1e200, 0, 0, 0, 1e200, 0, 0, 0, 1}, numeric.ErrOverflow},
+		{"infinite", [9]float64{math.Inf(1), 0, 0, 0, 1, 0, 0, 0, 1}, numeric.ErrOverflow},
+		{"NaN", [9]float64{math.NaN(), 0, 0, 0, 1, 0, 0, 0, 1}, numeric.ErrNaN},
+	}
+	for _, tt := range tests {
+		m := &Matrix3D{elements: tt.elements}
+		if _, err := m.DeterminantExtended(); err != tt.want {
+			t.Errorf("DeterminantExtended %s: got %v, want %v", tt.name, err, tt.want)
+		}
+	}
+}
+
+func TestMatrix3DMultiplicationOrder(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		a := &Matrix3D{}
+		b := &Matrix3D{}
+		copy(a.elements[:], randomElements(r, 3))
+		copy(b.elements[:], randomElements(r, 3))
+
+		m := a.Clone()
+		if err := m.Premultiply(b); err != nil {
+			t.Fatal(err)
+		}
+		got := m.Elements()
+		checkClose(t, "Premultiply", got[:], multiplyElements(b.elements[:], a.elements[:], 3), 1e-14)
+
+		m = a.Clone()
+		if err := m.Postmultiply(b); err != nil {
+			t.Fatal(err)
+		}
+		got = m.Elements()
+		checkClose(t, "Postmultiply", got[:], multiplyElements(a.elements[:], b.elements[:], 3), 1e-14)
+	}
+}
+
+func TestMatrix3DNearestRotation(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	tol := numeric.AbsoluteTolerance(1e-14)
+	for i := 0; i < 1000; i++ {
+		// a rotation perturbed by a small symmetric stretch projects back onto itself
+		q, err := randomUnitQuaternion(r).ToMatrix3D()
+		if err != nil {
+			t.Fatal(err)
+		}
+		s := randomElements(r, 3)
+		stretch := identitySlice(3)
+		for j := 0; j < 3; j++ {
+			for k := 0; k < 3; k++ {
+				stretch[3*j+k] += 0.05 * (s[3*j+k] + s[3*k+j])
+			}
+		}
+		m := &Matrix3D{}
+		copy(m.elements[:], multiplyElements(q.elements[:], stretch, 3))
+		isRotation, err := m.IsRotation(tol)
+		if err != nil || isRotation {
+			t.Fatalf("IsRotation of a stretched rotation = %v, %v", isRotation, err)
+		}
+
+		got, err := m.NearestRotation()
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkClose(t, "NearestRotation", got.elements[:], q.elements[:], 1e-14)
+		isRotation, err = got.IsRotation(tol)
+		if err != nil || !isRotation {
+			t.Fatalf("NearestRotation = %v is not a rotation: %v", got.Elements(), err)
+		}
+
+		// a reflection is orthogonal but not a rotation, and its nearest rotation has a positive determinant
+		refl := q.Clone()
+		for j := 0; j < 3; j++ {
+			refl.elements[3*j] = -refl.elements[3*j]
+		}
+		isOrthogonal, err := refl.IsOrthogonal(tol)
+		if err != nil || !isOrthogonal {
+			t.Fatalf("IsOrthogonal of a reflection = %v, %v", isOrthogonal, err)
+		}
+		isRotation, err = refl.IsRotation(tol)
+		if err != nil || isRotation {
+			t.Fatalf("IsRotation of a reflection = %v, %v", isRotation, err)
+		}
+		got, err = refl.NearestRotation()
+		if err != nil {
+			t.Fatal(err)
+		}
+		isRotation, err = got.IsRotation(tol)
+		if err != nil || !isRotation {
+			t.Fatalf("NearestRotation of a reflection = %v is not a rotation: %v", got.Elements(), err)
+		}
+	}
+
+	if _, err := (&Matrix3D{}).IsOrthogonal(numeric.AbsoluteTolerance(-1)); err != numeric.ErrInvalidTol {
+		t.Errorf("IsOrthogonal with an invalid tolerance: got %v, want %v", err, numeric.ErrInvalidTol)
+	}
+}
+
+func TestMatrix3DIsOrthogonalRelative(t *testing.T) {
+	// the zero products of distinct columns are compared at the scale of the unit columns
+	rel := numeric.RelativeTolerance(1e-12)
+	r := rand.New(rand.NewSource(4))
+	for i := 0; i < 1000; i++ {
+		m, err := randomUnitQuaternion(r).ToMatrix3D()
+		if err != nil {
+			t.Fatal(err)
+		}
+		isRotation, err := m.IsRotation(rel)
+		if err != nil || !isRotation {
+			t.Fatalf("IsRotation(%v) with a relative tolerance = %v, %v, want true", m.Elements(), isRotation, err)
+		}
+	}
+	m := &Matrix3D{elements: [9]float64{1, 1e-6, 0, 0, 1, 0, 0, 0, 1}}
+	if isOrthogonal, err := m.IsOrthogonal(rel); err != nil || isOrthogonal {
+		t.Errorf("IsOrthogonal of a sheared matrix = %v, %v, want false", isOrthogonal, err)
+	}
+}
+
+func TestMatrix3DIsNearSingular(t *testing.T) {
+	rel := numeric.RelativeTolerance(1e-9)
+	for _, s := range []float64{1e-200, 1e-5, 1, 1e5, 1e200} {
+		m := &Matrix3D{elements: [9]float64{s, 0, 0, 0, s, 0, 0, 0, s}}
+		if got, err := m.IsNearSingular(rel); err != nil || got {
+			t.Errorf("IsNearSingular(%v * I) = %v, %v, want false", s, got, err)
+		}
+		m = &Matrix3D{elements: [9]float64{s, 2 * s, 3 * s, 4 * s, 5 * s, 6 * s, 7 * s, 8 * s, 9 * s * (1 + 1e-14)}}
+		if got, err := m.IsNearSingular(rel); err != nil || !got {
+			t.Errorf("IsNearSingular of a rank deficient matrix scaled by %v = %v, %v, want true", s, got, err)
+		}
+	}
+
+	// the absolute criterion applies to the determinant itself
+	m := &Matrix3D{elements: [9]float64{1e-5, 0, 0, 0, 1e-5, 0, 0, 0, 1e-5}}
+	if got, err := m.IsNearSingular(numeric.AbsoluteTolerance(1e-12)); err != nil || !got {
+		t.Errorf("IsNearSingular(1e-5 * I) with an absolute tolerance = %v, %v, want true", got, err)
+	}
+	m = &Matrix3D{elements: [9]float64{1, 2, 3, 0, 0, 0, 7, 8, 9}}
+	if got, err := m.IsNearSingular(numeric.Tolerance{}); err != nil || !got {
+		t.Errorf("IsNearSingular with a zero row = %v, %v, want true", got, err)
+	}
+	if _, err := m.IsNearSingular(numeric.AbsoluteTolerance(-1)); err != numeric.ErrInvalidTol {
+		t.Errorf("IsNearSingular with an invalid tolerance: got %v, want %v", err, numeric.ErrInvalidTol)
+	}
+}
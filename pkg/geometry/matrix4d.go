@@ -1,8 +1,6 @@
 package geometry
 
 import (
-	"math"
-
 	"github.com/tab58/v1/spatial/pkg/numeric"
 	"gonum.org/v1/gonum/blas/blas64"
 )
@@ -192,6 +190,7 @@ func (m *Matrix4D) Sub(mat *Matrix4D) error {
 	return nil
 }
 
+// multiply4DMatrices computes the row-major product b * a.
 func multiply4DMatrices(a, b [16]float64) ([16]float64, error) {
 	a00, a01, a02, a03 := a[0], a[1], a[2], a[3]
 	a10, a11, a12, a13 := a[4], a[5], a[6], a[7]
@@ -228,9 +227,9 @@ func multiply4DMatrices(a, b [16]float64) ([16]float64, error) {
 	return out, nil
 }
 
-// Premultiply left-multiplies the given matrix with this one.
+// Premultiply left-multiplies the given matrix with this one (m = mat * m).
 func (m *Matrix4D) Premultiply(mat *Matrix4D) error {
-	res, err := multiply4DMatrices(mat.elements, m.elements)
+	res, err := multiply4DMatrices(m.elements, mat.elements)
 	if err != nil {
 		return err
 	}
@@ -238,9 +237,9 @@ func (m *Matrix4D) Premultiply(mat *Matrix4D) error {
 	return nil
 }
 
-// Postmultiply right-multiplies the given matrix with this one.
+// Postmultiply right-multiplies the given matrix with this one (m = m * mat).
 func (m *Matrix4D) Postmultiply(mat *Matrix4D) error {
-	res, err := multiply4DMatrices(m.elements, mat.elements)
+	res, err := multiply4DMatrices(mat.elements, m.elements)
 	if err != nil {
 		return err
 	}
@@ -251,6 +250,10 @@ func (m *Matrix4D) Postmultiply(mat *Matrix4D) error {
 // Invert inverts this matrix in-place.
 func (m *Matrix4D) Invert() error {
 	a := m.elements
+	err := checkFinite(a[:])
+	if err != nil {
+		return err
+	}
 	a00, a01, a02, a03 := a[0], a[1], a[2], a[3]
 	a10, a11, a12, a13 := a[4], a[5], a[6], a[7]
 	a20, a21, a22, a23 := a[8], a[9], a[10], a[11]
@@ -271,8 +274,9 @@ func (m *Matrix4D) Invert() error {
 
 	// Calculate the determinant
 	det := b00*b11 - b01*b10 + b02*b09 + b03*b08 - b04*b07 + b05*b06
-	if math.Abs(det) < 1e-13 {
-		return numeric.ErrSingularMatrix
+	err = checkDeterminant(det)
+	if err != nil {
+		return err
 	}
 	det = 1.0 / det
 
@@ -293,8 +297,7 @@ func (m *Matrix4D) Invert() error {
 	out[13] = (a00*b09 - a01*b07 + a02*b06) * det
 	out[14] = (a31*b01 - a30*b03 - a32*b00) * det
 	out[15] = (a20*b03 - a21*b01 + a22*b00) * det
-	m.elements = a
-	return nil
+	return setInverse(m.elements[:], out[:], 4)
 }
 
 // Determinant calculates the determinant of the matrix.
@@ -319,6 +322,29 @@ func (m *Matrix4D) Determinant() float64 {
 	return a13*b6 - a03*b7 + a33*b8 - a23*b9
 }
 
+// DeterminantExtended calculates the determinant of the matrix in double-double precision by Laplace expansion along
+// its top two rows, rounding the result to a float64.
+func (m *Matrix4D) DeterminantExtended() (float64, error) {
+	a := m.elements
+	err := checkFinite(a[:])
+	if err != nil {
+		return 0, err
+	}
+	d := numeric.DifferenceOfProducts
+
+	// 2x2 minors of the top and bottom rows
+	s0, s1, s2 := d(a[0], a[5], a[4], a[1]), d(a[0], a[6], a[4], a[2]), d(a[0], a[7], a[4], a[3])
+	s3, s4, s5 := d(a[1], a[6], a[5], a[2]), d(a[1], a[7], a[5], a[3]), d(a[2], a[7], a[6], a[3])
+	c0, c1, c2 := d(a[8], a[13], a[12], a[9]), d(a[8], a[14], a[12], a[10]), d(a[8], a[15], a[12], a[11])
+	c3, c4, c5 := d(a[9], a[14], a[13], a[10]), d(a[9], a[15], a[13], a[11]), d(a[10], a[15], a[14], a[11])
+
+	det := s0.Mul(c5).Sub(s1.Mul(c4)).Add(s2.Mul(c3)).Add(s3.Mul(c2)).Sub(s4.Mul(c1)).Add(s5.Mul(c0))
+	if det.IsOverflow() {
+		return 0, numeric.ErrOverflow
+	}
+	return det.Float64(), nil
+}
+
 // Adjoint calculates the adjoint/adjugate matrix.
 func (m *Matrix4D) Adjoint() *Matrix4D {
 	a := m.elements
@@ -390,11 +416,176 @@ func (m *Matrix4D) IsSingular() bool {
 	return m.Determinant() == 0
 }
 
-// IsNearSingular returns true if the matrix determinant is equal or below the given tolerance, false if not.
-func (m *Matrix4D) IsNearSingular(tol float64) (bool, error) {
-	if numeric.IsInvalidTolerance(tol) {
-		return false, numeric.ErrInvalidTol
+// IsNearSingular returns true if the matrix determinant is zero within the given tolerance, false if not. The relative
+// criterion is measured against the product of the row norms, the largest determinant of a matrix with those rows.
+func (m *Matrix4D) IsNearSingular(tol numeric.Tolerance) (bool, error) {
+	return isDeterminantNearZero(tol, m.elements[:], 4)
+}
+
+// QR computes the QR decomposition m = Q * R using Householder reflections, where Q is orthogonal and R is upper
+// triangular with a non-negative diagonal.
+func (m *Matrix4D) QR() (*Matrix4D, *Matrix4D, error) {
+	err := checkFinite(m.elements[:])
+	if err != nil {
+		return nil, nil, err
+	}
+	q, r := qrDecompose(m.elements[:], 4)
+	Q, R := &Matrix4D{}, &Matrix4D{}
+	copy(Q.elements[:], q)
+	copy(R.elements[:], r)
+	return Q, R, nil
+}
+
+// LU computes the LU decomposition with partial pivoting, P * m = L * U, where P is a permutation matrix, L is unit
+// lower triangular and U is upper triangular. A singular matrix produces a zero on the diagonal of U.
+func (m *Matrix4D) LU() (*Matrix4D, *Matrix4D, *Matrix4D, error) {
+	err := checkFinite(m.elements[:])
+	if err != nil {
+		return nil, nil, nil, err
 	}
+	lu, perm, _ := luDecompose(m.elements[:], 4)
+	p, l, u := unpackLU(lu, perm, 4)
+	P, L, U := &Matrix4D{}, &Matrix4D{}, &Matrix4D{}
+	copy(P.elements[:], p)
+	copy(L.elements[:], l)
+	copy(U.elements[:], u)
+	return P, L, U, nil
+}
 
-	return math.Abs(m.Determinant()) <= tol, nil
+// Cholesky computes the lower triangular matrix L with a positive diagonal such that m = L * L^T. Only the lower
+// triangle of the matrix is read, and numeric.ErrNotPositiveDefinite is returned if it is not positive definite.
+func (m *Matrix4D) Cholesky() (*Matrix4D, error) {
+	err := checkFinite(m.elements[:])
+	if err != nil {
+		return nil, err
+	}
+	l, err := choleskyDecompose(m.elements[:], 4)
+	if err != nil {
+		return nil, err
+	}
+	L := &Matrix4D{}
+	copy(L.elements[:], l)
+	return L, nil
+}
+
+// SVD computes the singular value decomposition m = U * diag(s) * V^T, where U and V are orthogonal and the singular
+// values s are non-negative and in descending order.
+func (m *Matrix4D) SVD() (*Matrix4D, *Vector4D, *Matrix4D, error) {
+	err := checkFinite(m.elements[:])
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	u, s, v, err := svdDecompose(m.elements[:], 4)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	U, V := &Matrix4D{}, &Matrix4D{}
+	copy(U.elements[:], u)
+	copy(V.elements[:], v)
+	return U, &Vector4D{X: s[0], Y: s[1], Z: s[2], W: s[3]}, V, nil
+}
+
+// singularValues computes the singular values of the matrix in descending order.
+func (m *Matrix4D) singularValues() ([]float64, error) {
+	err := checkFinite(m.elements[:])
+	if err != nil {
+		return nil, err
+	}
+	_, s, _, err := svdDecompose(m.elements[:], 4)
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Rank returns the numerical rank of the matrix, the number of singular values that are not zero within the tolerance
+// at the scale of the largest singular value.
+func (m *Matrix4D) Rank(tol numeric.Tolerance) (int, error) {
+	s, err := m.singularValues()
+	if err != nil {
+		return 0, err
+	}
+	return matrixRank(s, tol)
+}
+
+// ConditionNumber returns the 2-norm condition number of the matrix, the ratio of its largest to smallest singular
+// value, which is infinite for a singular matrix.
+func (m *Matrix4D) ConditionNumber() (float64, error) {
+	s, err := m.singularValues()
+	if err != nil {
+		return 0, err
+	}
+	return conditionNumber(s), nil
+}
+
+// Solve solves the linear system m * x = b for x using LU decomposition with partial pivoting, without forming the
+// inverse. numeric.ErrSingularMatrix is returned if the matrix is singular to working precision, which is when its
+// estimated reciprocal 1-norm condition number is below the machine epsilon.
+func (m *Matrix4D) Solve(b Vector4DReader) (*Vector4D, error) {
+	x, err := solveSystem(m.elements[:], 4, []float64{b.GetX(), b.GetY(), b.GetZ(), b.GetW()}, 1)
+	if err != nil {
+		return nil, err
+	}
+	return &Vector4D{X: x[0], Y: x[1], Z: x[2], W: x[3]}, nil
+}
+
+// SolveMatrix solves the linear system m * X = B for X, each column of B being a separate right-hand side.
+// The same conditions as Solve apply.
+func (m *Matrix4D) SolveMatrix(b *Matrix4D) (*Matrix4D, error) {
+	x, err := solveSystem(m.elements[:], 4, b.elements[:], 4)
+	if err != nil {
+		return nil, err
+	}
+	X := &Matrix4D{}
+	copy(X.elements[:], x)
+	return X, nil
+}
+
+// ConditionEstimate estimates the 1-norm condition number of the matrix from its LU decomposition without forming the
+// inverse. The estimate is a lower bound that is rarely more than a factor of 3 below the true 1-norm condition
+// number, and is infinite for a singular matrix.
+func (m *Matrix4D) ConditionEstimate() (float64, error) {
+	err := checkFinite(m.elements[:])
+	if err != nil {
+		return 0, err
+	}
+	lu, perm, _ := luDecompose(m.elements[:], 4)
+	return luConditionEstimate(m.elements[:], lu, perm, 4), nil
+}
+
+// Exp computes the matrix exponential e^m by scaling and squaring with a Pade approximant.
+func (m *Matrix4D) Exp() (*Matrix4D, error) {
+	e, err := expSlice(m.elements[:], 4)
+	if err != nil {
+		return nil, err
+	}
+	E := &Matrix4D{}
+	copy(E.elements[:], e)
+	return E, nil
+}
+
+// Log computes the principal matrix logarithm, the matrix L with eigenvalues of imaginary part in (-pi, pi) such
+// that e^L = m, by inverse scaling and squaring. numeric.ErrNegativeEigenvalue is returned if the matrix has a negative
+// real eigenvalue and numeric.ErrSingularMatrix if it has a zero eigenvalue.
+func (m *Matrix4D) Log() (*Matrix4D, error) {
+	l, err := logSlice(m.elements[:], 4)
+	if err != nil {
+		return nil, err
+	}
+	L := &Matrix4D{}
+	copy(L.elements[:], l)
+	return L, nil
+}
+
+// Sqrt computes the principal matrix square root, the matrix S with eigenvalues of positive real part such that
+// S * S = m, by the Denman-Beavers iteration. The same errors as Log are returned, and numeric.ErrNotConverged if the
+// iteration fails to converge.
+func (m *Matrix4D) Sqrt() (*Matrix4D, error) {
+	s, err := sqrtSlice(m.elements[:], 4)
+	if err != nil {
+		return nil, err
+	}
+	S := &Matrix4D{}
+	copy(S.elements[:], s)
+	return S, nil
 }
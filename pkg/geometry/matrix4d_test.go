@@ -0,0 +1,195 @@
+package geometry
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/tab58/v1/spatial/pkg/numeric"
+)
+
+func TestMatrix4DDeterminantExtended(t *testing.T) {
+	m := &Matrix4D{elements: [16]float64{
+		1 + 0x1p-30, 1 - 0x1p-30, 0, 0,
+		1 - 0x1p-30, 1 + 0x1p-30, 0, 0,
+		0, 0, 1, 0,
+		0, 0, 0, 1,
+	}}
+	det, err := m.DeterminantExtended()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := 0x1p-28; det != want {
+		t.Errorf("DeterminantExtended = %v, want %v", det, want)
+	}
+
+	tests := []struct {
+		name     string
+		elements [16]float64
+		want     error
+	}{
+		{"product", [16]float64{1e100, 0, 0, 0, 0, 1e100, 0, 0, 0, 0, 1e100, 0, 0, 0, 0, 1e100}, numeric.ErrOverflow},
+		{"infinite", [16]float64{math.Inf(1), 0, 0, 0, 0, 1, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1}, numeric.ErrOverflow},
+		{"NaN", [16]float64{math.NaN(), 0, 0, 0, 0, 1, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1}, numeric.ErrNaN},
+	}
+	for _, tt := range tests {
+		m := &Matrix4D{elements: tt.elements}
+		if _, err := m.DeterminantExtended(); err != tt.want {
+			t.Errorf("DeterminantExtended %s: got %v, want %v", tt.name, err, tt.want)
+		}
+	}
+}
+
+func TestMatrix4DMultiplicationOrder(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		a := &Matrix4D{}
+		b := &Matrix4D{}
+		copy(a.elements[:], randomElements(r, 4))
+		copy(b.elements[:], randomElements(r, 4))
+
+		m := a.Clone()
+		if err := m.Premultiply(b); err != nil {
+			t.Fatal(err)
+		}
+		got := m.Elements()
+		checkClose(t, "Premultiply", got[:], multiplyElements(b.elements[:], a.elements[:], 4), 1e-14)
+
+		m = a.Clone()
+		if err := m.Postmultiply(b); err != nil {
+			t.Fatal(err)
+		}
+		got = m.Elements()
+		checkClose(t, "Postmultiply", got[:], multiplyElements(a.elements[:], b.elements[:], 4), 1e-14)
+	}
+}
+
+func TestMatrix4DInvert(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	for i := 0; i < 100; i++ {
+		a := &Matrix4D{}
+		copy(a.elements[:], randomElements(r, 4))
+
+		// the inverse is stored in the matrix itself
+		m := a.Clone()
+		if err := m.Invert(); err != nil {
+			t.Fatal(err)
+		}
+		if m.Elements() == a.Elements() {
+			t.Fatalf("Invert left %v unchanged", a.Elements())
+		}
+		cond, err := a.ConditionNumber()
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := multiplyElements(m.elements[:], a.elements[:], 4)
+		checkClose(t, "inverse(a) * a", got, identitySlice(4), 1e-14*cond)
+	}
+
+	m := &Matrix4D{elements: [16]float64{1, 2, 3, 4, 2, 4, 6, 8, 0, 0, 1, 0, 0, 0, 0, 1}}
+	if err := m.Invert(); err != numeric.ErrSingularMatrix {
+		t.Errorf("Invert of a singular matrix: got %v, want %v", err, numeric.ErrSingularMatrix)
+	}
+
+	// a tiny determinant alone does not make a matrix singular
+	m = &Matrix4D{elements: [16]float64{1e-5, 0, 0, 0, 0, 1e-5, 0, 0, 0, 0, 1e-5, 0, 0, 0, 0, 1e-5}}
+	if err := m.Invert(); err != nil {
+		t.Fatalf("Invert of 1e-5 * I: %v", err)
+	}
+	checkClose(t, "inverse(1e-5 * I)", m.elements[:], []float64{1e5, 0, 0, 0, 0, 1e5, 0, 0, 0, 0, 1e5, 0, 0, 0, 0, 1e5}, 1e-9)
+
+	// the rows are dependent up to a rounding error, so the determinant is not exactly zero
+	m = &Matrix4D{elements: [16]float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16.000000000000004}}
+	if err := m.Invert(); err != numeric.ErrSingularMatrix {
+		t.Errorf("Invert of a nearly singular matrix: got %v, want %v", err, numeric.ErrSingularMatrix)
+	}
+}
+
+func TestMatrix4DSolve(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	for i := 0; i < 100; i++ {
+		m := &Matrix4D{}
+		copy(m.elements[:], randomElements(r, 4))
+		b := &Vector4D{X: r.NormFloat64(), Y: r.NormFloat64(), Z: r.NormFloat64(), W: r.NormFloat64()}
+		x, err := m.Solve(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// the residual is within the rounding error of the product, independent of the conditioning
+		e, bs := m.elements, []float64{b.X, b.Y, b.Z, b.W}
+		xs := []float64{x.X, x.Y, x.Z, x.W}
+		scale := 0.0
+		for j := 0; j < 4; j++ {
+			for k := 0; k < 4; k++ {
+				scale = math.Max(scale, math.Abs(e[4*j+k]*xs[k]))
+			}
+		}
+		checkClose(t, "m * x", multiplyVector(e[:], xs, 4), bs, 1e-14*math.Max(scale, 1))
+
+		// each column of the solution of m * X = B solves the system for that column
+		B := &Matrix4D{}
+		copy(B.elements[:], randomElements(r, 4))
+		X, err := m.SolveMatrix(B)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cond, err := m.ConditionNumber()
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkClose(t, "m * X", multiplyElements(e[:], X.elements[:], 4), B.elements[:], 1e-14*cond)
+	}
+
+	m := &Matrix4D{elements: [16]float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}}
+	if _, err := m.Solve(&Vector4D{X: 1}); err != numeric.ErrSingularMatrix {
+		t.Errorf("Solve with a singular matrix: got %v, want %v", err, numeric.ErrSingularMatrix)
+	}
+}
+
+func TestMatrix4DConditionEstimate(t *testing.T) {
+	r := rand.New(rand.NewSource(4))
+	for i := 0; i < 1000; i++ {
+		m := &Matrix4D{}
+		copy(m.elements[:], randomElements(r, 4))
+		got, err := m.ConditionEstimate()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		inv := m.Clone()
+		if err := inv.Invert(); err != nil {
+			t.Fatal(err)
+		}
+		// the estimate is rarely more than a factor of 3 low, and the inverse computed for comparison is itself
+		// accurate to about want * eps
+		want := norm1(m.elements[:], 4) * norm1(inv.elements[:], 4)
+		if !(got <= want*(1+1e-14*want) && got >= want/4) {
+			t.Fatalf("ConditionEstimate = %v, want a lower bound within a factor of 4 of %v", got, want)
+		}
+	}
+
+	m := &Matrix4D{elements: [16]float64{1, 2, 3, 4, 2, 4, 6, 8, 0, 0, 1, 0, 0, 0, 0, 1}}
+	if got, err := m.ConditionEstimate(); err != nil || !math.IsInf(got, 1) {
+		t.Errorf("ConditionEstimate of a singular matrix = %v, %v, want +Inf", got, err)
+	}
+}
+
+func TestMatrix4DIsNearSingular(t *testing.T) {
+	// the product of the row norms overflows, but the rows are orthogonal
+	m := &Matrix4D{elements: [16]float64{1e100, 0, 0, 0, 0, 1e100, 0, 0, 0, 0, 1e100, 0, 0, 0, 0, 1e100}}
+	if got, err := m.IsNearSingular(numeric.RelativeTolerance(1e-9)); err != nil || got {
+		t.Errorf("IsNearSingular(1e100 * I) = %v, %v, want false", got, err)
+	}
+	if got, err := m.IsNearSingular(numeric.AbsoluteTolerance(1e-12)); err != nil || got {
+		t.Errorf("IsNearSingular(1e100 * I) with an absolute tolerance = %v, %v, want false", got, err)
+	}
+	m = &Matrix4D{elements: [16]float64{1e100, 2e100, 0, 0, 2e100, 4e100, 0, 0, 0, 0, 1e100, 0, 0, 0, 0, 1e100}}
+	if got, err := m.IsNearSingular(numeric.RelativeTolerance(1e-9)); err != nil || !got {
+		t.Errorf("IsNearSingular of a large rank deficient matrix = %v, %v, want true", got, err)
+	}
+	m.elements[0] = math.NaN()
+	if _, err := m.IsNearSingular(numeric.RelativeTolerance(1e-9)); err != numeric.ErrNaN {
+		t.Errorf("IsNearSingular with a NaN element: got %v, want %v", err, numeric.ErrNaN)
+	}
+}
@@ -0,0 +1,240 @@
+package geometry
+
+import (
+	"math"
+
+	"github.com/tab58/v1/spatial/pkg/numeric"
+	"gonum.org/v1/gonum/mat"
+)
+
+// expPadeDegree is the degree of the diagonal Pade approximant used by the matrix exponential, which is accurate to
+// double precision once the 1-norm of the scaled matrix is at most 1/2.
+const expPadeDegree = 6
+
+// maxSqrtIterations is the maximum number of Denman-Beavers iterations for the matrix square root.
+const maxSqrtIterations = 100
+
+// maxLogSquareRoots is the maximum number of square roots taken by the inverse scaling and squaring logarithm.
+const maxLogSquareRoots = 64
+
+// logGaussNodes and logGaussWeights are the 8-point Gauss-Legendre rule on [0, 1], which evaluates the [8/8] Pade
+// approximant of log(I + X).
+var logGaussNodes = [8]float64{
+	0.019855071751231856, 0.10166676129318664, 0.2372337950418355, 0.4082826787521751,
+	0.5917173212478249, 0.7627662049581645, 0.8983332387068134, 0.9801449282487681,
+}
+var logGaussWeights = [8]float64{
+	0.05061426814518813, 0.11119051722668724, 0.15685332293894363, 0.18134189168918100,
+	0.18134189168918100, 0.15685332293894363, 0.11119051722668724, 0.05061426814518813,
+}
+
+// multiplySlices computes the product a * b of matrices of order n.
+func multiplySlices(a, b []float64, n int) []float64 {
+	c := make([]float64, n*n)
+	for i := 0; i < n; i++ {
+		for k := 0; k < n; k++ {
+			aik := a[i*n+k]
+			for j := 0; j < n; j++ {
+				c[i*n+j] += aik * b[k*n+j]
+			}
+		}
+	}
+	return c
+}
+
+// checkPrincipalEigenvalues returns an error if the matrix has an eigenvalue on the closed negative real axis, where
+// its principal logarithm and square root are undefined or not real.
+func checkPrincipalEigenvalues(a []float64, n int) error {
+	var eig mat.Eigen
+	ok := eig.Factorize(mat.NewDense(n, n, append([]float64(nil), a...)), mat.EigenNone)
+	if !ok {
+		return numeric.ErrNotConverged
+	}
+	for _, v := range eig.Values(nil) {
+		if imag(v) != 0 {
+			continue
+		}
+		if real(v) == 0 {
+			return numeric.ErrSingularMatrix
+		}
+		if real(v) < 0 {
+			return numeric.ErrNegativeEigenvalue
+		}
+	}
+	return nil
+}
+
+// expSlice computes the matrix exponential by scaling and squaring with a diagonal Pade approximant.
+func expSlice(a []float64, n int) ([]float64, error) {
+	err := checkFinite(a)
+	if err != nil {
+		return nil, err
+	}
+
+	// scale so that |A / 2^s| <= 1/2
+	s := 0
+	norm := norm1(a, n)
+	if norm > 0.5 {
+		s = int(math.Max(0, math.Ceil(math.Log2(norm/0.5))))
+	}
+	x := make([]float64, n*n)
+	f := math.Ldexp(1, -s)
+	for i := range a {
+		x[i] = a[i] * f
+	}
+
+	// N = sum c_k X^k, D = sum (-1)^k c_k X^k
+	num := identitySlice(n)
+	den := identitySlice(n)
+	p := identitySlice(n)
+	c := 1.0
+	for k := 1; k <= expPadeDegree; k++ {
+		c *= float64(expPadeDegree-k+1) / float64(k*(2*expPadeDegree-k+1))
+		p = multiplySlices(p, x, n)
+		sign := 1.0
+		if k%2 == 1 {
+			sign = -1.0
+		}
+		for i := range p {
+			num[i] += c * p[i]
+			den[i] += sign * c * p[i]
+		}
+	}
+	e, err := solveSystem(den, n, num, n)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := 0; i < s; i++ {
+		e = multiplySlices(e, e, n)
+	}
+	err = checkFinite(e)
+	if err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// sqrtSlice computes the principal matrix square root by the product form of the Denman-Beavers iteration.
+func sqrtSlice(a []float64, n int) ([]float64, error) {
+	err := checkFinite(a)
+	if err != nil {
+		return nil, err
+	}
+	err = checkPrincipalEigenvalues(a, n)
+	if err != nil {
+		return nil, err
+	}
+	return denmanBeavers(a, n)
+}
+
+// denmanBeavers computes the principal square root of A by the product form of the Denman-Beavers iteration,
+// X = X * (I + M^-1) / 2, M = (I + (M + M^-1) / 2) / 2 from X = M = A, under which M converges to I and X to sqrt(A)
+// for matrices without eigenvalues on the closed negative real axis. The iteration stops when the relative change in X
+// reaches the rounding level or, once M is close to I, when the change stops decreasing, since rounding errors in the
+// inverse then dominate it.
+func denmanBeavers(a []float64, n int) ([]float64, error) {
+	x := append([]float64(nil), a...)
+	m := append([]float64(nil), a...)
+	id := identitySlice(n)
+	d := make([]float64, n*n)
+	prev := math.Inf(1)
+	for iter := 0; iter < maxSqrtIterations; iter++ {
+		mi, err := solveSystem(m, n, id, n)
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range d {
+			d[i] = m[i] - id[i]
+		}
+		isNearIdentity := norm1(d, n) <= 0.5
+
+		for i := range d {
+			d[i] = (id[i] + mi[i]) / 2
+			m[i] = (id[i] + (m[i]+mi[i])/2) / 2
+		}
+		next := multiplySlices(x, d, n)
+		for i := range d {
+			d[i] = next[i] - x[i]
+		}
+		change := norm1(d, n) / norm1(next, n)
+		x = next
+		err = checkFinite(x)
+		if err != nil {
+			return nil, err
+		}
+
+		if change <= float64(n)*machineEpsilon || isNearIdentity && change >= prev {
+			return x, nil
+		}
+		prev = change
+	}
+	return nil, numeric.ErrNotConverged
+}
+
+// logSlice computes the principal matrix logarithm by inverse scaling and squaring: square roots are taken until the
+// matrix is close to the identity, then log(I + X) is evaluated by a Pade approximant and scaled back up.
+func logSlice(a []float64, n int) ([]float64, error) {
+	err := checkFinite(a)
+	if err != nil {
+		return nil, err
+	}
+	err = checkPrincipalEigenvalues(a, n)
+	if err != nil {
+		return nil, err
+	}
+
+	// the square roots are taken of r, and x = r - I is kept separately since adding I back would lose the low bits
+	// of small eigenvalues
+	r := append([]float64(nil), a...)
+	x := make([]float64, n*n)
+	k := 0
+	for {
+		copy(x, r)
+		for i := 0; i < n; i++ {
+			x[i*n+i]--
+		}
+		if norm1(x, n) <= 0.25 {
+			break
+		}
+		if k == maxLogSquareRoots {
+			return nil, numeric.ErrNotConverged
+		}
+		r, err = denmanBeavers(r, n)
+		if err != nil {
+			return nil, err
+		}
+		k++
+	}
+
+	// log(I + X) = sum w_j * X * (I + t_j * X)^-1
+	l := make([]float64, n*n)
+	for j, t := range logGaussNodes {
+		m := make([]float64, n*n)
+		for i := range x {
+			m[i] = t * x[i]
+		}
+		for i := 0; i < n; i++ {
+			m[i*n+i]++
+		}
+		// X and (I + t X)^-1 commute, so solve (I + t X) * Y = X
+		y, err := solveSystem(m, n, x, n)
+		if err != nil {
+			return nil, err
+		}
+		for i := range l {
+			l[i] += logGaussWeights[j] * y[i]
+		}
+	}
+
+	f := math.Ldexp(1, k)
+	for i := range l {
+		l[i] *= f
+	}
+	err = checkFinite(l)
+	if err != nil {
+		return nil, err
+	}
+	return l, nil
+}
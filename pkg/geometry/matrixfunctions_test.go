@@ -0,0 +1,162 @@
+package geometry
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/tab58/v1/spatial/pkg/numeric"
+)
+
+// maxAbs returns the largest absolute value of the elements.
+func maxAbs(a []float64) float64 {
+	m := 0.0
+	for _, v := range a {
+		m = math.Max(m, math.Abs(v))
+	}
+	return m
+}
+
+func TestMatrixExpLogSqrt(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		// with a norm this small the eigenvalues of a lie well inside the strip where log(exp(a)) = a
+		a := &Matrix4D{}
+		copy(a.elements[:], randomElements(r, 4))
+		if err := a.Scale(0.25 / maxAbs(a.elements[:])); err != nil {
+			t.Fatal(err)
+		}
+
+		e, err := a.Exp()
+		if err != nil {
+			t.Fatal(err)
+		}
+		l, err := e.Log()
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkClose(t, "log(exp(a))", l.elements[:], a.elements[:], 1e-14)
+
+		// e^a * e^-a = I, since a and -a commute
+		neg := a.Clone()
+		if err := neg.Scale(-1); err != nil {
+			t.Fatal(err)
+		}
+		en, err := neg.Exp()
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkClose(t, "exp(a) * exp(-a)", multiplyElements(e.elements[:], en.elements[:], 4), identitySlice(4), 1e-14)
+
+		// sqrt(e^a) = e^(a/2)
+		s, err := e.Sqrt()
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkClose(t, "sqrt(exp(a))^2", multiplyElements(s.elements[:], s.elements[:], 4), e.elements[:], 1e-14)
+		half := a.Clone()
+		if err := half.Scale(0.5); err != nil {
+			t.Fatal(err)
+		}
+		eh, err := half.Exp()
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkClose(t, "sqrt(exp(a))", s.elements[:], eh.elements[:], 1e-14)
+	}
+}
+
+func TestMatrixExpRotation(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	for i := 0; i < 100; i++ {
+		// e^hat(w) is the rotation about w by |w|, given by the Rodrigues formula
+		w := randomVector3D(r)
+		if err := w.Scale(2); err != nil {
+			t.Fatal(err)
+		}
+		theta, err := w.Length()
+		if err != nil {
+			t.Fatal(err)
+		}
+		k := Hat(w).elements[:]
+		k2 := multiplyElements(k, k, 3)
+		want := identitySlice(3)
+		for j := range want {
+			want[j] += math.Sin(theta)/theta*k[j] + (1-math.Cos(theta))/(theta*theta)*k2[j]
+		}
+		e, err := Hat(w).Exp()
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkClose(t, "exp(hat(w))", e.elements[:], want, 1e-13)
+
+		// the principal logarithm recovers the generator for angles below pi
+		if theta < math.Pi-1e-3 {
+			l, err := e.Log()
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkClose(t, "log(exp(hat(w)))", l.elements[:], k, 1e-12)
+		}
+	}
+}
+
+func TestMatrixFunctionsDiagonal(t *testing.T) {
+	m := &Matrix2D{elements: [4]float64{1, 0, 0, -2}}
+	e, err := m.Exp()
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkClose(t, "exp(diag(1, -2))", e.elements[:], []float64{math.E, 0, 0, math.Exp(-2)}, 1e-15)
+
+	m = &Matrix2D{elements: [4]float64{4, 0, 0, 9}}
+	s, err := m.Sqrt()
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkClose(t, "sqrt(diag(4, 9))", s.elements[:], []float64{2, 0, 0, 3}, 1e-15)
+	l, err := m.Log()
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkClose(t, "log(diag(4, 9))", l.elements[:], []float64{math.Log(4), 0, 0, math.Log(9)}, 1e-14)
+
+	// a large norm is handled by the squaring phase
+	big := &Matrix3D{elements: [9]float64{10, 3, 0, 0, 10, 0, 0, 0, -30}}
+	e3, err := big.Exp()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []float64{math.Exp(10), 3 * math.Exp(10), 0, 0, math.Exp(10), 0, 0, 0, math.Exp(-30)}
+	for j := range want {
+		if !(math.Abs(e3.elements[j]-want[j]) <= 1e-13*math.Abs(want[j])) {
+			t.Fatalf("exp = %v, want %v", e3.elements, want)
+		}
+	}
+}
+
+func TestMatrixFunctionsErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		elements [4]float64
+		want     error
+	}{
+		{"negative eigenvalue", [4]float64{-1, 0, 0, 2}, numeric.ErrNegativeEigenvalue},
+		{"zero eigenvalue", [4]float64{0, 0, 0, 2}, numeric.ErrSingularMatrix},
+		{"NaN", [4]float64{math.NaN(), 0, 0, 1}, numeric.ErrNaN},
+	}
+	for _, tt := range tests {
+		m := &Matrix2D{elements: tt.elements}
+		if _, err := m.Log(); err != tt.want {
+			t.Errorf("Log %s: got %v, want %v", tt.name, err, tt.want)
+		}
+		if _, err := m.Sqrt(); err != tt.want {
+			t.Errorf("Sqrt %s: got %v, want %v", tt.name, err, tt.want)
+		}
+	}
+
+	m := &Matrix2D{elements: [4]float64{math.Inf(1), 0, 0, 1}}
+	if _, err := m.Exp(); err != numeric.ErrOverflow {
+		t.Errorf("Exp of an infinite matrix: got %v, want %v", err, numeric.ErrOverflow)
+	}
+}
@@ -0,0 +1,295 @@
+package geometry
+
+import (
+	"github.com/tab58/v1/spatial/pkg/numeric"
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+)
+
+// MatrixMN is a row-major representation of a matrix with any positive number of rows and columns, backed by a
+// blas64.General.
+type MatrixMN struct {
+	data blas64.General
+}
+
+// NewMatrixMN creates a zero matrix with the given number of rows and columns.
+func NewMatrixMN(rows, cols uint) (*MatrixMN, error) {
+	if rows == 0 || cols == 0 {
+		return nil, numeric.ErrEmptyArray
+	}
+	return &MatrixMN{
+		data: blas64.General{Rows: int(rows), Cols: int(cols), Stride: int(cols), Data: make([]float64, rows*cols)},
+	}, nil
+}
+
+// NewMatrixMNFromSlice creates a matrix with a copy of the given row-major elements.
+func NewMatrixMNFromSlice(rows, cols uint, elements []float64) (*MatrixMN, error) {
+	m, err := NewMatrixMN(rows, cols)
+	if err != nil {
+		return nil, err
+	}
+	if uint(len(elements)) != rows*cols {
+		return nil, numeric.ErrMatrixDims
+	}
+	copy(m.data.Data, elements)
+	return m, nil
+}
+
+// NewMatrixMNFromBlas creates a matrix with a copy of the elements of the BLAS matrix.
+func NewMatrixMNFromBlas(g blas64.General) (*MatrixMN, error) {
+	if g.Rows <= 0 || g.Cols <= 0 {
+		return nil, numeric.ErrEmptyArray
+	}
+	m, err := NewMatrixMN(uint(g.Rows), uint(g.Cols))
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i < g.Rows; i++ {
+		copy(m.data.Data[i*g.Cols:(i+1)*g.Cols], g.Data[i*g.Stride:i*g.Stride+g.Cols])
+	}
+	return m, nil
+}
+
+// NewMatrixMNFromMatrix2D creates a 2x2 matrix from the given matrix.
+func NewMatrixMNFromMatrix2D(mat *Matrix2D) *MatrixMN {
+	return &MatrixMN{data: mat.ToBlas64General()}
+}
+
+// NewMatrixMNFromMatrix3D creates a 3x3 matrix from the given matrix.
+func NewMatrixMNFromMatrix3D(mat *Matrix3D) *MatrixMN {
+	return &MatrixMN{data: mat.ToBlas64General()}
+}
+
+// NewMatrixMNFromMatrix4D creates a 4x4 matrix from the given matrix.
+func NewMatrixMNFromMatrix4D(mat *Matrix4D) *MatrixMN {
+	return &MatrixMN{data: mat.ToBlas64General()}
+}
+
+// Rows returns the number of rows in the matrix.
+func (m *MatrixMN) Rows() uint { return uint(m.data.Rows) }
+
+// Cols returns the number of columns in the matrix.
+func (m *MatrixMN) Cols() uint { return uint(m.data.Cols) }
+
+// Clone returns a deep copy of the matrix.
+func (m *MatrixMN) Clone() *MatrixMN {
+	return &MatrixMN{data: m.ToBlas64General()}
+}
+
+// Copy copies the elements of the given matrix, which must have the same dimensions, to this one.
+func (m *MatrixMN) Copy(mat *MatrixMN) error {
+	if m.data.Rows != mat.data.Rows || m.data.Cols != mat.data.Cols {
+		return numeric.ErrMatrixDims
+	}
+	copy(m.data.Data, mat.data.Data)
+	return nil
+}
+
+// Identity sets the matrix to the identity matrix. The matrix must be square.
+func (m *MatrixMN) Identity() error {
+	if m.data.Rows != m.data.Cols {
+		return numeric.ErrMatrixDims
+	}
+	for i := range m.data.Data {
+		m.data.Data[i] = 0
+	}
+	for i := 0; i < m.data.Rows; i++ {
+		m.data.Data[i*m.data.Stride+i] = 1
+	}
+	return nil
+}
+
+// ElementAt returns the value of the element at the given indices.
+func (m *MatrixMN) ElementAt(i, j uint) (float64, error) {
+	if i >= m.Rows() || j >= m.Cols() {
+		return 0, numeric.ErrMatrixOutOfRange
+	}
+	return m.data.Data[int(i)*m.data.Stride+int(j)], nil
+}
+
+// SetElementAt sets the value of the element at the given indices.
+func (m *MatrixMN) SetElementAt(i, j uint, value float64) error {
+	if i >= m.Rows() || j >= m.Cols() {
+		return numeric.ErrMatrixOutOfRange
+	}
+	m.data.Data[int(i)*m.data.Stride+int(j)] = value
+	return nil
+}
+
+// Elements clones the row-major elements of the matrix and returns them.
+func (m *MatrixMN) Elements() []float64 {
+	tmp := make([]float64, len(m.data.Data))
+	copy(tmp, m.data.Data)
+	return tmp
+}
+
+// ToBlas64General returns a blas64.General with the same values as the matrix.
+func (m *MatrixMN) ToBlas64General() blas64.General {
+	return blas64.General{
+		Rows:   m.data.Rows,
+		Cols:   m.data.Cols,
+		Stride: m.data.Stride,
+		Data:   m.Elements(),
+	}
+}
+
+// ToMatrix2D converts the matrix to a Matrix2D. It must be 2x2.
+func (m *MatrixMN) ToMatrix2D() (*Matrix2D, error) {
+	if m.data.Rows != 2 || m.data.Cols != 2 {
+		return nil, numeric.ErrMatrixDims
+	}
+	mat := &Matrix2D{}
+	copy(mat.elements[:], m.data.Data)
+	return mat, nil
+}
+
+// ToMatrix3D converts the matrix to a Matrix3D. It must be 3x3.
+func (m *MatrixMN) ToMatrix3D() (*Matrix3D, error) {
+	if m.data.Rows != 3 || m.data.Cols != 3 {
+		return nil, numeric.ErrMatrixDims
+	}
+	mat := &Matrix3D{}
+	copy(mat.elements[:], m.data.Data)
+	return mat, nil
+}
+
+// ToMatrix4D converts the matrix to a Matrix4D. It must be 4x4.
+func (m *MatrixMN) ToMatrix4D() (*Matrix4D, error) {
+	if m.data.Rows != 4 || m.data.Cols != 4 {
+		return nil, numeric.ErrMatrixDims
+	}
+	mat := &Matrix4D{}
+	copy(mat.elements[:], m.data.Data)
+	return mat, nil
+}
+
+// Block returns a copy of the block of the given size whose top left element is at the given indices.
+func (m *MatrixMN) Block(i, j, rows, cols uint) (*MatrixMN, error) {
+	if rows == 0 || cols == 0 || i+rows > m.Rows() || j+cols > m.Cols() {
+		return nil, numeric.ErrMatrixOutOfRange
+	}
+	b, err := NewMatrixMN(rows, cols)
+	if err != nil {
+		return nil, err
+	}
+	for r := 0; r < int(rows); r++ {
+		start := (int(i)+r)*m.data.Stride + int(j)
+		copy(b.data.Data[r*int(cols):(r+1)*int(cols)], m.data.Data[start:start+int(cols)])
+	}
+	return b, nil
+}
+
+// SetBlock copies the elements of the given matrix into this one with its top left element at the given indices.
+func (m *MatrixMN) SetBlock(i, j uint, b *MatrixMN) error {
+	if i+b.Rows() > m.Rows() || j+b.Cols() > m.Cols() {
+		return numeric.ErrMatrixOutOfRange
+	}
+	for r := 0; r < b.data.Rows; r++ {
+		start := (int(i)+r)*m.data.Stride + int(j)
+		copy(m.data.Data[start:start+b.data.Cols], b.data.Data[r*b.data.Stride:r*b.data.Stride+b.data.Cols])
+	}
+	return nil
+}
+
+// Row returns a copy of the row at the given index.
+func (m *MatrixMN) Row(i uint) (*VectorN, error) {
+	if i >= m.Rows() {
+		return nil, numeric.ErrMatrixOutOfRange
+	}
+	start := int(i) * m.data.Stride
+	return NewVectorNFromSlice(m.data.Data[start : start+m.data.Cols])
+}
+
+// Col returns a copy of the column at the given index.
+func (m *MatrixMN) Col(j uint) (*VectorN, error) {
+	if j >= m.Cols() {
+		return nil, numeric.ErrMatrixOutOfRange
+	}
+	return NewVectorNFromBlas(blas64.Vector{N: m.data.Rows, Data: m.data.Data[j:], Inc: m.data.Stride})
+}
+
+// Scale multiplies the elements of the matrix by the given scalar.
+func (m *MatrixMN) Scale(z float64) error {
+	tmp := m.Elements()
+	for i := range tmp {
+		tmp[i] *= z
+	}
+	if numeric.AreAnyOverflow(tmp...) {
+		return numeric.ErrOverflow
+	}
+	m.data.Data = tmp
+	return nil
+}
+
+// Add adds the elements of the given matrix, which must have the same dimensions, to the elements of this matrix.
+func (m *MatrixMN) Add(mat *MatrixMN) error {
+	return m.addScaled(1, mat)
+}
+
+// Sub subtracts the elements of the given matrix, which must have the same dimensions, from the elements of this matrix.
+func (m *MatrixMN) Sub(mat *MatrixMN) error {
+	return m.addScaled(-1, mat)
+}
+
+// addScaled adds f * mat to this matrix, leaving it unchanged on overflow.
+func (m *MatrixMN) addScaled(f float64, mat *MatrixMN) error {
+	if m.data.Rows != mat.data.Rows || m.data.Cols != mat.data.Cols {
+		return numeric.ErrMatrixDims
+	}
+	tmp := m.Elements()
+	for i, v := range mat.data.Data {
+		tmp[i] += f * v
+	}
+	if numeric.AreAnyOverflow(tmp...) {
+		return numeric.ErrOverflow
+	}
+	m.data.Data = tmp
+	return nil
+}
+
+// multiplyMNMatrices computes a * b.
+func multiplyMNMatrices(a, b blas64.General) (blas64.General, error) {
+	if a.Cols != b.Rows {
+		return blas64.General{}, numeric.ErrMatrixDims
+	}
+	c := blas64.General{Rows: a.Rows, Cols: b.Cols, Stride: b.Cols, Data: make([]float64, a.Rows*b.Cols)}
+	blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, a, b, 0, c)
+	if numeric.AreAnyOverflow(c.Data...) {
+		return blas64.General{}, numeric.ErrOverflow
+	}
+	return c, nil
+}
+
+// Premultiply left-multiplies the given matrix with this one, m = mat * m. The column count of mat must equal the
+// row count of this matrix, which takes the row count of mat.
+func (m *MatrixMN) Premultiply(mat *MatrixMN) error {
+	res, err := multiplyMNMatrices(mat.data, m.data)
+	if err != nil {
+		return err
+	}
+	m.data = res
+	return nil
+}
+
+// Postmultiply right-multiplies the given matrix with this one, m = m * mat. The row count of mat must equal the
+// column count of this matrix, which takes the column count of mat.
+func (m *MatrixMN) Postmultiply(mat *MatrixMN) error {
+	res, err := multiplyMNMatrices(m.data, mat.data)
+	if err != nil {
+		return err
+	}
+	m.data = res
+	return nil
+}
+
+// Transpose transposes the matrix in-place, swapping its row and column counts.
+func (m *MatrixMN) Transpose() {
+	rows, cols := m.data.Rows, m.data.Cols
+	tmp := make([]float64, rows*cols)
+	for i := 0; i < rows; i++ {
+		for j := 0; j < cols; j++ {
+			tmp[j*rows+i] = m.data.Data[i*m.data.Stride+j]
+		}
+	}
+	m.data = blas64.General{Rows: cols, Cols: rows, Stride: rows, Data: tmp}
+}
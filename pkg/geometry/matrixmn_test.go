@@ -0,0 +1,158 @@
+package geometry
+
+import (
+	"testing"
+
+	"github.com/tab58/v1/spatial/pkg/numeric"
+	"gonum.org/v1/gonum/blas/blas64"
+)
+
+// sequenceMatrixMN returns a matrix whose elements are 1, 2, 3, ... in row-major order.
+func sequenceMatrixMN(t *testing.T, rows, cols uint) *MatrixMN {
+	t.Helper()
+	e := make([]float64, rows*cols)
+	for i := range e {
+		e[i] = float64(i + 1)
+	}
+	m, err := NewMatrixMNFromSlice(rows, cols, e)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func TestMatrixMNBlock(t *testing.T) {
+	m := sequenceMatrixMN(t, 4, 5)
+	b, err := m.Block(1, 2, 2, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.Rows() != 2 || b.Cols() != 3 {
+		t.Fatalf("Block is %dx%d, want 2x3", b.Rows(), b.Cols())
+	}
+	checkClose(t, "Block", b.Elements(), []float64{8, 9, 10, 13, 14, 15}, 0)
+
+	// the block is a copy
+	if err := b.SetElementAt(0, 0, -1); err != nil {
+		t.Fatal(err)
+	}
+	if v, _ := m.ElementAt(1, 2); v != 8 {
+		t.Fatalf("modifying the block changed the matrix to %v", m.Elements())
+	}
+
+	// writing the block back into a zero matrix places it at the same indices
+	z, err := NewMatrixMN(4, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := z.SetBlock(1, 2, b); err != nil {
+		t.Fatal(err)
+	}
+	checkClose(t, "SetBlock", z.Elements(), []float64{
+		0, 0, 0, 0, 0,
+		0, 0, -1, 9, 10,
+		0, 0, 13, 14, 15,
+		0, 0, 0, 0, 0,
+	}, 0)
+
+	// a block round trip leaves the matrix unchanged
+	b, err = m.Block(2, 0, 2, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := m.Clone()
+	if err := c.SetBlock(2, 0, b); err != nil {
+		t.Fatal(err)
+	}
+	checkClose(t, "SetBlock(Block)", c.Elements(), m.Elements(), 0)
+
+	if _, err := m.Block(3, 0, 2, 1); err != numeric.ErrMatrixOutOfRange {
+		t.Errorf("Block past the last row: got %v, want %v", err, numeric.ErrMatrixOutOfRange)
+	}
+	if _, err := m.Block(0, 0, 0, 1); err != numeric.ErrMatrixOutOfRange {
+		t.Errorf("empty Block: got %v, want %v", err, numeric.ErrMatrixOutOfRange)
+	}
+	if err := m.SetBlock(0, 4, b); err != numeric.ErrMatrixOutOfRange {
+		t.Errorf("SetBlock past the last column: got %v, want %v", err, numeric.ErrMatrixOutOfRange)
+	}
+}
+
+func TestMatrixMNRowCol(t *testing.T) {
+	m := sequenceMatrixMN(t, 3, 4)
+	row, err := m.Row(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkClose(t, "Row", row.Components(), []float64{5, 6, 7, 8}, 0)
+	col, err := m.Col(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkClose(t, "Col", col.Components(), []float64{3, 7, 11}, 0)
+	if _, err := m.Col(4); err != numeric.ErrMatrixOutOfRange {
+		t.Errorf("Col past the last column: got %v, want %v", err, numeric.ErrMatrixOutOfRange)
+	}
+}
+
+func TestMatrixMNFixedSizeConversions(t *testing.T) {
+	m3 := &Matrix3D{elements: [9]float64{1, 2, 3, 4, 5, 6, 7, 8, 9}}
+	m := NewMatrixMNFromMatrix3D(m3)
+	got, err := m.ToMatrix3D()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Elements() != m3.Elements() {
+		t.Errorf("ToMatrix3D(NewMatrixMNFromMatrix3D(m)) = %v, want %v", got.Elements(), m3.Elements())
+	}
+
+	// a fixed-size matrix goes in and comes out of a larger matrix as a block
+	big, err := NewMatrixMN(6, 6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := big.SetBlock(3, 3, m); err != nil {
+		t.Fatal(err)
+	}
+	b, err := big.Block(3, 3, 3, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err = b.ToMatrix3D()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Elements() != m3.Elements() {
+		t.Errorf("3x3 block = %v, want %v", got.Elements(), m3.Elements())
+	}
+
+	m4 := &Matrix4D{elements: [16]float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}}
+	got4, err := NewMatrixMNFromMatrix4D(m4).ToMatrix4D()
+	if err != nil || got4.Elements() != m4.Elements() {
+		t.Errorf("ToMatrix4D(NewMatrixMNFromMatrix4D(m)) = %v, %v, want %v", got4.Elements(), err, m4.Elements())
+	}
+	m2 := &Matrix2D{elements: [4]float64{1, 2, 3, 4}}
+	got2, err := NewMatrixMNFromMatrix2D(m2).ToMatrix2D()
+	if err != nil || got2.Elements() != m2.Elements() {
+		t.Errorf("ToMatrix2D(NewMatrixMNFromMatrix2D(m)) = %v, %v, want %v", got2.Elements(), err, m2.Elements())
+	}
+	if _, err := big.ToMatrix3D(); err != numeric.ErrMatrixDims {
+		t.Errorf("ToMatrix3D of a 6x6 matrix: got %v, want %v", err, numeric.ErrMatrixDims)
+	}
+}
+
+func TestNewMatrixMNFromBlas(t *testing.T) {
+	// the padding at the end of each row is skipped
+	g := blas64.General{Rows: 2, Cols: 2, Stride: 3, Data: []float64{1, 2, -1, 3, 4, -1}}
+	m, err := NewMatrixMNFromBlas(g)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkClose(t, "NewMatrixMNFromBlas", m.Elements(), []float64{1, 2, 3, 4}, 0)
+	g.Data[0] = 10
+	if v, _ := m.ElementAt(0, 0); v != 1 {
+		t.Error("NewMatrixMNFromBlas does not copy the elements")
+	}
+	if _, err := NewMatrixMNFromBlas(blas64.General{}); err != numeric.ErrEmptyArray {
+		t.Errorf("NewMatrixMNFromBlas of an empty matrix: got %v, want %v", err, numeric.ErrEmptyArray)
+	}
+}
@@ -14,7 +14,7 @@ type Point2DReader interface {
 	Clone() *Point2D
 	AsVector() *Vector2D
 	DistanceTo(q Point2DReader) (float64, error)
-	IsEqualTo(q Point2DReader, tol float64) (bool, error)
+	IsEqualTo(q Point2DReader, tol numeric.Tolerance) (bool, error)
 }
 
 // Point2DWriter is a read-only interface for vectors.
@@ -26,6 +26,7 @@ type Point2DWriter interface {
 	Scale(f float64) error
 	Add(v Vector2DReader) error
 	Sub(v Vector2DReader) error
+	RotateAbout(pivot Point2DReader, angleRad float64) error
 }
 
 // Origin2D is the canonical origin in the 2D plane.
@@ -147,15 +148,30 @@ func (p *Point2D) DistanceTo(q Point2DReader) (float64, error) {
 }
 
 // IsEqualTo returns true if 2 points can be considered equal to within a specific tolerance, false if not.
-func (p *Point2D) IsEqualTo(q Point2DReader, tol float64) (bool, error) {
-	if numeric.IsInvalidTolerance(tol) {
-		return false, numeric.ErrInvalidTol
-	}
+func (p *Point2D) IsEqualTo(q Point2DReader, tol numeric.Tolerance) (bool, error) {
 	px, py := p.GetX(), p.GetY()
 	qx, qy := q.GetX(), q.GetY()
+	return areComponentsEqual(tol, []float64{px, py}, []float64{qx, qy})
+}
 
-	x := math.Abs(px - qx)
-	y := math.Abs(py - qy)
-	isEqual := x <= tol && y <= tol
-	return isEqual, nil
+// RotateAbout rotates the point counterclockwise about the given pivot point by the given angle.
+func (p *Point2D) RotateAbout(pivot Point2DReader, angleRad float64) error {
+	v := p.AsVector()
+	c := pivot.AsVector()
+	err := v.Sub(c)
+	if err != nil {
+		return err
+	}
+	err = v.RotateBy(angleRad)
+	if err != nil {
+		return err
+	}
+	err = v.Add(c)
+	if err != nil {
+		return err
+	}
+
+	p.SetX(v.GetX())
+	p.SetY(v.GetY())
+	return nil
 }
@@ -0,0 +1,21 @@
+package geometry
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPoint2DRotateAbout(t *testing.T) {
+	p := &Point2D{X: 3, Y: 1}
+	if err := p.RotateAbout(&Point2D{X: 1, Y: 1}, math.Pi/2); err != nil {
+		t.Fatal(err)
+	}
+	checkClose(t, "RotateAbout", []float64{p.X, p.Y}, []float64{1, 3}, 1e-15)
+
+	// the pivot is fixed
+	c := &Point2D{X: -2, Y: 7}
+	if err := c.RotateAbout(&Point2D{X: -2, Y: 7}, 1.234); err != nil {
+		t.Fatal(err)
+	}
+	checkClose(t, "pivot", []float64{c.X, c.Y}, []float64{-2, 7}, 0)
+}
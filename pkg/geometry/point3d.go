@@ -1,8 +1,6 @@
 package geometry
 
 import (
-	"math"
-
 	"github.com/tab58/v1/spatial/pkg/numeric"
 )
 
@@ -15,7 +13,7 @@ type Point3DReader interface {
 	Clone() *Point3D
 	AsVector() *Vector3D
 	DistanceTo(q Point3DReader) (float64, error)
-	IsEqualTo(q Point3DReader, tol float64) (bool, error)
+	IsEqualTo(q Point3DReader, tol numeric.Tolerance) (bool, error)
 }
 
 // Point3DWriter is a write-only interface for vectors.
@@ -23,6 +21,8 @@ type Point3DWriter interface {
 	SetX(float64)
 	SetY(float64)
 	SetZ(float64)
+
+	RotateAbout(pivot Point3DReader, axis Vector3DReader, angleRad float64) error
 }
 
 // Origin3D is the canonical origin in the 3D plane.
@@ -94,17 +94,45 @@ func (p *Point3D) DistanceTo(q Point3DReader) (float64, error) {
 }
 
 // IsEqualTo returns true if 2 points can be considered equal to within a specific tolerance, false if not.
-func (p *Point3D) IsEqualTo(q Point3DReader, tol float64) (bool, error) {
-	if numeric.IsInvalidTolerance(tol) {
-		return false, numeric.ErrInvalidTol
-	}
-
+func (p *Point3D) IsEqualTo(q Point3DReader, tol numeric.Tolerance) (bool, error) {
 	px, py, pz := p.GetX(), p.GetY(), p.GetZ()
 	qx, qy, qz := q.GetX(), q.GetY(), q.GetZ()
+	return areComponentsEqual(tol, []float64{px, py, pz}, []float64{qx, qy, qz})
+}
+
+// RotateAbout rotates the point about the line through the given pivot point along the given axis by the given angle (right-hand rule).
+func (p *Point3D) RotateAbout(pivot Point3DReader, axis Vector3DReader, angleRad float64) error {
+	v := p.AsVector()
+	c := pivot.AsVector()
+	err := v.Sub(c)
+	if err != nil {
+		return err
+	}
+	err = v.RotateBy(axis, angleRad)
+	if err != nil {
+		return err
+	}
+	err = v.Add(c)
+	if err != nil {
+		return err
+	}
+
+	p.SetX(v.GetX())
+	p.SetY(v.GetY())
+	p.SetZ(v.GetZ())
+	return nil
+}
+
+// Lerp linearly interpolates this point towards the given point, where t = 0 leaves this point unchanged and t = 1 gives q.
+func (p *Point3D) Lerp(q Point3DReader, t float64) error {
+	v := p.AsVector()
+	err := v.Lerp(q.AsVector(), t)
+	if err != nil {
+		return err
+	}
 
-	resX := math.Abs(qx - px)
-	resY := math.Abs(qy - py)
-	resZ := math.Abs(qz - pz)
-	isEqual := resX <= tol && resY <= tol && resZ <= tol
-	return isEqual, nil
+	p.SetX(v.GetX())
+	p.SetY(v.GetY())
+	p.SetZ(v.GetZ())
+	return nil
 }
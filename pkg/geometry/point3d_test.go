@@ -0,0 +1,30 @@
+package geometry
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPoint3DRotateAbout(t *testing.T) {
+	// a quarter turn about the vertical line through (1, 1, 0)
+	p := &Point3D{X: 3, Y: 1, Z: 4}
+	if err := p.RotateAbout(&Point3D{X: 1, Y: 1, Z: -6}, &Vector3D{Z: 1}, math.Pi/2); err != nil {
+		t.Fatal(err)
+	}
+	checkClose(t, "RotateAbout", []float64{p.X, p.Y, p.Z}, []float64{1, 3, 4}, 1e-14)
+
+	// points on the axis are fixed
+	q := &Point3D{X: 2, Y: 3, Z: 4}
+	if err := q.RotateAbout(&Point3D{X: 1, Y: 1, Z: 1}, &Vector3D{X: 1, Y: 2, Z: 3}, 2.5); err != nil {
+		t.Fatal(err)
+	}
+	checkClose(t, "point on the axis", []float64{q.X, q.Y, q.Z}, []float64{2, 3, 4}, 1e-14)
+}
+
+func TestPoint3DLerp(t *testing.T) {
+	p := &Point3D{X: 1, Y: 2, Z: 3}
+	if err := p.Lerp(&Point3D{X: 5, Y: 2, Z: -1}, 0.75); err != nil {
+		t.Fatal(err)
+	}
+	checkClose(t, "Lerp", []float64{p.X, p.Y, p.Z}, []float64{4, 2, 0}, 0)
+}
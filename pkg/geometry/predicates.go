@@ -0,0 +1,245 @@
+package geometry
+
+import (
+	"math"
+
+	"github.com/tab58/v1/spatial/pkg/numeric"
+)
+
+// The predicates below are adaptive: the determinant is first evaluated in floating point and its sign is returned if
+// it exceeds a forward error bound, otherwise the determinant is evaluated exactly with expansion arithmetic. The
+// error bounds are those of Shewchuk, "Adaptive Precision Floating-Point Arithmetic and Fast Robust Geometric
+// Predicates", and the exact results assume that no intermediate product underflows.
+
+// roundoff is half the machine epsilon, the relative error of a single rounded operation.
+const roundoff = 0x1p-53
+
+// Forward error bounds of the floating-point determinants relative to their permanents.
+const (
+	orient2DErrBound  = (3 + 16*roundoff) * roundoff
+	orient3DErrBound  = (7 + 56*roundoff) * roundoff
+	inCircleErrBound  = (10 + 96*roundoff) * roundoff
+	inSphereErrBound  = (16 + 224*roundoff) * roundoff
+	predicateNoResult = 2
+)
+
+// checkCoordinates returns an error if any coordinate is NaN or infinite.
+func checkCoordinates(coords ...float64) error {
+	for _, c := range coords {
+		if math.IsNaN(c) {
+			return numeric.ErrNaN
+		}
+		if math.IsInf(c, 0) {
+			return numeric.ErrInfinity
+		}
+	}
+	return nil
+}
+
+// filterSign returns the sign of det if it is certain given the error bound on det, or predicateNoResult if the
+// determinant must be evaluated exactly.
+func filterSign(det, permanent, bound float64) (int, error) {
+	if numeric.AreAnyOverflow(det, permanent) || math.IsNaN(det) {
+		return 0, numeric.ErrOverflow
+	}
+	if math.Abs(det) > bound*permanent {
+		return numeric.Signum(det)
+	}
+	return predicateNoResult, nil
+}
+
+// differenceExpansion returns the difference a - b exactly as an expansion.
+func differenceExpansion(a, b float64) []float64 {
+	d, e := numeric.TwoDiff(a, b)
+	h := make([]float64, 0, 2)
+	if e != 0 {
+		h = append(h, e)
+	}
+	if d != 0 {
+		h = append(h, d)
+	}
+	return h
+}
+
+// crossExpansion returns a * d - b * c exactly as an expansion.
+func crossExpansion(a, b, c, d []float64) []float64 {
+	return numeric.ExpansionSum(numeric.ExpansionProduct(a, d), numeric.NegateExpansion(numeric.ExpansionProduct(b, c)))
+}
+
+// squaredNormExpansion returns the squared norm of the vector with the given expansion components.
+func squaredNormExpansion(components ...[]float64) []float64 {
+	var h []float64
+	for _, c := range components {
+		h = numeric.ExpansionSum(h, numeric.ExpansionProduct(c, c))
+	}
+	return h
+}
+
+// Orient2D returns the sign of the orientation of the points a, b and c: 1 if they are in counterclockwise order, -1
+// if they are in clockwise order and 0 if they are collinear. The result is exact.
+func Orient2D(a, b, c Point2DReader) (int, error) {
+	ax, ay, bx, by, cx, cy := a.GetX(), a.GetY(), b.GetX(), b.GetY(), c.GetX(), c.GetY()
+	err := checkCoordinates(ax, ay, bx, by, cx, cy)
+	if err != nil {
+		return 0, err
+	}
+
+	left := (ax - cx) * (by - cy)
+	right := (ay - cy) * (bx - cx)
+	sign, err := filterSign(left-right, math.Abs(left)+math.Abs(right), orient2DErrBound)
+	if sign != predicateNoResult || err != nil {
+		return sign, err
+	}
+
+	acx, acy := differenceExpansion(ax, cx), differenceExpansion(ay, cy)
+	bcx, bcy := differenceExpansion(bx, cx), differenceExpansion(by, cy)
+	return numeric.ExpansionSign(crossExpansion(acx, acy, bcx, bcy)), nil
+}
+
+// Orient3D returns the sign of the orientation of the point d relative to the plane through the points a, b and c: 1
+// if d lies below the plane, -1 if it lies above and 0 if the points are coplanar, where "above" is the side from
+// which a, b and c appear in counterclockwise order. The result is exact.
+func Orient3D(a, b, c, d Point3DReader) (int, error) {
+	dx, dy, dz := d.GetX(), d.GetY(), d.GetZ()
+	ax, ay, az := a.GetX(), a.GetY(), a.GetZ()
+	bx, by, bz := b.GetX(), b.GetY(), b.GetZ()
+	cx, cy, cz := c.GetX(), c.GetY(), c.GetZ()
+	err := checkCoordinates(ax, ay, az, bx, by, bz, cx, cy, cz, dx, dy, dz)
+	if err != nil {
+		return 0, err
+	}
+
+	adx, ady, adz := ax-dx, ay-dy, az-dz
+	bdx, bdy, bdz := bx-dx, by-dy, bz-dz
+	cdx, cdy, cdz := cx-dx, cy-dy, cz-dz
+	bdxcdy, cdxbdy := bdx*cdy, cdx*bdy
+	cdxady, adxcdy := cdx*ady, adx*cdy
+	adxbdy, bdxady := adx*bdy, bdx*ady
+	det := adz*(bdxcdy-cdxbdy) + bdz*(cdxady-adxcdy) + cdz*(adxbdy-bdxady)
+	permanent := (math.Abs(bdxcdy)+math.Abs(cdxbdy))*math.Abs(adz) +
+		(math.Abs(cdxady)+math.Abs(adxcdy))*math.Abs(bdz) +
+		(math.Abs(adxbdy)+math.Abs(bdxady))*math.Abs(cdz)
+	sign, err := filterSign(det, permanent, orient3DErrBound)
+	if sign != predicateNoResult || err != nil {
+		return sign, err
+	}
+
+	eax, eay, eaz := differenceExpansion(ax, dx), differenceExpansion(ay, dy), differenceExpansion(az, dz)
+	ebx, eby, ebz := differenceExpansion(bx, dx), differenceExpansion(by, dy), differenceExpansion(bz, dz)
+	ecx, ecy, ecz := differenceExpansion(cx, dx), differenceExpansion(cy, dy), differenceExpansion(cz, dz)
+	exact := numeric.ExpansionProduct(eaz, crossExpansion(ebx, eby, ecx, ecy))
+	exact = numeric.ExpansionSum(exact, numeric.ExpansionProduct(ebz, crossExpansion(ecx, ecy, eax, eay)))
+	exact = numeric.ExpansionSum(exact, numeric.ExpansionProduct(ecz, crossExpansion(eax, eay, ebx, eby)))
+	return numeric.ExpansionSign(exact), nil
+}
+
+// InCircle returns the position of the point d relative to the circle through the points a, b and c, which must be
+// in counterclockwise order: 1 if d lies inside the circle, -1 if it lies outside and 0 if the points are cocircular.
+// The sign is reversed if a, b and c are in clockwise order. The result is exact.
+func InCircle(a, b, c, d Point2DReader) (int, error) {
+	ax, ay, bx, by := a.GetX(), a.GetY(), b.GetX(), b.GetY()
+	cx, cy, dx, dy := c.GetX(), c.GetY(), d.GetX(), d.GetY()
+	err := checkCoordinates(ax, ay, bx, by, cx, cy, dx, dy)
+	if err != nil {
+		return 0, err
+	}
+
+	adx, ady := ax-dx, ay-dy
+	bdx, bdy := bx-dx, by-dy
+	cdx, cdy := cx-dx, cy-dy
+	bdxcdy, cdxbdy := bdx*cdy, cdx*bdy
+	cdxady, adxcdy := cdx*ady, adx*cdy
+	adxbdy, bdxady := adx*bdy, bdx*ady
+	alift := adx*adx + ady*ady
+	blift := bdx*bdx + bdy*bdy
+	clift := cdx*cdx + cdy*cdy
+	det := alift*(bdxcdy-cdxbdy) + blift*(cdxady-adxcdy) + clift*(adxbdy-bdxady)
+	permanent := (math.Abs(bdxcdy)+math.Abs(cdxbdy))*alift +
+		(math.Abs(cdxady)+math.Abs(adxcdy))*blift +
+		(math.Abs(adxbdy)+math.Abs(bdxady))*clift
+	sign, err := filterSign(det, permanent, inCircleErrBound)
+	if sign != predicateNoResult || err != nil {
+		return sign, err
+	}
+
+	eax, eay := differenceExpansion(ax, dx), differenceExpansion(ay, dy)
+	ebx, eby := differenceExpansion(bx, dx), differenceExpansion(by, dy)
+	ecx, ecy := differenceExpansion(cx, dx), differenceExpansion(cy, dy)
+	exact := numeric.ExpansionProduct(squaredNormExpansion(eax, eay), crossExpansion(ebx, eby, ecx, ecy))
+	exact = numeric.ExpansionSum(exact,
+		numeric.ExpansionProduct(squaredNormExpansion(ebx, eby), crossExpansion(ecx, ecy, eax, eay)))
+	exact = numeric.ExpansionSum(exact,
+		numeric.ExpansionProduct(squaredNormExpansion(ecx, ecy), crossExpansion(eax, eay, ebx, eby)))
+	return numeric.ExpansionSign(exact), nil
+}
+
+// InSphere returns the position of the point e relative to the sphere through the points a, b, c and d, which must
+// have a positive orientation as reported by Orient3D: 1 if e lies inside the sphere, -1 if it lies outside and 0 if
+// the points are cospherical. The sign is reversed if the orientation is negative. The result is exact.
+func InSphere(a, b, c, d, e Point3DReader) (int, error) {
+	ex, ey, ez := e.GetX(), e.GetY(), e.GetZ()
+	p := [4]Point3DReader{a, b, c, d}
+	var x, y, z [4]float64
+	for i, q := range p {
+		x[i], y[i], z[i] = q.GetX(), q.GetY(), q.GetZ()
+	}
+	err := checkCoordinates(append(append(append([]float64{ex, ey, ez}, x[:]...), y[:]...), z[:]...)...)
+	if err != nil {
+		return 0, err
+	}
+
+	// relative coordinates, 2x2 minors of the xy-columns and lifts of each point
+	var dx, dy, dz, lift [4]float64
+	for i := range p {
+		dx[i], dy[i], dz[i] = x[i]-ex, y[i]-ey, z[i]-ez
+		lift[i] = dx[i]*dx[i] + dy[i]*dy[i] + dz[i]*dz[i]
+	}
+	minor := func(i, j int) (float64, float64) {
+		l, r := dx[i]*dy[j], dx[j]*dy[i]
+		return l - r, math.Abs(l) + math.Abs(r)
+	}
+	ab, abP := minor(0, 1)
+	bc, bcP := minor(1, 2)
+	cd, cdP := minor(2, 3)
+	da, daP := minor(3, 0)
+	ac, acP := minor(0, 2)
+	bd, bdP := minor(1, 3)
+	za, zb, zc, zd := math.Abs(dz[0]), math.Abs(dz[1]), math.Abs(dz[2]), math.Abs(dz[3])
+
+	abc := dz[0]*bc - dz[1]*ac + dz[2]*ab
+	bcd := dz[1]*cd - dz[2]*bd + dz[3]*bc
+	cda := dz[2]*da + dz[3]*ac + dz[0]*cd
+	dab := dz[3]*ab + dz[0]*bd + dz[1]*da
+	det := (lift[3]*abc - lift[2]*dab) + (lift[1]*cda - lift[0]*bcd)
+	permanent := ((cdP*zb+bdP*zc+bcP*zd)*lift[0] + (daP*zc+acP*zd+cdP*za)*lift[1]) +
+		((abP*zd+bdP*za+daP*zb)*lift[2] + (bcP*za+acP*zb+abP*zc)*lift[3])
+	sign, err := filterSign(det, permanent, inSphereErrBound)
+	if sign != predicateNoResult || err != nil {
+		return sign, err
+	}
+
+	var edx, edy, edz, elift [4][]float64
+	for i := range p {
+		edx[i], edy[i], edz[i] = differenceExpansion(x[i], ex), differenceExpansion(y[i], ey), differenceExpansion(z[i], ez)
+		elift[i] = squaredNormExpansion(edx[i], edy[i], edz[i])
+	}
+	eminor := func(i, j int) []float64 {
+		return crossExpansion(edx[i], edy[i], edx[j], edy[j])
+	}
+	eab, ebc, ecd, eda, eac, ebd := eminor(0, 1), eminor(1, 2), eminor(2, 3), eminor(3, 0), eminor(0, 2), eminor(1, 3)
+	triple := func(z0, m0, z1, m1, z2, m2 []float64) []float64 {
+		h := numeric.ExpansionSum(numeric.ExpansionProduct(z0, m0), numeric.ExpansionProduct(z1, m1))
+		return numeric.ExpansionSum(h, numeric.ExpansionProduct(z2, m2))
+	}
+	neg := numeric.NegateExpansion
+	eabc := triple(edz[0], ebc, neg(edz[1]), eac, edz[2], eab)
+	ebcd := triple(edz[1], ecd, neg(edz[2]), ebd, edz[3], ebc)
+	ecda := triple(edz[2], eda, edz[3], eac, edz[0], ecd)
+	edab := triple(edz[3], eab, edz[0], ebd, edz[1], eda)
+
+	exact := numeric.ExpansionProduct(elift[3], eabc)
+	exact = numeric.ExpansionSum(exact, neg(numeric.ExpansionProduct(elift[2], edab)))
+	exact = numeric.ExpansionSum(exact, numeric.ExpansionProduct(elift[1], ecda))
+	exact = numeric.ExpansionSum(exact, neg(numeric.ExpansionProduct(elift[0], ebcd)))
+	return numeric.ExpansionSign(exact), nil
+}
@@ -0,0 +1,186 @@
+package geometry
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/tab58/v1/spatial/pkg/numeric"
+)
+
+// ratDiff returns a - b exactly.
+func ratDiff(a, b float64) *big.Rat {
+	x := new(big.Rat).SetFloat64(a)
+	return x.Sub(x, new(big.Rat).SetFloat64(b))
+}
+
+// ratDeterminant returns the determinant of a square matrix of rationals by cofactor expansion along the first row.
+func ratDeterminant(m [][]*big.Rat) *big.Rat {
+	n := len(m)
+	if n == 1 {
+		return m[0][0]
+	}
+	det := new(big.Rat)
+	for j := 0; j < n; j++ {
+		minor := make([][]*big.Rat, 0, n-1)
+		for _, row := range m[1:] {
+			r := make([]*big.Rat, 0, n-1)
+			r = append(r, row[:j]...)
+			r = append(r, row[j+1:]...)
+			minor = append(minor, r)
+		}
+		t := new(big.Rat).Mul(m[0][j], ratDeterminant(minor))
+		if j%2 == 1 {
+			t.Neg(t)
+		}
+		det.Add(det, t)
+	}
+	return det
+}
+
+// ratLiftedDeterminant returns the sign of the determinant whose rows are the differences between each point and the
+// last one, optionally followed by the squared length of the difference.
+func ratLiftedDeterminant(points [][]float64, lift bool) int {
+	last := points[len(points)-1]
+	m := make([][]*big.Rat, 0, len(points)-1)
+	for _, p := range points[:len(points)-1] {
+		row := make([]*big.Rat, 0, len(p)+1)
+		norm := new(big.Rat)
+		for k := range p {
+			d := ratDiff(p[k], last[k])
+			row = append(row, d)
+			norm.Add(norm, new(big.Rat).Mul(d, d))
+		}
+		if lift {
+			row = append(row, norm)
+		}
+		m = append(m, row)
+	}
+	return ratDeterminant(m).Sign()
+}
+
+// perturb moves x by up to two units in the last place. Zero is left alone, since the predicates assume that no
+// product underflows.
+func perturb(r *rand.Rand, x float64) float64 {
+	if x == 0 {
+		return x
+	}
+	for i := r.Intn(5) - 2; i != 0; {
+		if i > 0 {
+			x = math.Nextafter(x, math.Inf(1))
+			i--
+		} else {
+			x = math.Nextafter(x, math.Inf(-1))
+			i++
+		}
+	}
+	return x
+}
+
+func TestOrient2DNearCollinear(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 2000; i++ {
+		a := []float64{r.Float64()*10 - 5, r.Float64()*10 - 5}
+		b := []float64{r.Float64()*10 - 5, r.Float64()*10 - 5}
+		s := r.Float64()*4 - 2
+		c := []float64{perturb(r, a[0]+s*(b[0]-a[0])), perturb(r, a[1]+s*(b[1]-a[1]))}
+
+		got, err := Orient2D(&Point2D{X: a[0], Y: a[1]}, &Point2D{X: b[0], Y: b[1]}, &Point2D{X: c[0], Y: c[1]})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := ratLiftedDeterminant([][]float64{a, b, c}, false); got != want {
+			t.Fatalf("Orient2D(%v, %v, %v) = %d, want %d", a, b, c, got, want)
+		}
+	}
+}
+
+func TestOrient3DNearCoplanar(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	for i := 0; i < 2000; i++ {
+		a := []float64{r.Float64(), r.Float64(), r.Float64()}
+		b := []float64{r.Float64(), r.Float64(), r.Float64()}
+		c := []float64{r.Float64(), r.Float64(), r.Float64()}
+		s, u := r.Float64()*2-1, r.Float64()*2-1
+		d := make([]float64, 3)
+		for k := range d {
+			d[k] = perturb(r, a[k]+s*(b[k]-a[k])+u*(c[k]-a[k]))
+		}
+
+		got, err := Orient3D(&Point3D{X: a[0], Y: a[1], Z: a[2]}, &Point3D{X: b[0], Y: b[1], Z: b[2]},
+			&Point3D{X: c[0], Y: c[1], Z: c[2]}, &Point3D{X: d[0], Y: d[1], Z: d[2]})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := ratLiftedDeterminant([][]float64{a, b, c, d}, false); got != want {
+			t.Fatalf("Orient3D(%v, %v, %v, %v) = %d, want %d", a, b, c, d, got, want)
+		}
+	}
+}
+
+func TestInCircleNearCocircular(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	for i := 0; i < 2000; i++ {
+		cx, cy, rad := r.Float64()*2-1, r.Float64()*2-1, 0.5+r.Float64()
+		p := make([][]float64, 4)
+		for k := range p {
+			theta := 2 * math.Pi * r.Float64()
+			p[k] = []float64{perturb(r, cx+rad*math.Cos(theta)), perturb(r, cy+rad*math.Sin(theta))}
+		}
+
+		got, err := InCircle(&Point2D{X: p[0][0], Y: p[0][1]}, &Point2D{X: p[1][0], Y: p[1][1]},
+			&Point2D{X: p[2][0], Y: p[2][1]}, &Point2D{X: p[3][0], Y: p[3][1]})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := ratLiftedDeterminant(p, true); got != want {
+			t.Fatalf("InCircle(%v) = %d, want %d", p, got, want)
+		}
+	}
+}
+
+func TestInSphereNearCospherical(t *testing.T) {
+	r := rand.New(rand.NewSource(4))
+	for i := 0; i < 2000; i++ {
+		// points of the unit sphere at multiples of 45 degrees, where many quintuples are exactly degenerate
+		p := make([][]float64, 5)
+		for k := range p {
+			theta, phi := float64(r.Intn(8))*math.Pi/4, float64(r.Intn(5))*math.Pi/4
+			p[k] = []float64{
+				perturb(r, math.Sin(phi)*math.Cos(theta)),
+				perturb(r, math.Sin(phi)*math.Sin(theta)),
+				perturb(r, math.Cos(phi)),
+			}
+		}
+
+		pts := make([]*Point3D, len(p))
+		for k := range p {
+			pts[k] = &Point3D{X: p[k][0], Y: p[k][1], Z: p[k][2]}
+		}
+		got, err := InSphere(pts[0], pts[1], pts[2], pts[3], pts[4])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := ratLiftedDeterminant(p, true); got != want {
+			t.Fatalf("InSphere(%v) = %d, want %d", p, got, want)
+		}
+	}
+}
+
+func TestPredicatesNonFinite(t *testing.T) {
+	o := &Point2D{}
+	if _, err := Orient2D(&Point2D{X: math.NaN()}, o, &Point2D{X: 1}); !errors.Is(err, numeric.ErrNaN) {
+		t.Errorf("Orient2D with NaN: got %v, want %v", err, numeric.ErrNaN)
+	}
+	_, err := InCircle(&Point2D{X: math.Inf(1)}, o, &Point2D{X: 1}, &Point2D{Y: 1})
+	if !errors.Is(err, numeric.ErrInfinity) {
+		t.Errorf("InCircle with Inf: got %v, want %v", err, numeric.ErrInfinity)
+	}
+	huge := &Point3D{X: 1e300, Y: 1e300, Z: 1e300}
+	_, err = Orient3D(huge, &Point3D{X: -1e300}, &Point3D{Y: -1e300}, &Point3D{Z: -1e300})
+	if !errors.Is(err, numeric.ErrOverflow) {
+		t.Errorf("Orient3D overflowing: got %v, want %v", err, numeric.ErrOverflow)
+	}
+}
@@ -0,0 +1,611 @@
+package geometry
+
+import (
+	"math"
+
+	"github.com/tab58/v1/spatial/pkg/numeric"
+)
+
+// QuaternionReader is a read-only interface for a quaternion.
+type QuaternionReader interface {
+	GetX() float64
+	GetY() float64
+	GetZ() float64
+	GetW() float64
+
+	GetComponents() (float64, float64, float64, float64)
+	Length() (float64, error)
+	LengthSquared() (float64, error)
+	Clone() *Quaternion
+	ToVector4D() *Vector4D
+
+	IsUnitLength(tol numeric.Tolerance) (bool, error)
+	IsEqualTo(r QuaternionReader, tol numeric.Tolerance) (bool, error)
+	Dot(r QuaternionReader) (float64, error)
+
+	ToMatrix3D() (*Matrix3D, error)
+	ToAxisAngle() (*Vector3D, float64, error)
+	RotateVector3D(v Vector3DReader) (*Vector3D, error)
+}
+
+// QuaternionWriter is a write-only interface for a quaternion.
+type QuaternionWriter interface {
+	SetX(float64)
+	SetY(float64)
+	SetZ(float64)
+	SetW(float64)
+
+	SetComponents(x, y, z, w float64)
+	Copy(r QuaternionReader)
+	Identity()
+
+	Conjugate()
+	Negate()
+	Invert() error
+	Normalize() error
+	Premultiply(r QuaternionReader) error
+	Postmultiply(r QuaternionReader) error
+	Exp() error
+	Log() error
+	Slerp(r QuaternionReader, t float64) error
+	Squad(r, a, b QuaternionReader, t float64) error
+
+	SetFromAxisAngle(axis Vector3DReader, angleRad float64) error
+	SetFromMatrix3D(m *Matrix3D) error
+}
+
+// Quaternion is a representation of a quaternion w + xi + yj + zk, where W is the scalar part and (X, Y, Z) is the vector part.
+type Quaternion struct {
+	X float64
+	Y float64
+	Z float64
+	W float64
+}
+
+// GetX returns the i-component of the quaternion.
+func (q *Quaternion) GetX() float64 { return q.X }
+
+// GetY returns the j-component of the quaternion.
+func (q *Quaternion) GetY() float64 { return q.Y }
+
+// GetZ returns the k-component of the quaternion.
+func (q *Quaternion) GetZ() float64 { return q.Z }
+
+// GetW returns the scalar component of the quaternion.
+func (q *Quaternion) GetW() float64 { return q.W }
+
+// GetComponents returns the components of the quaternion.
+func (q *Quaternion) GetComponents() (x, y, z, w float64) {
+	return q.GetX(), q.GetY(), q.GetZ(), q.GetW()
+}
+
+// SetX sets the i-component of the quaternion.
+func (q *Quaternion) SetX(z float64) { q.X = z }
+
+// SetY sets the j-component of the quaternion.
+func (q *Quaternion) SetY(z float64) { q.Y = z }
+
+// SetZ sets the k-component of the quaternion.
+func (q *Quaternion) SetZ(z float64) { q.Z = z }
+
+// SetW sets the scalar component of the quaternion.
+func (q *Quaternion) SetW(z float64) { q.W = z }
+
+// SetComponents sets the components of the quaternion.
+func (q *Quaternion) SetComponents(x, y, z, w float64) {
+	q.SetX(x)
+	q.SetY(y)
+	q.SetZ(z)
+	q.SetW(w)
+}
+
+// Copy copies the components of the given quaternion to this one.
+func (q *Quaternion) Copy(r QuaternionReader) {
+	q.SetComponents(r.GetComponents())
+}
+
+// Identity sets the quaternion to the identity rotation.
+func (q *Quaternion) Identity() {
+	q.SetComponents(0, 0, 0, 1)
+}
+
+// Clone creates a new Quaternion with the same component values.
+func (q *Quaternion) Clone() *Quaternion {
+	return &Quaternion{
+		X: q.GetX(),
+		Y: q.GetY(),
+		Z: q.GetZ(),
+		W: q.GetW(),
+	}
+}
+
+// ToVector4D returns a 4D vector with the same component values.
+func (q *Quaternion) ToVector4D() *Vector4D {
+	return &Vector4D{
+		X: q.GetX(),
+		Y: q.GetY(),
+		Z: q.GetZ(),
+		W: q.GetW(),
+	}
+}
+
+// Length computes the norm of the quaternion.
+func (q *Quaternion) Length() (float64, error) {
+	x, y, z, w := q.GetComponents()
+
+	r := numeric.NrmN(x, y, z, w)
+	if numeric.IsOverflow(r) {
+		return 0, numeric.ErrOverflow
+	}
+	return r, nil
+}
+
+// LengthSquared computes the squared norm of the quaternion.
+func (q *Quaternion) LengthSquared() (float64, error) {
+	x, y, z, w := q.GetComponents()
+
+	r := x*x + y*y + z*z + w*w
+	if numeric.IsOverflow(r) {
+		return 0, numeric.ErrOverflow
+	}
+	return r, nil
+}
+
+// Dot computes the 4D dot product between this quaternion and another.
+func (q *Quaternion) Dot(r QuaternionReader) (float64, error) {
+	ax, ay, az, aw := q.GetComponents()
+	bx, by, bz, bw := r.GetComponents()
+
+	res := ax*bx + ay*by + az*bz + aw*bw
+	if numeric.IsOverflow(res) {
+		return 0, numeric.ErrOverflow
+	}
+	return res, nil
+}
+
+// IsUnitLength returns true if the quaternion is equal to the normalized quaternion within the given tolerance, false if not.
+func (q *Quaternion) IsUnitLength(tol numeric.Tolerance) (bool, error) {
+	if tol.IsInvalid() {
+		return false, numeric.ErrInvalidTol
+	}
+
+	qq := q.Clone()
+	qq.Normalize()
+	return q.IsEqualTo(qq, tol)
+}
+
+// IsEqualTo returns true if the quaternion components are equal within a tolerance of each other, false if not.
+func (q *Quaternion) IsEqualTo(r QuaternionReader, tol numeric.Tolerance) (bool, error) {
+	qx, qy, qz, qw := q.GetComponents()
+	rx, ry, rz, rw := r.GetComponents()
+	return areComponentsEqual(tol, []float64{qx, qy, qz, qw}, []float64{rx, ry, rz, rw})
+}
+
+// Conjugate negates the vector part of the quaternion.
+func (q *Quaternion) Conjugate() {
+	x, y, z, w := q.GetComponents()
+	q.SetComponents(-x, -y, -z, w)
+}
+
+// Negate negates all the quaternion components. The negated quaternion represents the same rotation.
+func (q *Quaternion) Negate() {
+	x, y, z, w := q.GetComponents()
+	q.SetComponents(-x, -y, -z, -w)
+}
+
+// Invert sets the quaternion to its multiplicative inverse.
+func (q *Quaternion) Invert() error {
+	x, y, z, w := q.GetComponents()
+	l, err := q.LengthSquared()
+	if err != nil {
+		return err
+	}
+	if l == 0 {
+		return numeric.ErrDivideByZero
+	}
+
+	newX := -x / l
+	newY := -y / l
+	newZ := -z / l
+	newW := w / l
+	if numeric.AreAnyOverflow(newX, newY, newZ, newW) {
+		return numeric.ErrOverflow
+	}
+
+	q.SetComponents(newX, newY, newZ, newW)
+	return nil
+}
+
+// Normalize scales the quaternion to unit length.
+func (q *Quaternion) Normalize() error {
+	x, y, z, w := q.GetComponents()
+	l, err := q.Length()
+	if err != nil {
+		return err
+	}
+	if l == 0 {
+		return numeric.ErrDivideByZero
+	}
+
+	newX := x / l
+	newY := y / l
+	newZ := z / l
+	newW := w / l
+	if numeric.AreAnyOverflow(newX, newY, newZ, newW) {
+		return numeric.ErrOverflow
+	}
+
+	q.SetComponents(newX, newY, newZ, newW)
+	return nil
+}
+
+// multiplyQuaternions computes the Hamilton product a * b.
+func multiplyQuaternions(a, b QuaternionReader) (x, y, z, w float64, err error) {
+	ax, ay, az, aw := a.GetComponents()
+	bx, by, bz, bw := b.GetComponents()
+
+	x = aw*bx + ax*bw + ay*bz - az*by
+	y = aw*by - ax*bz + ay*bw + az*bx
+	z = aw*bz + ax*by - ay*bx + az*bw
+	w = aw*bw - ax*bx - ay*by - az*bz
+	if numeric.AreAnyOverflow(x, y, z, w) {
+		return 0, 0, 0, 0, numeric.ErrOverflow
+	}
+	return x, y, z, w, nil
+}
+
+// Premultiply left-multiplies the given quaternion with this one (q = r * q).
+func (q *Quaternion) Premultiply(r QuaternionReader) error {
+	x, y, z, w, err := multiplyQuaternions(r, q)
+	if err != nil {
+		return err
+	}
+	q.SetComponents(x, y, z, w)
+	return nil
+}
+
+// Postmultiply right-multiplies the given quaternion with this one (q = q * r).
+func (q *Quaternion) Postmultiply(r QuaternionReader) error {
+	x, y, z, w, err := multiplyQuaternions(q, r)
+	if err != nil {
+		return err
+	}
+	q.SetComponents(x, y, z, w)
+	return nil
+}
+
+// SetFromAxisAngle sets the quaternion to the rotation about the given axis by the given angle.
+func (q *Quaternion) SetFromAxisAngle(axis Vector3DReader, angleRad float64) error {
+	if math.IsNaN(angleRad) || math.IsInf(angleRad, 0) {
+		return numeric.ErrInvalidArgument
+	}
+
+	u := axis.Clone()
+	err := u.Normalize()
+	if err != nil {
+		return err
+	}
+
+	s := math.Sin(angleRad / 2)
+	c := math.Cos(angleRad / 2)
+	q.SetComponents(u.X*s, u.Y*s, u.Z*s, c)
+	return nil
+}
+
+// SetFromMatrix3D sets the quaternion to the rotation encoded by the given rotation matrix.
+func (q *Quaternion) SetFromMatrix3D(m *Matrix3D) error {
+	// Shepperd's method: pivot on the largest of the trace and diagonal elements
+	// so the square root is always taken of a number bounded away from zero.
+	a := m.elements
+	m00, m01, m02 := a[0], a[1], a[2]
+	m10, m11, m12 := a[3], a[4], a[5]
+	m20, m21, m22 := a[6], a[7], a[8]
+
+	var x, y, z, w float64
+	trace := m00 + m11 + m22
+	switch {
+	case trace >= m00 && trace >= m11 && trace >= m22:
+		s := 2 * math.Sqrt(1+trace)
+		w = s / 4
+		x = (m21 - m12) / s
+		y = (m02 - m20) / s
+		z = (m10 - m01) / s
+	case m00 >= m11 && m00 >= m22:
+		s := 2 * math.Sqrt(1+m00-m11-m22)
+		w = (m21 - m12) / s
+		x = s / 4
+		y = (m01 + m10) / s
+		z = (m02 + m20) / s
+	case m11 >= m22:
+		s := 2 * math.Sqrt(1+m11-m00-m22)
+		w = (m02 - m20) / s
+		x = (m01 + m10) / s
+		y = s / 4
+		z = (m12 + m21) / s
+	default:
+		s := 2 * math.Sqrt(1+m22-m00-m11)
+		w = (m10 - m01) / s
+		x = (m02 + m20) / s
+		y = (m12 + m21) / s
+		z = s / 4
+	}
+	if math.IsNaN(x) || math.IsNaN(y) || math.IsNaN(z) || math.IsNaN(w) {
+		return numeric.ErrNaN
+	}
+	if numeric.AreAnyOverflow(x, y, z, w) {
+		return numeric.ErrOverflow
+	}
+
+	q.SetComponents(x, y, z, w)
+	return q.Normalize()
+}
+
+// ToMatrix3D returns the rotation matrix encoded by the normalized quaternion.
+func (q *Quaternion) ToMatrix3D() (*Matrix3D, error) {
+	u := q.Clone()
+	err := u.Normalize()
+	if err != nil {
+		return nil, err
+	}
+	x, y, z, w := u.GetComponents()
+
+	xx, yy, zz := x*x, y*y, z*z
+	xy, xz, yz := x*y, x*z, y*z
+	wx, wy, wz := w*x, w*y, w*z
+
+	m := &Matrix3D{}
+	err = m.SetElements(
+		1-2*(yy+zz), 2*(xy-wz), 2*(xz+wy),
+		2*(xy+wz), 1-2*(xx+zz), 2*(yz-wx),
+		2*(xz-wy), 2*(yz+wx), 1-2*(xx+yy),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ToAxisAngle returns the unit rotation axis and the rotation angle, in [0, 2*pi], encoded by the normalized quaternion.
+// The x-axis is returned as the axis of the identity rotation.
+func (q *Quaternion) ToAxisAngle() (*Vector3D, float64, error) {
+	u := q.Clone()
+	err := u.Normalize()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	axis := &Vector3D{X: u.X, Y: u.Y, Z: u.Z}
+	s, err := axis.Length()
+	if err != nil {
+		return nil, 0, err
+	}
+	if s == 0 {
+		return &Vector3D{X: 1, Y: 0, Z: 0}, 0, nil
+	}
+
+	// atan2 stays accurate for angles near 0 and pi where acos(w) does not
+	angle := 2 * math.Atan2(s, u.W)
+	err = axis.Scale(1 / s)
+	if err != nil {
+		return nil, 0, err
+	}
+	return axis, angle, nil
+}
+
+// RotateVector3D returns the given vector rotated by the normalized quaternion.
+func (q *Quaternion) RotateVector3D(v Vector3DReader) (*Vector3D, error) {
+	u := q.Clone()
+	err := u.Normalize()
+	if err != nil {
+		return nil, err
+	}
+	qv := &Vector3D{X: u.X, Y: u.Y, Z: u.Z}
+
+	// v' = v + w*t + qv x t, where t = 2 * (qv x v)
+	t, err := qv.Cross(v)
+	if err != nil {
+		return nil, err
+	}
+	err = t.Scale(2)
+	if err != nil {
+		return nil, err
+	}
+	c, err := qv.Cross(t)
+	if err != nil {
+		return nil, err
+	}
+	err = t.Scale(u.W)
+	if err != nil {
+		return nil, err
+	}
+
+	res := v.Clone()
+	err = res.Add(t)
+	if err != nil {
+		return nil, err
+	}
+	err = res.Add(c)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// Exp sets the quaternion to its exponential.
+func (q *Quaternion) Exp() error {
+	x, y, z, w := q.GetComponents()
+	theta := numeric.Hypot3(x, y, z)
+	if numeric.IsOverflow(theta) {
+		return numeric.ErrOverflow
+	}
+
+	// exp(v, w) = e^w * (v/|v| * sin|v|, cos|v|)
+	e := math.Exp(w)
+	s := e * sinc(theta)
+	newX := x * s
+	newY := y * s
+	newZ := z * s
+	newW := e * math.Cos(theta)
+	if numeric.AreAnyOverflow(newX, newY, newZ, newW) {
+		return numeric.ErrOverflow
+	}
+
+	q.SetComponents(newX, newY, newZ, newW)
+	return nil
+}
+
+// Log sets the quaternion to its principal logarithm. The logarithm of a negative real quaternion is taken about the x-axis.
+func (q *Quaternion) Log() error {
+	x, y, z, w := q.GetComponents()
+	n, err := q.Length()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return numeric.ErrDivideByZero
+	}
+
+	vn := numeric.Hypot3(x, y, z)
+	theta := math.Atan2(vn, w)
+
+	var newX, newY, newZ float64
+	if vn == 0 {
+		if w < 0 {
+			newX = math.Pi
+		}
+	} else {
+		s := theta / vn
+		newX = x * s
+		newY = y * s
+		newZ = z * s
+	}
+	newW := math.Log(n)
+
+	q.SetComponents(newX, newY, newZ, newW)
+	return nil
+}
+
+// slerpQuaternions spherically interpolates between a and b, optionally flipping b so the shortest arc is taken.
+func slerpQuaternions(a, b QuaternionReader, t float64, shortest bool) (*Quaternion, error) {
+	if math.IsNaN(t) || math.IsInf(t, 0) {
+		return nil, numeric.ErrInvalidArgument
+	}
+
+	r := b.Clone()
+	if shortest {
+		d, err := a.Dot(r)
+		if err != nil {
+			return nil, err
+		}
+		if d < 0 {
+			r.Negate()
+		}
+	}
+
+	theta, err := a.ToVector4D().AngleTo(r.ToVector4D())
+	if err != nil {
+		return nil, err
+	}
+	wa, wb, err := slerpWeights(theta, t)
+	if err != nil {
+		return nil, err
+	}
+
+	ax, ay, az, aw := a.GetComponents()
+	rx, ry, rz, rw := r.GetComponents()
+
+	newX := wa*ax + wb*rx
+	newY := wa*ay + wb*ry
+	newZ := wa*az + wb*rz
+	newW := wa*aw + wb*rw
+	if numeric.AreAnyOverflow(newX, newY, newZ, newW) {
+		return nil, numeric.ErrOverflow
+	}
+
+	res := &Quaternion{X: newX, Y: newY, Z: newZ, W: newW}
+	err = res.Normalize()
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// Slerp spherically interpolates this unit quaternion towards the given unit quaternion along the shortest arc,
+// where t = 0 leaves this quaternion unchanged and t = 1 gives the same rotation as r.
+func (q *Quaternion) Slerp(r QuaternionReader, t float64) error {
+	res, err := slerpQuaternions(q, r, t, true)
+	if err != nil {
+		return err
+	}
+	q.Copy(res)
+	return nil
+}
+
+// Squad performs spherical quadrangle interpolation from this unit quaternion towards r, where a and b are the
+// control points of this quaternion and r as given by QuaternionSquadControlPoint.
+func (q *Quaternion) Squad(r, a, b QuaternionReader, t float64) error {
+	s1, err := slerpQuaternions(q, r, t, false)
+	if err != nil {
+		return err
+	}
+	s2, err := slerpQuaternions(a, b, t, false)
+	if err != nil {
+		return err
+	}
+	res, err := slerpQuaternions(s1, s2, 2*t*(1-t), false)
+	if err != nil {
+		return err
+	}
+	q.Copy(res)
+	return nil
+}
+
+// QuaternionSquadControlPoint computes the squad control point for the keyframe cur given its neighboring keyframes.
+// At the ends of a keyframe sequence, the keyframe itself can be given as the missing neighbor.
+func QuaternionSquadControlPoint(prev, cur, next QuaternionReader) (*Quaternion, error) {
+	// s = cur * exp(-(log(cur^-1 * next) + log(cur^-1 * prev)) / 4)
+	inv := cur.Clone()
+	err := inv.Invert()
+	if err != nil {
+		return nil, err
+	}
+
+	logs := [2]*Quaternion{}
+	for i, n := range []QuaternionReader{next, prev} {
+		l := n.Clone()
+		d, err := cur.Dot(l)
+		if err != nil {
+			return nil, err
+		}
+		if d < 0 {
+			l.Negate()
+		}
+		err = l.Premultiply(inv)
+		if err != nil {
+			return nil, err
+		}
+		err = l.Log()
+		if err != nil {
+			return nil, err
+		}
+		logs[i] = l
+	}
+
+	e := &Quaternion{
+		X: -(logs[0].X + logs[1].X) / 4,
+		Y: -(logs[0].Y + logs[1].Y) / 4,
+		Z: -(logs[0].Z + logs[1].Z) / 4,
+		W: -(logs[0].W + logs[1].W) / 4,
+	}
+	err = e.Exp()
+	if err != nil {
+		return nil, err
+	}
+
+	s := cur.Clone()
+	err = s.Postmultiply(e)
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
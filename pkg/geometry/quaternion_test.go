@@ -0,0 +1,230 @@
+package geometry
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/tab58/v1/spatial/pkg/numeric"
+)
+
+func TestQuaternionMatrixRoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		q := randomUnitQuaternion(r)
+		m, err := q.ToMatrix3D()
+		if err != nil {
+			t.Fatal(err)
+		}
+		isRotation, err := m.IsRotation(numeric.AbsoluteTolerance(1e-12))
+		if err != nil || !isRotation {
+			t.Fatalf("ToMatrix3D(%v) = %v is not a rotation: %v", q, m.Elements(), err)
+		}
+
+		p := &Quaternion{}
+		err = p.SetFromMatrix3D(m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkClose(t, "SetFromMatrix3D(ToMatrix3D(q))", quaternionSlice(p), quaternionSlice(q), 1e-14)
+	}
+}
+
+func TestQuaternionAxisAngleRoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	for i := 0; i < 1000; i++ {
+		axis := randomVector3D(r)
+		if err := axis.Normalize(); err != nil {
+			t.Fatal(err)
+		}
+		angle := 2 * math.Pi * r.Float64()
+
+		q := &Quaternion{}
+		if err := q.SetFromAxisAngle(axis, angle); err != nil {
+			t.Fatal(err)
+		}
+		gotAxis, gotAngle, err := q.ToAxisAngle()
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkClose(t, "axis", vectorSlice(gotAxis), vectorSlice(axis), 1e-12)
+		checkClose(t, "angle", []float64{gotAngle}, []float64{angle}, 1e-12)
+	}
+
+	q := &Quaternion{}
+	q.Identity()
+	axis, angle, err := q.ToAxisAngle()
+	if err != nil || angle != 0 || axis.X != 1 {
+		t.Errorf("ToAxisAngle(identity) = %v, %v, %v, want x-axis and 0", axis, angle, err)
+	}
+	if err := q.SetFromAxisAngle(&Vector3D{}, 1); err == nil {
+		t.Error("SetFromAxisAngle with a zero axis: got nil error")
+	}
+}
+
+func TestQuaternionRotationComposition(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	for i := 0; i < 1000; i++ {
+		p, q, v := randomUnitQuaternion(r), randomUnitQuaternion(r), randomVector3D(r)
+
+		// rotating by p * q rotates by q first, then by p
+		pq := q.Clone()
+		if err := pq.Premultiply(p); err != nil {
+			t.Fatal(err)
+		}
+		got, err := pq.RotateVector3D(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		qv, err := q.RotateVector3D(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want, err := p.RotateVector3D(qv)
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkClose(t, "(p * q) v", vectorSlice(got), vectorSlice(want), 1e-13)
+
+		// the quaternion and its matrix rotate vectors identically
+		m, err := p.ToMatrix3D()
+		if err != nil {
+			t.Fatal(err)
+		}
+		mv := v.Clone()
+		if err := mv.MatrixTransform3D(m); err != nil {
+			t.Fatal(err)
+		}
+		pv, err := p.RotateVector3D(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkClose(t, "matrix rotation", vectorSlice(mv), vectorSlice(pv), 1e-13)
+
+		if err := pq.Invert(); err != nil {
+			t.Fatal(err)
+		}
+		// (p * q)^-1 = q^-1 * p^-1, so (p * q)^-1 * p * q is the identity
+		if err := pq.Postmultiply(p); err != nil {
+			t.Fatal(err)
+		}
+		if err := pq.Postmultiply(q); err != nil {
+			t.Fatal(err)
+		}
+		checkClose(t, "(p * q)^-1 * p * q", quaternionSlice(pq), []float64{0, 0, 0, 1}, 1e-14)
+	}
+}
+
+func TestQuaternionExpLog(t *testing.T) {
+	r := rand.New(rand.NewSource(4))
+	for i := 0; i < 1000; i++ {
+		q := randomUnitQuaternion(r)
+		f := 0.5 + r.Float64()
+		q.SetComponents(f*q.X, f*q.Y, f*q.Z, f*q.W)
+		if q.W < 0 {
+			q.Negate()
+		}
+		p := q.Clone()
+		if err := p.Log(); err != nil {
+			t.Fatal(err)
+		}
+		if err := p.Exp(); err != nil {
+			t.Fatal(err)
+		}
+		checkClose(t, "exp(log(q))", quaternionSlice(p), quaternionSlice(q), 1e-14)
+	}
+
+	q := &Quaternion{W: -1}
+	if err := q.Log(); err != nil || q.X != math.Pi || q.W != 0 {
+		t.Errorf("log(-1) = %v, %v, want (pi, 0, 0, 0)", q, err)
+	}
+	if err := (&Quaternion{}).Invert(); err != numeric.ErrDivideByZero {
+		t.Errorf("Invert(0): got %v, want %v", err, numeric.ErrDivideByZero)
+	}
+}
+
+func TestQuaternionSlerp(t *testing.T) {
+	r := rand.New(rand.NewSource(5))
+	for i := 0; i < 1000; i++ {
+		p, q := randomUnitQuaternion(r), randomUnitQuaternion(r)
+		for _, s := range []float64{0, 1} {
+			a := p.Clone()
+			if err := a.Slerp(q, s); err != nil {
+				t.Fatal(err)
+			}
+			want := p
+			if s == 1 {
+				want = q
+			}
+			checkClose(t, "Slerp endpoint", quaternionSlice(a), quaternionSlice(want), 1e-14)
+		}
+
+		// halfway along the shortest arc, the rotation from p to the midpoint is half the rotation from p to q
+		mid := p.Clone()
+		if err := mid.Slerp(q, 0.5); err != nil {
+			t.Fatal(err)
+		}
+		_, full, err := relativeRotation(p, q).ToAxisAngle()
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, half, err := relativeRotation(p, mid).ToAxisAngle()
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkClose(t, "Slerp midpoint angle", []float64{half}, []float64{full / 2}, 1e-12)
+		if full > math.Pi+1e-12 {
+			t.Fatalf("Slerp took the long arc of %v", full)
+		}
+	}
+}
+
+// relativeRotation returns the rotation p^-1 * q, flipped onto the shortest arc.
+func relativeRotation(p, q *Quaternion) *Quaternion {
+	d := q.Clone()
+	inv := p.Clone()
+	if err := inv.Invert(); err != nil {
+		panic(err)
+	}
+	if err := d.Premultiply(inv); err != nil {
+		panic(err)
+	}
+	if d.W < 0 {
+		d.Negate()
+	}
+	return d
+}
+
+func TestQuaternionSquad(t *testing.T) {
+	r := rand.New(rand.NewSource(6))
+	keys := make([]*Quaternion, 4)
+	for i := range keys {
+		keys[i] = randomUnitQuaternion(r)
+	}
+	a, err := QuaternionSquadControlPoint(keys[0], keys[1], keys[2])
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := QuaternionSquadControlPoint(keys[1], keys[2], keys[3])
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []float64{0, 1} {
+		q := keys[1].Clone()
+		if err := q.Squad(keys[2], a, b, s); err != nil {
+			t.Fatal(err)
+		}
+		want := keys[1]
+		if s == 1 {
+			want = keys[2]
+		}
+		checkClose(t, "Squad endpoint", quaternionSlice(q), quaternionSlice(want), 1e-14)
+	}
+
+	// the control point of a keyframe between two identical neighbors is the keyframe itself
+	c, err := QuaternionSquadControlPoint(keys[0], keys[0], keys[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkClose(t, "control point", quaternionSlice(c), quaternionSlice(keys[0]), 1e-15)
+}
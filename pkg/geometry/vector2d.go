@@ -22,16 +22,16 @@ type Vector2DReader interface {
 	GetPerpendicularVector() *Vector2D
 	GetNormalizedVector() *Vector2D
 
-	IsZeroLength(tol float64) (bool, error)
-	IsUnitLength(tol float64) (bool, error)
+	IsZeroLength(tol numeric.Tolerance) (bool, error)
+	IsUnitLength(tol numeric.Tolerance) (bool, error)
 
 	AngleTo(w Vector2DReader) (float64, error)
 	Dot(w Vector2DReader) (float64, error)
 
-	IsPerpendicularTo(w Vector2DReader, tol float64) (bool, error)
-	IsCodirectionalTo(w Vector2DReader, tol float64) (bool, error)
-	IsParallelTo(w Vector2DReader, tol float64) (bool, error)
-	IsEqualTo(w Vector2DReader, tol float64) (bool, error)
+	IsPerpendicularTo(w Vector2DReader, tol numeric.Tolerance) (bool, error)
+	IsCodirectionalTo(w Vector2DReader, tol numeric.Tolerance) (bool, error)
+	IsParallelTo(w Vector2DReader, tol numeric.Tolerance) (bool, error)
+	IsEqualTo(w Vector2DReader, tol numeric.Tolerance) (bool, error)
 
 	MatrixTransform2D(m *Matrix2D) error
 	HomogeneousMatrixTransform3D(m *Matrix3D) error
@@ -116,6 +116,19 @@ func (v *Vector2D) Dot(w Vector2DReader) (float64, error) {
 	return res, nil
 }
 
+// DotExtended computes the dot product between this vector and another Vector2DReader in double-double precision,
+// rounding the result to a float64.
+func (v *Vector2D) DotExtended(w Vector2DReader) (float64, error) {
+	ax, ay := v.GetComponents()
+	bx, by := w.GetComponents()
+
+	r, err := numeric.DotExtended([]float64{ax, ay}, []float64{bx, by})
+	if err != nil {
+		return 0, err
+	}
+	return r.Float64(), nil
+}
+
 // Length computes the length of the vector.
 func (v *Vector2D) Length() (float64, error) {
 	x, y := v.GetComponents()
@@ -180,18 +193,21 @@ func (v *Vector2D) AngleTo(w Vector2DReader) (float64, error) {
 	// https://people.eecs.berkeley.edu/~wkahan/Triangle.pdf
 	lv, err := v.Length()
 	if err != nil {
-		return 0, nil
+		return 0, err
 	}
 	lw, err := w.Length()
 	if err != nil {
-		return 0, nil
+		return 0, err
 	}
 
 	u := v.Clone()
-	u.Sub(w)
+	err = u.Sub(w)
+	if err != nil {
+		return 0, err
+	}
 	c, err := u.Length()
 	if err != nil {
-		return 0, nil
+		return 0, err
 	}
 
 	a := math.Max(lv, lw)
@@ -204,7 +220,7 @@ func (v *Vector2D) AngleTo(w Vector2DReader) (float64, error) {
 		mu = c - (a - b)
 	}
 
-	t1 := (a - b) - c
+	t1 := (a - b) + c
 	t2 := a + (b + c)
 	t3 := (a - c) + b
 
@@ -254,25 +270,36 @@ func (v *Vector2D) Scale(f float64) error {
 	return nil
 }
 
-// IsEqualTo returns true if the vector components are equal within a tolerance of each other, false if not.
-func (v *Vector2D) IsEqualTo(w Vector2DReader, tol float64) (bool, error) {
-	if numeric.IsInvalidTolerance(tol) {
-		return false, numeric.ErrInvalidTol
+// RotateBy rotates the vector counterclockwise by the given angle.
+func (v *Vector2D) RotateBy(angleRad float64) error {
+	if math.IsNaN(angleRad) || math.IsInf(angleRad, 0) {
+		return numeric.ErrInvalidArgument
 	}
 
-	vx, vy := v.GetComponents()
-	wx, wy := w.GetComponents()
+	x, y := v.GetComponents()
+	c := math.Cos(angleRad)
+	s := math.Sin(angleRad)
 
-	x := math.Abs(wx - vx)
-	y := math.Abs(wy - vy)
+	newX := x*c - y*s
+	newY := x*s + y*c
+	if numeric.AreAnyOverflow(newX, newY) {
+		return numeric.ErrOverflow
+	}
 
-	isEqual := x <= tol && y <= tol
-	return isEqual, nil
+	v.SetComponents(newX, newY)
+	return nil
+}
+
+// IsEqualTo returns true if the vector components are equal within a tolerance of each other, false if not.
+func (v *Vector2D) IsEqualTo(w Vector2DReader, tol numeric.Tolerance) (bool, error) {
+	vx, vy := v.GetComponents()
+	wx, wy := w.GetComponents()
+	return areComponentsEqual(tol, []float64{vx, vy}, []float64{wx, wy})
 }
 
 // IsParallelTo returns true if the vector is in the direction (either same or opposite) of the given vector within the given tolerance, false if not.
-func (v *Vector2D) IsParallelTo(w Vector2DReader, tol float64) (bool, error) {
-	if numeric.IsInvalidTolerance(tol) {
+func (v *Vector2D) IsParallelTo(w Vector2DReader, tol numeric.Tolerance) (bool, error) {
+	if tol.IsInvalid() {
 		return false, numeric.ErrInvalidTol
 	}
 
@@ -302,8 +329,8 @@ func (v *Vector2D) IsParallelTo(w Vector2DReader, tol float64) (bool, error) {
 }
 
 // IsCodirectionalTo returns true if the vector is pointed in the same direction as the given vector within the given tolerance, false if not.
-func (v *Vector2D) IsCodirectionalTo(w Vector2DReader, tol float64) (bool, error) {
-	if numeric.IsInvalidTolerance(tol) {
+func (v *Vector2D) IsCodirectionalTo(w Vector2DReader, tol numeric.Tolerance) (bool, error) {
+	if tol.IsInvalid() {
 		return false, numeric.ErrInvalidTol
 	}
 
@@ -316,8 +343,8 @@ func (v *Vector2D) IsCodirectionalTo(w Vector2DReader, tol float64) (bool, error
 }
 
 // IsPerpendicularTo returns true if the vector is pointed in the same direction as the given vector within the given tolerance, false if not.
-func (v *Vector2D) IsPerpendicularTo(w Vector2DReader, tol float64) (bool, error) {
-	if numeric.IsInvalidTolerance(tol) {
+func (v *Vector2D) IsPerpendicularTo(w Vector2DReader, tol numeric.Tolerance) (bool, error) {
+	if tol.IsInvalid() {
 		return false, numeric.ErrInvalidTol
 	}
 
@@ -331,12 +358,12 @@ func (v *Vector2D) IsPerpendicularTo(w Vector2DReader, tol float64) (bool, error
 		return false, err
 	}
 
-	return math.Abs(d) <= tol, nil
+	return tol.IsEqualAtScale(d, 0, 1), nil
 }
 
 // IsUnitLength returns true if the vector is equal to the normalized vector within the given tolerance, false if not.
-func (v *Vector2D) IsUnitLength(tol float64) (bool, error) {
-	if numeric.IsInvalidTolerance(tol) {
+func (v *Vector2D) IsUnitLength(tol numeric.Tolerance) (bool, error) {
+	if tol.IsInvalid() {
 		return false, numeric.ErrInvalidTol
 	}
 
@@ -346,8 +373,8 @@ func (v *Vector2D) IsUnitLength(tol float64) (bool, error) {
 }
 
 // IsZeroLength returns true if the vector is of zero length (within a tolerance), false if not.
-func (v *Vector2D) IsZeroLength(tol float64) (bool, error) {
-	if numeric.IsInvalidTolerance(tol) {
+func (v *Vector2D) IsZeroLength(tol numeric.Tolerance) (bool, error) {
+	if tol.IsInvalid() {
 		return false, numeric.ErrInvalidTol
 	}
 	return v.IsEqualTo(Zero2D, tol)
@@ -377,7 +404,7 @@ func (v *Vector2D) ToBlasVector() blas64.Vector {
 
 // MatrixTransform2D transforms this vector by left-multiplying the given matrix.
 func (v *Vector2D) MatrixTransform2D(m *Matrix2D) error {
-	isSingular, err := m.IsNearSingular(1e-12)
+	isSingular, err := m.IsNearSingular(numeric.AbsoluteTolerance(1e-12))
 	if err != nil {
 		return err
 	}
@@ -426,3 +453,50 @@ func (v *Vector2D) HomogeneousMatrixTransform3D(m *Matrix3D) error {
 	v.SetComponents(newX, newY)
 	return nil
 }
+
+// Lerp linearly interpolates this vector towards the given vector, where t = 0 leaves this vector unchanged and t = 1 gives w.
+func (v *Vector2D) Lerp(w Vector2DReader, t float64) error {
+	if math.IsNaN(t) || math.IsInf(t, 0) {
+		return numeric.ErrInvalidArgument
+	}
+
+	vx, vy := v.GetComponents()
+	wx, wy := w.GetComponents()
+
+	newX := vx + t*(wx-vx)
+	newY := vy + t*(wy-vy)
+	if numeric.AreAnyOverflow(newX, newY) {
+		return numeric.ErrOverflow
+	}
+
+	v.SetComponents(newX, newY)
+	return nil
+}
+
+// Slerp spherically interpolates this unit vector towards the given unit vector along the unit circle.
+func (v *Vector2D) Slerp(w Vector2DReader, t float64) error {
+	if math.IsNaN(t) || math.IsInf(t, 0) {
+		return numeric.ErrInvalidArgument
+	}
+
+	theta, err := v.AngleTo(w)
+	if err != nil {
+		return err
+	}
+	a, b, err := slerpWeights(theta, t)
+	if err != nil {
+		return err
+	}
+
+	vx, vy := v.GetComponents()
+	wx, wy := w.GetComponents()
+
+	newX := a*vx + b*wx
+	newY := a*vy + b*wy
+	if numeric.AreAnyOverflow(newX, newY) {
+		return numeric.ErrOverflow
+	}
+
+	v.SetComponents(newX, newY)
+	return nil
+}
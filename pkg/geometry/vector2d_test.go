@@ -0,0 +1,91 @@
+package geometry
+
+import (
+	"math"
+	"testing"
+
+	"github.com/tab58/v1/spatial/pkg/numeric"
+)
+
+// the writer interfaces declare the in-place rotations of both vector types
+var (
+	_ Vector2DWriter = &Vector2D{}
+	_ Vector3DWriter = &Vector3D{}
+)
+
+func TestVector2DRotateBy(t *testing.T) {
+	v := &Vector2D{X: 2, Y: 0}
+	if err := v.RotateBy(math.Pi / 2); err != nil {
+		t.Fatal(err)
+	}
+	checkClose(t, "RotateBy(pi/2)", []float64{v.X, v.Y}, []float64{0, 2}, 1e-15)
+
+	// a full turn in twelve steps returns to the start
+	v = &Vector2D{X: 3, Y: -4}
+	for i := 0; i < 12; i++ {
+		if err := v.RotateBy(math.Pi / 6); err != nil {
+			t.Fatal(err)
+		}
+	}
+	checkClose(t, "twelve turns of pi/6", []float64{v.X, v.Y}, []float64{3, -4}, 1e-14)
+
+	for _, angle := range []float64{math.NaN(), math.Inf(1)} {
+		if err := v.RotateBy(angle); err != numeric.ErrInvalidArgument {
+			t.Errorf("RotateBy(%v): got %v, want %v", angle, err, numeric.ErrInvalidArgument)
+		}
+	}
+}
+
+func TestVector2DAngleTo(t *testing.T) {
+	for _, angle := range []float64{0, 0.5, math.Pi / 2, 3, math.Pi - 1e-9} {
+		v, w := &Vector2D{X: 2}, &Vector2D{X: 5 * math.Cos(angle), Y: 5 * math.Sin(angle)}
+		got, err := v.AngleTo(w)
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkClose(t, "AngleTo", []float64{got}, []float64{angle}, 1e-9*angle+1e-15)
+	}
+
+	v, w := &Vector2D{X: 1e308}, &Vector2D{X: -1e308}
+	if _, err := v.AngleTo(w); err != numeric.ErrOverflow {
+		t.Errorf("AngleTo with an overflowing difference: got %v, want %v", err, numeric.ErrOverflow)
+	}
+}
+
+func TestVector2DSlerp(t *testing.T) {
+	a, b := &Vector2D{X: 1}, &Vector2D{X: math.Cos(2), Y: math.Sin(2)}
+	for _, tt := range []struct{ t, angle float64 }{{0, 0}, {0.25, 0.5}, {0.5, 1}, {1, 2}} {
+		v := a.Clone()
+		if err := v.Slerp(b, tt.t); err != nil {
+			t.Fatal(err)
+		}
+		checkClose(t, "Slerp", []float64{v.X, v.Y}, []float64{math.Cos(tt.angle), math.Sin(tt.angle)}, 1e-15)
+	}
+
+	v := a.Clone()
+	if err := v.Slerp(&Vector2D{X: -1}, 0.5); err != numeric.ErrInvalidArgument {
+		t.Errorf("Slerp between antipodal vectors: got %v, want %v", err, numeric.ErrInvalidArgument)
+	}
+	if err := v.Slerp(b, math.NaN()); err != numeric.ErrInvalidArgument {
+		t.Errorf("Slerp(NaN): got %v, want %v", err, numeric.ErrInvalidArgument)
+	}
+}
+
+func TestVector2DIsPerpendicularTo(t *testing.T) {
+	// a relative tolerance measures the cosine of the angle at the scale of the unit vectors rather than of zero
+	rel := numeric.RelativeTolerance(1e-9)
+	v := &Vector2D{X: 1e6, Y: 0}
+	for _, tt := range []struct {
+		w    *Vector2D
+		want bool
+	}{
+		{&Vector2D{X: 1e-12, Y: 3}, true},
+		{&Vector2D{X: 0, Y: -1e-6}, true},
+		{&Vector2D{X: 1e-3, Y: 1}, false},
+	} {
+		got, err := v.IsPerpendicularTo(tt.w, rel)
+		if err != nil || got != tt.want {
+			t.Errorf("IsPerpendicularTo(%v, %v) = %v, %v, want %v", v, tt.w, got, err, tt.want)
+		}
+	}
+}
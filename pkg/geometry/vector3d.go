@@ -21,17 +21,17 @@ type Vector3DReader interface {
 	ToBlasVector() blas64.Vector
 	GetNormalizedVector() *Vector3D
 
-	IsZeroLength(tol float64) (bool, error)
-	IsUnitLength(tol float64) (bool, error)
+	IsZeroLength(tol numeric.Tolerance) (bool, error)
+	IsUnitLength(tol numeric.Tolerance) (bool, error)
 
 	AngleTo(w Vector3DReader) (float64, error)
 	Dot(w Vector3DReader) (float64, error)
 	Cross(w Vector3DReader) (*Vector3D, error)
 
-	IsPerpendicularTo(w Vector3DReader, tol float64) (bool, error)
-	IsCodirectionalTo(w Vector3DReader, tol float64) (bool, error)
-	IsParallelTo(w Vector3DReader, tol float64) (bool, error)
-	IsEqualTo(w Vector3DReader, tol float64) (bool, error)
+	IsPerpendicularTo(w Vector3DReader, tol numeric.Tolerance) (bool, error)
+	IsCodirectionalTo(w Vector3DReader, tol numeric.Tolerance) (bool, error)
+	IsParallelTo(w Vector3DReader, tol numeric.Tolerance) (bool, error)
+	IsEqualTo(w Vector3DReader, tol numeric.Tolerance) (bool, error)
 
 	MatrixTransform3D(m *Matrix3D) error
 	HomogeneousMatrixTransform4D(m *Matrix4D) error
@@ -125,7 +125,7 @@ func (v *Vector3D) ToBlasVector() blas64.Vector {
 func (v *Vector3D) Length() (float64, error) {
 	x, y, z := v.GetComponents()
 
-	r := numeric.Nrm2(numeric.Nrm2(x, y), z)
+	r := numeric.Hypot3(x, y, z)
 	if numeric.AreAnyOverflow(r) {
 		return 0, numeric.ErrOverflow
 	}
@@ -160,16 +160,16 @@ func (v *Vector3D) GetNormalizedVector() *Vector3D {
 }
 
 // IsZeroLength returns true if the vector is of zero length (within a tolerance), false if not.
-func (v *Vector3D) IsZeroLength(tol float64) (bool, error) {
-	if numeric.IsInvalidTolerance(tol) {
+func (v *Vector3D) IsZeroLength(tol numeric.Tolerance) (bool, error) {
+	if tol.IsInvalid() {
 		return false, numeric.ErrInvalidTol
 	}
 	return v.IsEqualTo(Zero3D, tol)
 }
 
 // IsUnitLength returns true if the vector is equal to the normalized vector within the given tolerance, false if not.
-func (v *Vector3D) IsUnitLength(tol float64) (bool, error) {
-	if numeric.IsInvalidTolerance(tol) {
+func (v *Vector3D) IsUnitLength(tol numeric.Tolerance) (bool, error) {
+	if tol.IsInvalid() {
 		return false, numeric.ErrInvalidTol
 	}
 
@@ -205,11 +205,17 @@ func (v *Vector3D) AngleTo(u Vector3DReader) (float64, error) {
 
 	// Y = norm(v) * u - norm(u) * v
 	Y := nVu.Clone()
-	Y.Sub(nUv)
+	err = Y.Sub(nUv)
+	if err != nil {
+		return 0, err
+	}
 
 	// X = norm(v) * u + norm(u) * v
 	X := nVu.Clone()
-	X.Add(nUv)
+	err = X.Add(nUv)
+	if err != nil {
+		return 0, err
+	}
 
 	ay, err := Y.Length()
 	if err != nil {
@@ -235,6 +241,19 @@ func (v *Vector3D) Dot(w Vector3DReader) (float64, error) {
 	return r, nil
 }
 
+// DotExtended computes the dot product between this vector and another Vector3DReader in double-double precision,
+// rounding the result to a float64.
+func (v *Vector3D) DotExtended(w Vector3DReader) (float64, error) {
+	ax, ay, az := v.GetComponents()
+	bx, by, bz := w.GetComponents()
+
+	r, err := numeric.DotExtended([]float64{ax, ay, az}, []float64{bx, by, bz})
+	if err != nil {
+		return 0, err
+	}
+	return r.Float64(), nil
+}
+
 // Cross computes the cross product between this vector and another Vector3DReader.
 func (v *Vector3D) Cross(w Vector3DReader) (*Vector3D, error) {
 	ax, ay, az := v.GetComponents()
@@ -255,25 +274,54 @@ func (v *Vector3D) Cross(w Vector3DReader) (*Vector3D, error) {
 	return cross, nil
 }
 
-// IsEqualTo returns true if the vector components are equal within a tolerance of each other, false if not.
-func (v *Vector3D) IsEqualTo(w Vector3DReader, tol float64) (bool, error) {
-	if numeric.IsInvalidTolerance(tol) {
-		return false, numeric.ErrInvalidTol
+// CrossExtended computes the cross product between this vector and another Vector3DReader, evaluating each component
+// in double-double precision and rounding it to a float64.
+func (v *Vector3D) CrossExtended(w Vector3DReader) (*Vector3D, error) {
+	ax, ay, az := v.GetComponents()
+	bx, by, bz := w.GetComponents()
+
+	err := checkFinite([]float64{ax, ay, az, bx, by, bz})
+	if err != nil {
+		return nil, err
+	}
+
+	ux := numeric.DifferenceOfProducts(ay, bz, az, by)
+	uy := numeric.DifferenceOfProducts(az, bx, ax, bz)
+	uz := numeric.DifferenceOfProducts(ax, by, ay, bx)
+	if ux.IsOverflow() || uy.IsOverflow() || uz.IsOverflow() {
+		return nil, numeric.ErrOverflow
 	}
+	return &Vector3D{X: ux.Float64(), Y: uy.Float64(), Z: uz.Float64()}, nil
+}
 
+// Hat returns the skew-symmetric matrix [v] such that [v] * w = v x w for all vectors w.
+func Hat(v Vector3DReader) *Matrix3D {
+	x, y, z := v.GetComponents()
+	return &Matrix3D{
+		elements: [9]float64{0, -z, y, z, 0, -x, -y, x, 0},
+	}
+}
+
+// Vee returns the vector of the skew-symmetric part of the matrix, inverting Hat.
+func Vee(m *Matrix3D) *Vector3D {
+	e := m.elements
+	return &Vector3D{
+		X: (e[7] - e[5]) / 2,
+		Y: (e[2] - e[6]) / 2,
+		Z: (e[3] - e[1]) / 2,
+	}
+}
+
+// IsEqualTo returns true if the vector components are equal within a tolerance of each other, false if not.
+func (v *Vector3D) IsEqualTo(w Vector3DReader, tol numeric.Tolerance) (bool, error) {
 	vx, vy, vz := v.GetComponents()
 	wx, wy, wz := w.GetComponents()
-
-	x := math.Abs(wx - vx)
-	y := math.Abs(wy - vy)
-	z := math.Abs(wz - vz)
-	isEqual := x <= tol && y <= tol && z <= tol
-	return isEqual, nil
+	return areComponentsEqual(tol, []float64{vx, vy, vz}, []float64{wx, wy, wz})
 }
 
 // IsParallelTo returns true if the vector is in the direction (either same or opposite) of the given vector within the given tolerance, false if not.
-func (v *Vector3D) IsParallelTo(w Vector3DReader, tol float64) (bool, error) {
-	if numeric.IsInvalidTolerance(tol) {
+func (v *Vector3D) IsParallelTo(w Vector3DReader, tol numeric.Tolerance) (bool, error) {
+	if tol.IsInvalid() {
 		return false, numeric.ErrInvalidTol
 	}
 
@@ -303,8 +351,8 @@ func (v *Vector3D) IsParallelTo(w Vector3DReader, tol float64) (bool, error) {
 }
 
 // IsPerpendicularTo returns true if the vector is pointed in the same direction as the given vector within the given tolerance, false if not.
-func (v *Vector3D) IsPerpendicularTo(w Vector3DReader, tol float64) (bool, error) {
-	if numeric.IsInvalidTolerance(tol) {
+func (v *Vector3D) IsPerpendicularTo(w Vector3DReader, tol numeric.Tolerance) (bool, error) {
+	if tol.IsInvalid() {
 		return false, numeric.ErrInvalidTol
 	}
 
@@ -318,12 +366,14 @@ func (v *Vector3D) IsPerpendicularTo(w Vector3DReader, tol float64) (bool, error
 		return false, err
 	}
 
-	return math.Abs(d) <= tol, nil
+	// the cosine of the angle is compared at the scale of the unit vectors, since a relative criterion at the scale of
+	// zero could never be met
+	return tol.IsEqualAtScale(d, 0, 1), nil
 }
 
 // IsCodirectionalTo returns true if the vector is pointed in the same direction as the given vector within the given tolerance, false if not.
-func (v *Vector3D) IsCodirectionalTo(w Vector3DReader, tol float64) (bool, error) {
-	if numeric.IsInvalidTolerance(tol) {
+func (v *Vector3D) IsCodirectionalTo(w Vector3DReader, tol numeric.Tolerance) (bool, error) {
+	if tol.IsInvalid() {
 		return false, numeric.ErrInvalidTol
 	}
 
@@ -416,9 +466,47 @@ func (v *Vector3D) Scale(f float64) error {
 	return nil
 }
 
+// RotateBy rotates the vector about the given axis by the given angle (right-hand rule) using Rodrigues' formula.
+func (v *Vector3D) RotateBy(axis Vector3DReader, angleRad float64) error {
+	if math.IsNaN(angleRad) || math.IsInf(angleRad, 0) {
+		return numeric.ErrInvalidArgument
+	}
+
+	k := axis.Clone()
+	err := k.Normalize()
+	if err != nil {
+		return err
+	}
+
+	// v' = v*cos + (k x v)*sin + k*(k . v)*(1 - cos)
+	c := math.Cos(angleRad)
+	s := math.Sin(angleRad)
+	kxv, err := k.Cross(v)
+	if err != nil {
+		return err
+	}
+	kdv, err := k.Dot(v)
+	if err != nil {
+		return err
+	}
+
+	vx, vy, vz := v.GetComponents()
+	kx, ky, kz := k.GetComponents()
+	f := kdv * (1 - c)
+	newX := vx*c + kxv.X*s + kx*f
+	newY := vy*c + kxv.Y*s + ky*f
+	newZ := vz*c + kxv.Z*s + kz*f
+	if numeric.AreAnyOverflow(newX, newY, newZ) {
+		return numeric.ErrOverflow
+	}
+
+	v.SetComponents(newX, newY, newZ)
+	return nil
+}
+
 // MatrixTransform3D transforms this vector by left-multiplying the given matrix.
 func (v *Vector3D) MatrixTransform3D(m *Matrix3D) error {
-	isSingular, err := m.IsNearSingular(1e-12)
+	isSingular, err := m.IsNearSingular(numeric.AbsoluteTolerance(1e-12))
 	if err != nil {
 		return err
 	}
@@ -470,3 +558,126 @@ func (v *Vector3D) HomogeneousMatrixTransform4D(m *Matrix4D) error {
 	v.SetComponents(newX, newY, newZ)
 	return nil
 }
+
+// Lerp linearly interpolates this vector towards the given vector, where t = 0 leaves this vector unchanged and t = 1 gives w.
+func (v *Vector3D) Lerp(w Vector3DReader, t float64) error {
+	if math.IsNaN(t) || math.IsInf(t, 0) {
+		return numeric.ErrInvalidArgument
+	}
+
+	vx, vy, vz := v.GetComponents()
+	wx, wy, wz := w.GetComponents()
+
+	newX := vx + t*(wx-vx)
+	newY := vy + t*(wy-vy)
+	newZ := vz + t*(wz-vz)
+	if numeric.AreAnyOverflow(newX, newY, newZ) {
+		return numeric.ErrOverflow
+	}
+
+	v.SetComponents(newX, newY, newZ)
+	return nil
+}
+
+// Slerp spherically interpolates this unit vector towards the given unit vector along the great circle between them.
+func (v *Vector3D) Slerp(w Vector3DReader, t float64) error {
+	if math.IsNaN(t) || math.IsInf(t, 0) {
+		return numeric.ErrInvalidArgument
+	}
+
+	theta, err := v.AngleTo(w)
+	if err != nil {
+		return err
+	}
+	a, b, err := slerpWeights(theta, t)
+	if err != nil {
+		return err
+	}
+
+	vx, vy, vz := v.GetComponents()
+	wx, wy, wz := w.GetComponents()
+
+	newX := a*vx + b*wx
+	newY := a*vy + b*wy
+	newZ := a*vz + b*wz
+	if numeric.AreAnyOverflow(newX, newY, newZ) {
+		return numeric.ErrOverflow
+	}
+
+	v.SetComponents(newX, newY, newZ)
+	return nil
+}
+
+// sinc computes sin(x)/x, using a Taylor expansion near zero.
+func sinc(x float64) float64 {
+	if math.Abs(x) < 1e-4 {
+		x2 := x * x
+		return 1 - x2/6*(1-x2/20)
+	}
+	return math.Sin(x) / x
+}
+
+// slerpWeights computes the weights sin((1-t)*theta)/sin(theta) and sin(t*theta)/sin(theta) for
+// spherical interpolation over the angle theta. Writing them in terms of sinc keeps them accurate as theta goes to zero.
+// numeric.ErrInvalidArgument is returned for nearly antipodal directions, whose great circle is not unique.
+func slerpWeights(theta, t float64) (float64, float64, error) {
+	if math.IsNaN(theta) {
+		return 0, 0, numeric.ErrNaN
+	}
+	// the weights carry a relative error of about machineEpsilon / (pi - theta), so reject angles that would leave
+	// less than half the working precision
+	if math.Pi-theta < math.Sqrt(machineEpsilon) {
+		return 0, 0, numeric.ErrInvalidArgument
+	}
+
+	s := sinc(theta)
+	a := (1 - t) * sinc((1-t)*theta) / s
+	b := t * sinc(t*theta) / s
+	return a, b, nil
+}
+
+// GramSchmidt orthonormalizes the vectors in order using the modified Gram-Schmidt process with reorthogonalization,
+// so that each result spans the same space as the corresponding input and those before it.
+// numeric.ErrVectorZeroLength is returned if a vector is linearly dependent on the previous ones, when its length
+// after removing their components is zero within the tolerance at the scale of its original length.
+func GramSchmidt(vectors []Vector3DReader, tol numeric.Tolerance) ([]*Vector3D, error) {
+	if tol.IsInvalid() {
+		return nil, numeric.ErrInvalidTol
+	}
+
+	basis := make([]*Vector3D, 0, len(vectors))
+	for _, v := range vectors {
+		u := v.Clone()
+		l0, err := u.Length()
+		if err != nil {
+			return nil, err
+		}
+
+		// a second pass restores the orthogonality lost to cancellation in the first
+		for pass := 0; pass < 2; pass++ {
+			for _, b := range basis {
+				d, err := u.Dot(b)
+				if err != nil {
+					return nil, err
+				}
+				u.X -= d * b.X
+				u.Y -= d * b.Y
+				u.Z -= d * b.Z
+			}
+		}
+
+		l, err := u.Length()
+		if err != nil {
+			return nil, err
+		}
+		if l == 0 || tol.IsEqualAtScale(l, 0, l0) {
+			return nil, numeric.ErrVectorZeroLength
+		}
+		err = u.Scale(1 / l)
+		if err != nil {
+			return nil, err
+		}
+		basis = append(basis, u)
+	}
+	return basis, nil
+}
@@ -0,0 +1,243 @@
+package geometry
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/tab58/v1/spatial/pkg/numeric"
+)
+
+func TestVector3DCrossExtended(t *testing.T) {
+	// the components cancel to a single rounding error of the float64 products
+	v := &Vector3D{X: 1 + 0x1p-30, Y: 1 - 0x1p-30, Z: 0}
+	w := &Vector3D{X: 1 - 0x1p-30, Y: 1 + 0x1p-30, Z: 0}
+	u, err := v.CrossExtended(w)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := 0x1p-28; u.X != 0 || u.Y != 0 || u.Z != want {
+		t.Errorf("CrossExtended = %v, want (0, 0, %v)", u, want)
+	}
+
+	tests := []struct {
+		name string
+		v, w *Vector3D
+		want error
+	}{
+		{"product", &Vector3D{X: 1e200}, &Vector3D{Y: 1e200}, numeric.ErrOverflow},
+		{"cancelling", &Vector3D{X: 1e300, Y: 1e300}, &Vector3D{X: 1e10, Y: 1e10}, numeric.ErrOverflow},
+		{"infinite", &Vector3D{X: math.Inf(1)}, &Vector3D{Y: 1}, numeric.ErrOverflow},
+		{"NaN", &Vector3D{X: math.NaN()}, &Vector3D{Y: 1}, numeric.ErrNaN},
+	}
+	for _, tt := range tests {
+		if _, err := tt.v.CrossExtended(tt.w); err != tt.want {
+			t.Errorf("CrossExtended %s: got %v, want %v", tt.name, err, tt.want)
+		}
+	}
+}
+
+func TestVector3DRotateBy(t *testing.T) {
+	v := &Vector3D{X: 1, Y: 0, Z: 5}
+	if err := v.RotateBy(&Vector3D{Z: 2}, math.Pi/2); err != nil {
+		t.Fatal(err)
+	}
+	// the component along the axis is kept and the rest turns by the right-hand rule
+	checkClose(t, "RotateBy(z, pi/2)", vectorSlice(v), []float64{0, 1, 5}, 1e-15)
+
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		axis, v := randomVector3D(r), randomVector3D(r)
+		angle := 2*math.Pi*r.Float64() - math.Pi
+
+		q := &Quaternion{}
+		if err := q.SetFromAxisAngle(axis, angle); err != nil {
+			t.Fatal(err)
+		}
+		want, err := q.RotateVector3D(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := v.RotateBy(axis, angle); err != nil {
+			t.Fatal(err)
+		}
+		checkClose(t, "RotateBy", vectorSlice(v), vectorSlice(want), 1e-13)
+	}
+
+	if err := v.RotateBy(&Vector3D{}, 1); err == nil {
+		t.Error("RotateBy about a zero axis: got nil error")
+	}
+	if err := v.RotateBy(&Vector3D{X: 1}, math.NaN()); err != numeric.ErrInvalidArgument {
+		t.Errorf("RotateBy(NaN): got %v, want %v", err, numeric.ErrInvalidArgument)
+	}
+}
+
+func TestVector3DSlerp(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	for i := 0; i < 1000; i++ {
+		a, b := randomVector3D(r), randomVector3D(r)
+		if err := a.Normalize(); err != nil {
+			t.Fatal(err)
+		}
+		if err := b.Normalize(); err != nil {
+			t.Fatal(err)
+		}
+		theta, err := a.AngleTo(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, s := range []float64{0, 1} {
+			v := a.Clone()
+			if err := v.Slerp(b, s); err != nil {
+				t.Fatal(err)
+			}
+			want := a
+			if s == 1 {
+				want = b
+			}
+			checkClose(t, "Slerp endpoint", vectorSlice(v), vectorSlice(want), 1e-14)
+		}
+
+		// the interpolant stays on the unit sphere and divides the arc in proportion to t
+		s := r.Float64()
+		v := a.Clone()
+		if err := v.Slerp(b, s); err != nil {
+			t.Fatal(err)
+		}
+		l, err := v.Length()
+		if err != nil {
+			t.Fatal(err)
+		}
+		ta, err := a.AngleTo(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tb, err := v.AngleTo(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkClose(t, "Slerp arc", []float64{l, ta, tb}, []float64{1, s * theta, (1 - s) * theta}, 1e-12)
+	}
+
+	v := &Vector3D{X: 1}
+	if err := v.Slerp(&Vector3D{X: -1}, 0.5); err != numeric.ErrInvalidArgument {
+		t.Errorf("Slerp between antipodal vectors: got %v, want %v", err, numeric.ErrInvalidArgument)
+	}
+}
+
+func TestVector3DLerp(t *testing.T) {
+	v := &Vector3D{X: 1, Y: 2, Z: 3}
+	if err := v.Lerp(&Vector3D{X: 3, Y: 2, Z: -1}, 0.25); err != nil {
+		t.Fatal(err)
+	}
+	checkClose(t, "Lerp", vectorSlice(v), []float64{1.5, 2, 2}, 0)
+}
+
+func TestHatVee(t *testing.T) {
+	r := rand.New(rand.NewSource(5))
+	for i := 0; i < 1000; i++ {
+		v, w := randomVector3D(r), randomVector3D(r)
+		h := Hat(v)
+		vw, err := v.Cross(w)
+		if err != nil {
+			t.Fatal(err)
+		}
+		e := h.Elements()
+		hw := []float64{
+			e[0]*w.X + e[1]*w.Y + e[2]*w.Z,
+			e[3]*w.X + e[4]*w.Y + e[5]*w.Z,
+			e[6]*w.X + e[7]*w.Y + e[8]*w.Z,
+		}
+		checkClose(t, "Hat(v) * w", hw, vectorSlice(vw), 1e-15)
+		checkClose(t, "Vee(Hat(v))", vectorSlice(Vee(h)), vectorSlice(v), 0)
+
+		// the symmetric part of a matrix does not contribute
+		if err := h.Add(&Matrix3D{elements: [9]float64{1, 2, 3, 2, 4, 5, 3, 5, 6}}); err != nil {
+			t.Fatal(err)
+		}
+		checkClose(t, "Vee(Hat(v) + S)", vectorSlice(Vee(h)), vectorSlice(v), 1e-15)
+	}
+}
+
+func TestGramSchmidt(t *testing.T) {
+	r := rand.New(rand.NewSource(6))
+	tol := numeric.RelativeTolerance(1e-12)
+	for i := 0; i < 1000; i++ {
+		vectors := []Vector3DReader{randomVector3D(r), randomVector3D(r), randomVector3D(r)}
+		basis, err := GramSchmidt(vectors, tol)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for j, b := range basis {
+			for k, c := range basis {
+				d, err := b.Dot(c)
+				if err != nil {
+					t.Fatal(err)
+				}
+				want := 0.0
+				if j == k {
+					want = 1
+				}
+				checkClose(t, "b . c", []float64{d}, []float64{want}, 1e-15)
+			}
+		}
+
+		// each result spans the same space as the corresponding input and those before it
+		c, err := vectors[0].Cross(vectors[1])
+		if err != nil {
+			t.Fatal(err)
+		}
+		d, err := c.Dot(basis[1])
+		if err != nil {
+			t.Fatal(err)
+		}
+		l, err := c.Length()
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkClose(t, "(v0 x v1) . b1", []float64{d / l}, []float64{0}, 1e-14)
+		d, err = vectors[0].Dot(basis[0])
+		if err != nil || !(d > 0) {
+			t.Fatalf("b0 does not point along v0: %v, %v", d, err)
+		}
+	}
+
+	// nearly dependent vectors keep their orthogonality through reorthogonalization
+	vectors := []Vector3DReader{&Vector3D{X: 1}, &Vector3D{X: 1, Y: 1e-10}, &Vector3D{X: 1, Y: 1e-10, Z: 1e-10}}
+	basis, err := GramSchmidt(vectors, tol)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkClose(t, "basis", append(append(vectorSlice(basis[0]), vectorSlice(basis[1])...), vectorSlice(basis[2])...),
+		[]float64{1, 0, 0, 0, 1, 0, 0, 0, 1}, 1e-15)
+
+	vectors = []Vector3DReader{&Vector3D{X: 1, Y: 2, Z: 3}, &Vector3D{X: 2, Y: 4, Z: 6}}
+	if _, err := GramSchmidt(vectors, tol); err != numeric.ErrVectorZeroLength {
+		t.Errorf("GramSchmidt of dependent vectors: got %v, want %v", err, numeric.ErrVectorZeroLength)
+	}
+	if _, err := GramSchmidt(vectors, numeric.AbsoluteTolerance(-1)); err != numeric.ErrInvalidTol {
+		t.Errorf("GramSchmidt with an invalid tolerance: got %v, want %v", err, numeric.ErrInvalidTol)
+	}
+}
+
+func TestVector3DIsPerpendicularTo(t *testing.T) {
+	// a relative tolerance measures the cosine of the angle at the scale of the unit vectors rather than of zero
+	rel := numeric.RelativeTolerance(1e-9)
+	r := rand.New(rand.NewSource(7))
+	for i := 0; i < 1000; i++ {
+		v, w := randomVector3D(r), randomVector3D(r)
+		p, err := v.Cross(w)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := v.IsPerpendicularTo(p, rel)
+		if err != nil || !got {
+			t.Fatalf("IsPerpendicularTo(%v, %v x %v) = %v, %v, want true", v, v, w, got, err)
+		}
+		got, err = v.IsPerpendicularTo(v, rel)
+		if err != nil || got {
+			t.Fatalf("IsPerpendicularTo(%v, itself) = %v, %v, want false", v, got, err)
+		}
+	}
+}
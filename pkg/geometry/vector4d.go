@@ -22,16 +22,16 @@ type Vector4DReader interface {
 	ToBlasVector() blas64.Vector
 	GetNormalizedVector() *Vector4D
 
-	IsZeroLength(tol float64) (bool, error)
-	IsUnitLength(tol float64) (bool, error)
+	IsZeroLength(tol numeric.Tolerance) (bool, error)
+	IsUnitLength(tol numeric.Tolerance) (bool, error)
 
 	AngleTo(w Vector4DReader) (float64, error)
 	Dot(w Vector4DReader) (float64, error)
 
-	IsPerpendicularTo(w Vector4DReader, tol float64) (bool, error)
-	IsCodirectionalTo(w Vector4DReader, tol float64) (bool, error)
-	IsParallelTo(w Vector4DReader, tol float64) (bool, error)
-	IsEqualTo(w Vector4DReader, tol float64) (bool, error)
+	IsPerpendicularTo(w Vector4DReader, tol numeric.Tolerance) (bool, error)
+	IsCodirectionalTo(w Vector4DReader, tol numeric.Tolerance) (bool, error)
+	IsParallelTo(w Vector4DReader, tol numeric.Tolerance) (bool, error)
+	IsEqualTo(w Vector4DReader, tol numeric.Tolerance) (bool, error)
 
 	MatrixTransform4D(m *Matrix4D) error
 	ProjectToVector3D() (*Vector3D, error)
@@ -97,7 +97,7 @@ func (v *Vector4D) GetComponents() (x, y, z, w float64) {
 func (v *Vector4D) Length() (float64, error) {
 	x, y, z, w := v.GetComponents()
 
-	r := numeric.Nrm2(numeric.Nrm2(numeric.Nrm2(x, y), z), w)
+	r := numeric.NrmN(x, y, z, w)
 	if numeric.IsOverflow(r) {
 		return 0, numeric.ErrOverflow
 	}
@@ -142,16 +142,16 @@ func (v *Vector4D) GetNormalizedVector() *Vector4D {
 }
 
 // IsZeroLength returns true if the vector is of zero length (within a tolerance), false if not.
-func (v *Vector4D) IsZeroLength(tol float64) (bool, error) {
-	if numeric.IsInvalidTolerance(tol) {
+func (v *Vector4D) IsZeroLength(tol numeric.Tolerance) (bool, error) {
+	if tol.IsInvalid() {
 		return false, numeric.ErrInvalidTol
 	}
 	return v.IsEqualTo(Zero4D, tol)
 }
 
 // IsUnitLength returns true if the vector is equal to the normalized vector within the given tolerance, false if not.
-func (v *Vector4D) IsUnitLength(tol float64) (bool, error) {
-	if numeric.IsInvalidTolerance(tol) {
+func (v *Vector4D) IsUnitLength(tol numeric.Tolerance) (bool, error) {
+	if tol.IsInvalid() {
 		return false, numeric.ErrInvalidTol
 	}
 
@@ -187,11 +187,17 @@ func (v *Vector4D) AngleTo(u Vector4DReader) (float64, error) {
 
 	// Y = norm(v) * u - norm(u) * v
 	Y := nVu.Clone()
-	Y.Sub(nUv)
+	err = Y.Sub(nUv)
+	if err != nil {
+		return 0, err
+	}
 
 	// X = norm(v) * u + norm(u) * v
 	X := nVu.Clone()
-	X.Add(nUv)
+	err = X.Add(nUv)
+	if err != nil {
+		return 0, err
+	}
 
 	ay, err := Y.Length()
 	if err != nil {
@@ -207,7 +213,7 @@ func (v *Vector4D) AngleTo(u Vector4DReader) (float64, error) {
 // Dot computes the dot product between this vector and another Vector3DReader.
 func (v *Vector4D) Dot(w Vector4DReader) (float64, error) {
 	ax, ay, az, aw := v.GetComponents()
-	bx, by, bz, bw := v.GetComponents()
+	bx, by, bz, bw := w.GetComponents()
 
 	r := ax*bx + ay*by + az*bz + aw*bw
 	if numeric.AreAnyOverflow(r) {
@@ -217,9 +223,22 @@ func (v *Vector4D) Dot(w Vector4DReader) (float64, error) {
 	return r, nil
 }
 
+// DotExtended computes the dot product between this vector and another Vector4DReader in double-double precision,
+// rounding the result to a float64.
+func (v *Vector4D) DotExtended(w Vector4DReader) (float64, error) {
+	ax, ay, az, aw := v.GetComponents()
+	bx, by, bz, bw := w.GetComponents()
+
+	r, err := numeric.DotExtended([]float64{ax, ay, az, aw}, []float64{bx, by, bz, bw})
+	if err != nil {
+		return 0, err
+	}
+	return r.Float64(), nil
+}
+
 // IsPerpendicularTo returns true if the vector is pointed in the same direction as the given vector within the given tolerance, false if not.
-func (v *Vector4D) IsPerpendicularTo(w Vector4DReader, tol float64) (bool, error) {
-	if numeric.IsInvalidTolerance(tol) {
+func (v *Vector4D) IsPerpendicularTo(w Vector4DReader, tol numeric.Tolerance) (bool, error) {
+	if tol.IsInvalid() {
 		return false, numeric.ErrInvalidTol
 	}
 
@@ -233,12 +252,12 @@ func (v *Vector4D) IsPerpendicularTo(w Vector4DReader, tol float64) (bool, error
 		return false, err
 	}
 
-	return math.Abs(d) <= tol, nil
+	return tol.IsEqualAtScale(d, 0, 1), nil
 }
 
 // IsCodirectionalTo returns true if the vector is pointed in the same direction as the given vector within the given tolerance, false if not.
-func (v *Vector4D) IsCodirectionalTo(w Vector4DReader, tol float64) (bool, error) {
-	if numeric.IsInvalidTolerance(tol) {
+func (v *Vector4D) IsCodirectionalTo(w Vector4DReader, tol numeric.Tolerance) (bool, error) {
+	if tol.IsInvalid() {
 		return false, numeric.ErrInvalidTol
 	}
 
@@ -251,8 +270,8 @@ func (v *Vector4D) IsCodirectionalTo(w Vector4DReader, tol float64) (bool, error
 }
 
 // IsParallelTo returns true if the vector is in the direction (either same or opposite) of the given vector within the given tolerance, false if not.
-func (v *Vector4D) IsParallelTo(w Vector4DReader, tol float64) (bool, error) {
-	if numeric.IsInvalidTolerance(tol) {
+func (v *Vector4D) IsParallelTo(w Vector4DReader, tol numeric.Tolerance) (bool, error) {
+	if tol.IsInvalid() {
 		return false, numeric.ErrInvalidTol
 	}
 
@@ -282,25 +301,15 @@ func (v *Vector4D) IsParallelTo(w Vector4DReader, tol float64) (bool, error) {
 }
 
 // IsEqualTo returns true if the vector components are equal within a tolerance of each other, false if not.
-func (v *Vector4D) IsEqualTo(w Vector4DReader, tol float64) (bool, error) {
-	if numeric.IsInvalidTolerance(tol) {
-		return false, numeric.ErrInvalidTol
-	}
-
+func (v *Vector4D) IsEqualTo(w Vector4DReader, tol numeric.Tolerance) (bool, error) {
 	vx, vy, vz, vw := v.GetComponents()
 	wx, wy, wz, ww := w.GetComponents()
-
-	x := math.Abs(wx - vx)
-	y := math.Abs(wy - vy)
-	z := math.Abs(wz - vz)
-	dw := math.Abs(ww - vw)
-	isEqual := x <= tol && y <= tol && z <= tol && dw <= tol
-	return isEqual, nil
+	return areComponentsEqual(tol, []float64{vx, vy, vz, vw}, []float64{wx, wy, wz, ww})
 }
 
 // MatrixTransform4D transforms this vector by left-multiplying the given matrix.
 func (v *Vector4D) MatrixTransform4D(m *Matrix4D) error {
-	isSingular, err := m.IsNearSingular(1e-12)
+	isSingular, err := m.IsNearSingular(numeric.AbsoluteTolerance(1e-12))
 	if err != nil {
 		return err
 	}
@@ -454,3 +463,24 @@ func (v *Vector4D) ProjectToVector3D() (*Vector3D, error) {
 	}
 	return u, nil
 }
+
+// Lerp linearly interpolates this vector towards the given vector, where t = 0 leaves this vector unchanged and t = 1 gives w.
+func (v *Vector4D) Lerp(w Vector4DReader, t float64) error {
+	if math.IsNaN(t) || math.IsInf(t, 0) {
+		return numeric.ErrInvalidArgument
+	}
+
+	vx, vy, vz, vw := v.GetComponents()
+	wx, wy, wz, ww := w.GetComponents()
+
+	newX := vx + t*(wx-vx)
+	newY := vy + t*(wy-vy)
+	newZ := vz + t*(wz-vz)
+	newW := vw + t*(ww-vw)
+	if numeric.AreAnyOverflow(newX, newY, newZ, newW) {
+		return numeric.ErrOverflow
+	}
+
+	v.SetComponents(newX, newY, newZ, newW)
+	return nil
+}
@@ -0,0 +1,26 @@
+package geometry
+
+import (
+	"testing"
+
+	"github.com/tab58/v1/spatial/pkg/numeric"
+)
+
+func TestVector4DIsPerpendicularTo(t *testing.T) {
+	// a relative tolerance measures the cosine of the angle at the scale of the unit vectors rather than of zero
+	rel := numeric.RelativeTolerance(1e-9)
+	v := &Vector4D{X: 1, Y: 2, Z: 3, W: 4}
+	for _, tt := range []struct {
+		w    *Vector4D
+		want bool
+	}{
+		{&Vector4D{X: 2, Y: -1, Z: 4, W: -3}, true},
+		{&Vector4D{X: 2e-12, Y: -1e-12, Z: 4e-12, W: -3e-12}, true},
+		{&Vector4D{X: 2, Y: -1, Z: 4, W: -2.999}, false},
+	} {
+		got, err := v.IsPerpendicularTo(tt.w, rel)
+		if err != nil || got != tt.want {
+			t.Errorf("IsPerpendicularTo(%v, %v) = %v, %v, want %v", v, tt.w, got, err, tt.want)
+		}
+	}
+}
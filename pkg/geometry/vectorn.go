@@ -0,0 +1,253 @@
+package geometry
+
+import (
+	"github.com/tab58/v1/spatial/pkg/numeric"
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+)
+
+// VectorN is a representation of a vector with any positive number of components, backed by a blas64.Vector.
+type VectorN struct {
+	data blas64.Vector
+}
+
+// NewVectorN creates a zero vector with n components.
+func NewVectorN(n uint) (*VectorN, error) {
+	if n == 0 {
+		return nil, numeric.ErrEmptyArray
+	}
+	return &VectorN{
+		data: blas64.Vector{N: int(n), Data: make([]float64, n), Inc: 1},
+	}, nil
+}
+
+// NewVectorNFromSlice creates a vector with a copy of the given components.
+func NewVectorNFromSlice(components []float64) (*VectorN, error) {
+	v, err := NewVectorN(uint(len(components)))
+	if err != nil {
+		return nil, err
+	}
+	copy(v.data.Data, components)
+	return v, nil
+}
+
+// NewVectorNFromBlas creates a vector with a copy of the components of the BLAS vector.
+func NewVectorNFromBlas(b blas64.Vector) (*VectorN, error) {
+	if b.N <= 0 {
+		return nil, numeric.ErrEmptyArray
+	}
+	v, err := NewVectorN(uint(b.N))
+	if err != nil {
+		return nil, err
+	}
+	blas64.Copy(b, v.data)
+	return v, nil
+}
+
+// NewVectorNFromVector2D creates a vector with 2 components from the 2D vector.
+func NewVectorNFromVector2D(w Vector2DReader) *VectorN {
+	x, y := w.GetComponents()
+	return &VectorN{data: blas64.Vector{N: 2, Data: []float64{x, y}, Inc: 1}}
+}
+
+// NewVectorNFromVector3D creates a vector with 3 components from the 3D vector.
+func NewVectorNFromVector3D(w Vector3DReader) *VectorN {
+	x, y, z := w.GetComponents()
+	return &VectorN{data: blas64.Vector{N: 3, Data: []float64{x, y, z}, Inc: 1}}
+}
+
+// NewVectorNFromVector4D creates a vector with 4 components from the 4D vector.
+func NewVectorNFromVector4D(w Vector4DReader) *VectorN {
+	x, y, z, ww := w.GetComponents()
+	return &VectorN{data: blas64.Vector{N: 4, Data: []float64{x, y, z, ww}, Inc: 1}}
+}
+
+// Len returns the number of components of the vector.
+func (v *VectorN) Len() uint {
+	return uint(v.data.N)
+}
+
+// ComponentAt returns the component at the given index.
+func (v *VectorN) ComponentAt(i uint) (float64, error) {
+	if i >= v.Len() {
+		return 0, numeric.ErrVectorOutOfRange
+	}
+	return v.data.Data[i], nil
+}
+
+// SetComponentAt sets the component at the given index.
+func (v *VectorN) SetComponentAt(i uint, value float64) error {
+	if i >= v.Len() {
+		return numeric.ErrVectorOutOfRange
+	}
+	v.data.Data[i] = value
+	return nil
+}
+
+// Components clones the components of the vector and returns them.
+func (v *VectorN) Components() []float64 {
+	tmp := make([]float64, v.data.N)
+	copy(tmp, v.data.Data)
+	return tmp
+}
+
+// Clone returns a deep copy of the vector.
+func (v *VectorN) Clone() *VectorN {
+	return &VectorN{
+		data: blas64.Vector{N: v.data.N, Data: v.Components(), Inc: 1},
+	}
+}
+
+// Copy copies the components of the given vector to this one.
+func (v *VectorN) Copy(w *VectorN) error {
+	if v.data.N != w.data.N {
+		return numeric.ErrVectorDims
+	}
+	copy(v.data.Data, w.data.Data)
+	return nil
+}
+
+// ToBlasVector returns a BLAS vector with the same values as the vector.
+func (v *VectorN) ToBlasVector() blas64.Vector {
+	return blas64.Vector{N: v.data.N, Data: v.Components(), Inc: 1}
+}
+
+// ToVector2D converts the vector to a 2D vector. It must have 2 components.
+func (v *VectorN) ToVector2D() (*Vector2D, error) {
+	if v.data.N != 2 {
+		return nil, numeric.ErrVectorDims
+	}
+	d := v.data.Data
+	return &Vector2D{X: d[0], Y: d[1]}, nil
+}
+
+// ToVector3D converts the vector to a 3D vector. It must have 3 components.
+func (v *VectorN) ToVector3D() (*Vector3D, error) {
+	if v.data.N != 3 {
+		return nil, numeric.ErrVectorDims
+	}
+	d := v.data.Data
+	return &Vector3D{X: d[0], Y: d[1], Z: d[2]}, nil
+}
+
+// ToVector4D converts the vector to a 4D vector. It must have 4 components.
+func (v *VectorN) ToVector4D() (*Vector4D, error) {
+	if v.data.N != 4 {
+		return nil, numeric.ErrVectorDims
+	}
+	d := v.data.Data
+	return &Vector4D{X: d[0], Y: d[1], Z: d[2], W: d[3]}, nil
+}
+
+// Segment returns a copy of the n components starting at the given index.
+func (v *VectorN) Segment(start, n uint) (*VectorN, error) {
+	if n == 0 || start+n > v.Len() {
+		return nil, numeric.ErrVectorOutOfRange
+	}
+	return NewVectorNFromSlice(v.data.Data[start : start+n])
+}
+
+// SetSegment copies the components of the given vector into this one starting at the given index.
+func (v *VectorN) SetSegment(start uint, w *VectorN) error {
+	if start+w.Len() > v.Len() {
+		return numeric.ErrVectorOutOfRange
+	}
+	copy(v.data.Data[start:], w.data.Data)
+	return nil
+}
+
+// Dot computes the dot product of this vector with the given vector.
+func (v *VectorN) Dot(w *VectorN) (float64, error) {
+	if v.data.N != w.data.N {
+		return 0, numeric.ErrVectorDims
+	}
+	d := blas64.Dot(v.data, w.data)
+	if numeric.IsOverflow(d) {
+		return 0, numeric.ErrOverflow
+	}
+	return d, nil
+}
+
+// Length computes the length of the vector.
+func (v *VectorN) Length() (float64, error) {
+	l := blas64.Nrm2(v.data)
+	if numeric.IsOverflow(l) {
+		return 0, numeric.ErrOverflow
+	}
+	return l, nil
+}
+
+// Negate negates the vector components.
+func (v *VectorN) Negate() {
+	blas64.Scal(-1, v.data)
+}
+
+// Add adds the given vector to this one.
+func (v *VectorN) Add(w *VectorN) error {
+	return v.addScaled(1, w)
+}
+
+// Sub subtracts the given vector from this one.
+func (v *VectorN) Sub(w *VectorN) error {
+	return v.addScaled(-1, w)
+}
+
+// addScaled adds f * w to this vector, leaving it unchanged on overflow.
+func (v *VectorN) addScaled(f float64, w *VectorN) error {
+	if v.data.N != w.data.N {
+		return numeric.ErrVectorDims
+	}
+	tmp := v.ToBlasVector()
+	blas64.Axpy(f, w.data, tmp)
+	if numeric.AreAnyOverflow(tmp.Data...) {
+		return numeric.ErrOverflow
+	}
+	v.data = tmp
+	return nil
+}
+
+// Scale scales the vector by the given factor.
+func (v *VectorN) Scale(f float64) error {
+	tmp := v.ToBlasVector()
+	blas64.Scal(f, tmp)
+	if numeric.AreAnyOverflow(tmp.Data...) {
+		return numeric.ErrOverflow
+	}
+	v.data = tmp
+	return nil
+}
+
+// Normalize scales the vector to unit length.
+func (v *VectorN) Normalize() error {
+	l, err := v.Length()
+	if err != nil {
+		return err
+	}
+	if l == 0 {
+		return numeric.ErrDivideByZero
+	}
+	return v.Scale(1 / l)
+}
+
+// IsEqualTo returns true if the vector components are equal within a tolerance of each other, false if not.
+func (v *VectorN) IsEqualTo(w *VectorN, tol numeric.Tolerance) (bool, error) {
+	if v.data.N != w.data.N {
+		return false, numeric.ErrVectorDims
+	}
+	return areComponentsEqual(tol, v.data.Data, w.data.Data)
+}
+
+// MatrixTransform transforms this vector by left-multiplying the given matrix, whose column count must match the
+// length of the vector. The vector takes the row count of the matrix as its length.
+func (v *VectorN) MatrixTransform(m *MatrixMN) error {
+	if m.data.Cols != v.data.N {
+		return numeric.ErrMatrixDims
+	}
+	out := blas64.Vector{N: m.data.Rows, Data: make([]float64, m.data.Rows), Inc: 1}
+	blas64.Gemv(blas.NoTrans, 1, m.data, v.data, 0, out)
+	if numeric.AreAnyOverflow(out.Data...) {
+		return numeric.ErrOverflow
+	}
+	v.data = out
+	return nil
+}
@@ -0,0 +1,75 @@
+package geometry
+
+import (
+	"testing"
+
+	"github.com/tab58/v1/spatial/pkg/numeric"
+)
+
+func TestVectorNSegment(t *testing.T) {
+	v, err := NewVectorNFromSlice([]float64{1, 2, 3, 4, 5, 6})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := v.Segment(3, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkClose(t, "Segment", s.Components(), []float64{4, 5, 6}, 0)
+
+	// the segment is a copy
+	if err := s.SetComponentAt(0, -4); err != nil {
+		t.Fatal(err)
+	}
+	if c, _ := v.ComponentAt(3); c != 4 {
+		t.Fatalf("modifying the segment changed the vector to %v", v.Components())
+	}
+
+	w, err := NewVectorN(6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.SetSegment(1, s); err != nil {
+		t.Fatal(err)
+	}
+	checkClose(t, "SetSegment", w.Components(), []float64{0, -4, 5, 6, 0, 0}, 0)
+
+	// a fixed-size vector goes in and comes out of a larger vector as a segment
+	x := &Vector3D{X: 7, Y: 8, Z: 9}
+	if err := w.SetSegment(3, NewVectorNFromVector3D(x)); err != nil {
+		t.Fatal(err)
+	}
+	s, err = w.Segment(3, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := s.ToVector3D()
+	if err != nil || *got != *x {
+		t.Errorf("3D segment = %v, %v, want %v", got, err, x)
+	}
+
+	if _, err := v.Segment(4, 3); err != numeric.ErrVectorOutOfRange {
+		t.Errorf("Segment past the end: got %v, want %v", err, numeric.ErrVectorOutOfRange)
+	}
+	if err := v.SetSegment(4, s); err != numeric.ErrVectorOutOfRange {
+		t.Errorf("SetSegment past the end: got %v, want %v", err, numeric.ErrVectorOutOfRange)
+	}
+	if _, err := v.ToVector3D(); err != numeric.ErrVectorDims {
+		t.Errorf("ToVector3D of a 6-vector: got %v, want %v", err, numeric.ErrVectorDims)
+	}
+}
+
+func TestVectorNMatrixTransform(t *testing.T) {
+	m := sequenceMatrixMN(t, 2, 3)
+	v, err := NewVectorNFromSlice([]float64{1, 0, -1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := v.MatrixTransform(m); err != nil {
+		t.Fatal(err)
+	}
+	checkClose(t, "m * v", v.Components(), []float64{-2, -2}, 0)
+	if err := v.MatrixTransform(m); err != numeric.ErrMatrixDims {
+		t.Errorf("MatrixTransform with mismatched dimensions: got %v, want %v", err, numeric.ErrMatrixDims)
+	}
+}
@@ -0,0 +1,206 @@
+package kinematics
+
+import (
+	"math"
+
+	"github.com/tab58/v1/spatial/pkg/geometry"
+	"github.com/tab58/v1/spatial/pkg/numeric"
+)
+
+// Affine2DComponents describes an affine transformation in the plane as A = R(Angle) * H(Shear) * S(ScaleX, ScaleY)
+// followed by a translation, where H = [1, Shear; 0, 1] is a shear along the x-axis and S = diag(ScaleX, ScaleY).
+// A reflection is represented by a negative ScaleY.
+type Affine2DComponents struct {
+	Translation geometry.Vector2D
+	Angle       float64
+	ScaleX      float64
+	ScaleY      float64
+	Shear       float64
+}
+
+// Affine2D is an affine transformation in the plane: a linear map followed by a translation.
+// The zero value is not a valid transform; use NewAffine2D or Identity.
+type Affine2D struct {
+	linear      geometry.Matrix2D
+	translation geometry.Vector2D
+}
+
+// NewAffine2D creates an affine transform from the given linear map and translation vector.
+func NewAffine2D(linear *geometry.Matrix2D, translation geometry.Vector2DReader) *Affine2D {
+	t := &Affine2D{}
+	t.linear.Copy(linear)
+	t.translation.SetComponents(translation.GetComponents())
+	return t
+}
+
+// NewAffine2DFromComponents creates an affine transform from its rotation, shear, scale and translation components.
+func NewAffine2DFromComponents(c *Affine2DComponents) (*Affine2D, error) {
+	cs, sn := math.Cos(c.Angle), math.Sin(c.Angle)
+
+	// R * H * S = [c, -s; s, c] * [sx, k*sy; 0, sy]
+	ksy := c.Shear * c.ScaleY
+	m00, m01 := cs*c.ScaleX, cs*ksy-sn*c.ScaleY
+	m10, m11 := sn*c.ScaleX, sn*ksy+cs*c.ScaleY
+
+	t := &Affine2D{}
+	err := t.linear.SetElements(m00, m01, m10, m11)
+	if err != nil {
+		return nil, err
+	}
+	t.translation.SetComponents(c.Translation.GetComponents())
+	return t, nil
+}
+
+// Identity sets the transform to the identity transform.
+func (t *Affine2D) Identity() {
+	t.linear.Identity()
+	t.translation.SetComponents(0, 0)
+}
+
+// Clone returns a deep copy of the transform.
+func (t *Affine2D) Clone() *Affine2D {
+	return NewAffine2D(&t.linear, &t.translation)
+}
+
+// Linear returns a copy of the linear part of the transform.
+func (t *Affine2D) Linear() *geometry.Matrix2D {
+	return t.linear.Clone()
+}
+
+// Translation returns a copy of the translation vector of the transform.
+func (t *Affine2D) Translation() *geometry.Vector2D {
+	return t.translation.Clone()
+}
+
+// Decompose splits the transform into its rotation, shear, scale and translation components, with a positive ScaleX.
+// numeric.ErrSingularMatrix is returned if the linear part is singular.
+func (t *Affine2D) Decompose() (*Affine2DComponents, error) {
+	e := t.linear.Elements()
+
+	// the first column is R * (sx, 0) and the upper triangular factor is R^T * A
+	sx := math.Hypot(e[0], e[2])
+	if sx == 0 {
+		return nil, numeric.ErrSingularMatrix
+	}
+	angle := math.Atan2(e[2], e[0])
+	cs, sn := e[0]/sx, e[2]/sx
+	ksy := cs*e[1] + sn*e[3]
+	sy := cs*e[3] - sn*e[1]
+	if sy == 0 {
+		return nil, numeric.ErrSingularMatrix
+	}
+	shear := ksy / sy
+	if numeric.IsOverflow(shear) {
+		return nil, numeric.ErrOverflow
+	}
+
+	c := &Affine2DComponents{Angle: angle, ScaleX: sx, ScaleY: sy, Shear: shear}
+	c.Translation.SetComponents(t.translation.GetComponents())
+	return c, nil
+}
+
+// linearTransform2D computes A * (x, y) for the row-major 2x2 elements.
+func linearTransform2D(e [4]float64, x, y float64) (float64, float64, error) {
+	rx := e[0]*x + e[1]*y
+	ry := e[2]*x + e[3]*y
+	if numeric.AreAnyOverflow(rx, ry) {
+		return 0, 0, numeric.ErrOverflow
+	}
+	return rx, ry, nil
+}
+
+// Compose sets this transform to the composition of this transform with the given one, so that the result
+// applies u first and then the original transform.
+func (t *Affine2D) Compose(u *Affine2D) error {
+	// (A1, p1) * (A2, p2) = (A1 * A2, A1 * p2 + p1)
+	x, y, err := linearTransform2D(t.linear.Elements(), u.translation.X, u.translation.Y)
+	if err != nil {
+		return err
+	}
+	p := &geometry.Vector2D{X: x, Y: y}
+	err = p.Add(&t.translation)
+	if err != nil {
+		return err
+	}
+
+	a := t.linear.Clone()
+	err = a.Postmultiply(&u.linear)
+	if err != nil {
+		return err
+	}
+	t.linear.Copy(a)
+	t.translation.SetComponents(p.GetComponents())
+	return nil
+}
+
+// Inverse returns the inverse transform, (A^-1, -A^-1 * p).
+func (t *Affine2D) Inverse() (*Affine2D, error) {
+	a := t.linear.Clone()
+	err := a.Invert()
+	if err != nil {
+		return nil, err
+	}
+	x, y, err := linearTransform2D(a.Elements(), t.translation.X, t.translation.Y)
+	if err != nil {
+		return nil, err
+	}
+	return NewAffine2D(a, &geometry.Vector2D{X: -x, Y: -y}), nil
+}
+
+// ApplyToPoint returns the point transformed by the linear map and then the translation.
+func (t *Affine2D) ApplyToPoint(p geometry.Point2DReader) (*geometry.Point2D, error) {
+	x, y, err := linearTransform2D(t.linear.Elements(), p.GetX(), p.GetY())
+	if err != nil {
+		return nil, err
+	}
+	newX := x + t.translation.X
+	newY := y + t.translation.Y
+	if numeric.AreAnyOverflow(newX, newY) {
+		return nil, numeric.ErrOverflow
+	}
+	return &geometry.Point2D{X: newX, Y: newY}, nil
+}
+
+// ApplyToVector returns the vector transformed by the linear map only; displacement vectors are unaffected by translation.
+func (t *Affine2D) ApplyToVector(v geometry.Vector2DReader) (*geometry.Vector2D, error) {
+	x, y, err := linearTransform2D(t.linear.Elements(), v.GetX(), v.GetY())
+	if err != nil {
+		return nil, err
+	}
+	return &geometry.Vector2D{X: x, Y: y}, nil
+}
+
+// ToHomogeneousTransform3D returns the transform encoded as a 3x3 homogeneous matrix.
+func (t *Affine2D) ToHomogeneousTransform3D() (*HomogeneousTransform3D, error) {
+	e := t.linear.Elements()
+	x, y := t.translation.GetComponents()
+
+	m := &HomogeneousTransform3D{Matrix3D: &geometry.Matrix3D{}}
+	err := m.SetElements(e[0], e[1], x, e[2], e[3], y, 0, 0, 1)
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// SetFromHomogeneousTransform3D sets the transform from the linear and translation blocks of a 3x3 homogeneous matrix.
+// The bottom row of the matrix is assumed to be (0, 0, 1).
+func (t *Affine2D) SetFromHomogeneousTransform3D(m *HomogeneousTransform3D) error {
+	e := m.Elements()
+	err := t.linear.SetElements(e[0], e[1], e[3], e[4])
+	if err != nil {
+		return err
+	}
+	t.translation.SetComponents(e[2], e[5])
+	return nil
+}
+
+// ToAffine2D returns the pose as an affine transform.
+func (p *Pose2D) ToAffine2D() (*Affine2D, error) {
+	return NewAffine2DFromComponents(&Affine2DComponents{
+		Translation: geometry.Vector2D{X: p.X, Y: p.Y},
+		Angle:       p.Theta,
+		ScaleX:      1,
+		ScaleY:      1,
+	})
+}
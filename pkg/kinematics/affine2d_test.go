@@ -0,0 +1,160 @@
+package kinematics
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/tab58/v1/spatial/pkg/geometry"
+	"github.com/tab58/v1/spatial/pkg/numeric"
+)
+
+// randomAffine2DComponents returns components with a heading in (-pi, pi), positive scales and a random shear.
+func randomAffine2DComponents(r *rand.Rand) *Affine2DComponents {
+	return &Affine2DComponents{
+		Translation: geometry.Vector2D{X: r.NormFloat64(), Y: r.NormFloat64()},
+		Angle:       (2*r.Float64() - 1) * math.Pi,
+		ScaleX:      0.1 + r.Float64(),
+		ScaleY:      0.1 + r.Float64(),
+		Shear:       r.NormFloat64(),
+	}
+}
+
+// affineComponentsSlice returns the components as a slice.
+func affineComponentsSlice(c *Affine2DComponents) []float64 {
+	return []float64{c.Translation.X, c.Translation.Y, c.Angle, c.ScaleX, c.ScaleY, c.Shear}
+}
+
+func TestAffine2DDecompose(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		c := randomAffine2DComponents(r)
+		// reflections carry a negative y scale
+		if i%2 == 1 {
+			c.ScaleY = -c.ScaleY
+		}
+		a, err := NewAffine2DFromComponents(c)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := a.Decompose()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !closeSlices(affineComponentsSlice(got), affineComponentsSlice(c), 1e-12) {
+			t.Fatalf("Decompose = %v, want %v", affineComponentsSlice(got), affineComponentsSlice(c))
+		}
+	}
+
+	a := NewAffine2D(&geometry.Matrix2D{}, &geometry.Vector2D{})
+	if _, err := a.Decompose(); err != numeric.ErrSingularMatrix {
+		t.Errorf("Decompose of a zero map: got %v, want %v", err, numeric.ErrSingularMatrix)
+	}
+	a, err := NewAffine2DFromComponents(&Affine2DComponents{ScaleX: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := a.Decompose(); err != numeric.ErrSingularMatrix {
+		t.Errorf("Decompose of a projection: got %v, want %v", err, numeric.ErrSingularMatrix)
+	}
+	if _, err := a.Inverse(); err != numeric.ErrSingularMatrix {
+		t.Errorf("Inverse of a projection: got %v, want %v", err, numeric.ErrSingularMatrix)
+	}
+}
+
+func TestAffine2DComposeInverse(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	for i := 0; i < 1000; i++ {
+		a, err := NewAffine2DFromComponents(randomAffine2DComponents(r))
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := NewAffine2DFromComponents(randomAffine2DComponents(r))
+		if err != nil {
+			t.Fatal(err)
+		}
+		pt := &geometry.Point2D{X: r.NormFloat64(), Y: r.NormFloat64()}
+
+		// a * b applies b first
+		ab := a.Clone()
+		if err := ab.Compose(b); err != nil {
+			t.Fatal(err)
+		}
+		got, err := ab.ApplyToPoint(pt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		bp, err := b.ApplyToPoint(pt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want, err := a.ApplyToPoint(bp)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !closeSlices([]float64{got.X, got.Y}, []float64{want.X, want.Y}, 1e-13) {
+			t.Fatalf("(a * b) x = %v, want %v", got, want)
+		}
+
+		// the inverse undoes the transform, and vectors ignore the translation
+		inv, err := a.Inverse()
+		if err != nil {
+			t.Fatal(err)
+		}
+		ap, err := a.ApplyToPoint(pt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		back, err := inv.ApplyToPoint(ap)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !closeSlices([]float64{back.X, back.Y}, []float64{pt.X, pt.Y}, 1e-11) {
+			t.Fatalf("a^-1 (a x) = %v, want %v", back, pt)
+		}
+		v, err := a.ApplyToVector(&geometry.Vector2D{X: pt.X, Y: pt.Y})
+		if err != nil {
+			t.Fatal(err)
+		}
+		tr := a.Translation()
+		if !closeSlices([]float64{v.X + tr.X, v.Y + tr.Y}, []float64{ap.X, ap.Y}, 1e-14) {
+			t.Fatalf("ApplyToVector = %v, ApplyToPoint = %v", v, ap)
+		}
+
+		// the homogeneous matrix round trips
+		m, err := a.ToHomogeneousTransform3D()
+		if err != nil {
+			t.Fatal(err)
+		}
+		c := &Affine2D{}
+		if err := c.SetFromHomogeneousTransform3D(m); err != nil {
+			t.Fatal(err)
+		}
+		if c.Linear().Elements() != a.Linear().Elements() || *c.Translation() != *a.Translation() {
+			t.Fatalf("SetFromHomogeneousTransform3D(ToHomogeneousTransform3D(a)) = %v, want %v", c, a)
+		}
+	}
+}
+
+func TestPose2DToAffine2D(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	for i := 0; i < 100; i++ {
+		p := randomPose2D(r)
+		a, err := p.ToAffine2D()
+		if err != nil {
+			t.Fatal(err)
+		}
+		pt := &geometry.Point2D{X: r.NormFloat64(), Y: r.NormFloat64()}
+		got, err := a.ApplyToPoint(pt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want, err := p.ApplyToPoint(pt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !closeSlices([]float64{got.X, got.Y}, []float64{want.X, want.Y}, 1e-15) {
+			t.Fatalf("ToAffine2D(p) x = %v, want %v", got, want)
+		}
+	}
+}
@@ -2,16 +2,47 @@ package kinematics
 
 import (
 	"github.com/tab58/v1/spatial/pkg/geometry"
+	"github.com/tab58/v1/spatial/pkg/numeric"
 )
 
 // CoordinateSystem defines a coordinate system for referencing vectors and points.
 type CoordinateSystem struct {
+	name   string
 	origin geometry.Point3DReader
 	b0     geometry.Vector3DReader
 	b1     geometry.Vector3DReader
 	parent *CoordinateSystem
 }
 
+// NewCoordinateSystem creates a coordinate system whose origin and first two basis vectors are expressed in the
+// parent coordinate system. A nil parent creates a root coordinate system.
+func NewCoordinateSystem(name string, parent *CoordinateSystem, origin geometry.Point3DReader, b0, b1 geometry.Vector3DReader) (*CoordinateSystem, error) {
+	c := &CoordinateSystem{
+		name:   name,
+		origin: origin.Clone(),
+		b0:     b0.Clone(),
+		b1:     b1.Clone(),
+		parent: parent,
+	}
+
+	// reject bases that do not span a plane
+	_, _, _, err := c.basis()
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Name returns the name of the coordinate system.
+func (c *CoordinateSystem) Name() string {
+	return c.name
+}
+
+// Parent returns the parent coordinate system, or nil if this is a root coordinate system.
+func (c *CoordinateSystem) Parent() *CoordinateSystem {
+	return c.parent
+}
+
 // Origin returns the origin of the coordinate system.
 func (c *CoordinateSystem) Origin() geometry.Point3DReader {
 	return c.origin
@@ -27,18 +58,26 @@ func (c *CoordinateSystem) B1() geometry.Vector3DReader {
 	return c.b1
 }
 
-// GetLocalOrientation returns the rotation matrix that defines the orientation of the coordinate system from the parent coordinate system.
-func (c *CoordinateSystem) GetLocalOrientation() (*geometry.Matrix3D, error) {
-	b0 := c.b0
+// basisTol is the fraction of its length below which b1 is considered parallel to b0.
+var basisTol = numeric.RelativeTolerance(1e-12)
 
-	// calculate z-axis
-	b2, err := b0.Cross(c.b1)
+// basis computes the right-handed orthonormal basis of the coordinate system. The first basis vector keeps the
+// direction of b0, and the second lies in the plane of b0 and b1.
+func (c *CoordinateSystem) basis() (*geometry.Vector3D, *geometry.Vector3D, *geometry.Vector3D, error) {
+	e, err := geometry.GramSchmidt([]geometry.Vector3DReader{c.b0, c.b1}, basisTol)
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, err
 	}
+	e2, err := e[0].Cross(e[1])
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return e[0], e[1], e2, nil
+}
 
-	// do just in case b0 is not orthogonal to c.b1
-	b1, err := b0.Cross(b2)
+// GetLocalOrientation returns the rotation matrix that defines the orientation of the coordinate system from the parent coordinate system.
+func (c *CoordinateSystem) GetLocalOrientation() (*geometry.Matrix3D, error) {
+	b0, b1, b2, err := c.basis()
 	if err != nil {
 		return nil, err
 	}
@@ -63,7 +102,7 @@ func (c *CoordinateSystem) GetGlobalOrientation() (*geometry.Matrix3D, error) {
 		if err != nil {
 			return nil, err
 		}
-		err = global.Premultiply(localOrient)
+		err = global.Postmultiply(localOrient)
 		if err != nil {
 			return nil, err
 		}
@@ -71,3 +110,105 @@ func (c *CoordinateSystem) GetGlobalOrientation() (*geometry.Matrix3D, error) {
 
 	return global, nil
 }
+
+// GetLocalTransform returns the transform that maps coordinates in this coordinate system to coordinates in the parent coordinate system.
+func (c *CoordinateSystem) GetLocalTransform() (*RigidTransform3D, error) {
+	m, err := c.GetLocalOrientation()
+	if err != nil {
+		return nil, err
+	}
+	// the basis vectors are the columns of the local-to-parent rotation
+	m.Transpose()
+	return NewRigidTransform3D(m, c.origin.AsVector()), nil
+}
+
+// GetGlobalTransform returns the transform that maps coordinates in this coordinate system to coordinates in the root coordinate system.
+func (c *CoordinateSystem) GetGlobalTransform() (*RigidTransform3D, error) {
+	return c.transformToAncestor(nil)
+}
+
+// transformToAncestor composes the local transforms from this coordinate system up to, but not including, the given ancestor.
+func (c *CoordinateSystem) transformToAncestor(ancestor *CoordinateSystem) (*RigidTransform3D, error) {
+	global := &RigidTransform3D{}
+	global.Identity()
+
+	for current := c; current != ancestor; current = current.parent {
+		local, err := current.GetLocalTransform()
+		if err != nil {
+			return nil, err
+		}
+		err = local.Compose(global)
+		if err != nil {
+			return nil, err
+		}
+		global = local
+	}
+
+	return global, nil
+}
+
+// Root returns the root of the coordinate system's hierarchy.
+func (c *CoordinateSystem) Root() *CoordinateSystem {
+	current := c
+	for current.parent != nil {
+		current = current.parent
+	}
+	return current
+}
+
+// depth returns the number of ancestors of the coordinate system.
+func (c *CoordinateSystem) depth() int {
+	d := 0
+	for current := c.parent; current != nil; current = current.parent {
+		d++
+	}
+	return d
+}
+
+// commonAncestor finds the lowest common ancestor of two coordinate systems, or nil if they are in different hierarchies.
+func commonAncestor(a, b *CoordinateSystem) *CoordinateSystem {
+	da, db := a.depth(), b.depth()
+	for ; da > db; da-- {
+		a = a.parent
+	}
+	for ; db > da; db-- {
+		b = b.parent
+	}
+	for a != b {
+		a = a.parent
+		b = b.parent
+	}
+	return a
+}
+
+// GetTransformTo returns the transform that maps coordinates in this coordinate system to coordinates in the given one.
+func (c *CoordinateSystem) GetTransformTo(target *CoordinateSystem) (*RigidTransform3D, error) {
+	if target == nil {
+		return nil, numeric.ErrInvalidArgument
+	}
+
+	lca := commonAncestor(c, target)
+	if lca == nil {
+		return nil, ErrFramesNotConnected
+	}
+
+	// T(target <- c) = T(lca <- target)^-1 * T(lca <- c)
+	fromSource, err := c.transformToAncestor(lca)
+	if err != nil {
+		return nil, err
+	}
+	fromTarget, err := target.transformToAncestor(lca)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := fromTarget.Inverse()
+	if err != nil {
+		return nil, err
+	}
+	err = t.Compose(fromSource)
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
@@ -0,0 +1,44 @@
+package kinematics
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/tab58/v1/spatial/pkg/geometry"
+	"github.com/tab58/v1/spatial/pkg/numeric"
+)
+
+func TestCoordinateSystemLocalOrientation(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		// neither unit length nor perpendicular
+		b0, b1 := randomVector3D(r), randomVector3D(r)
+		c, err := NewCoordinateSystem("c", nil, &geometry.Point3D{}, b0, b1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		m, err := c.GetLocalOrientation()
+		if err != nil {
+			t.Fatal(err)
+		}
+		isRotation, err := m.IsRotation(numeric.AbsoluteTolerance(1e-14))
+		if err != nil || !isRotation {
+			t.Fatalf("GetLocalOrientation = %v is not a rotation: %v", m.Elements(), err)
+		}
+
+		// the first row keeps the direction of b0
+		e := m.Elements()
+		l, err := b0.Length()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !closeSlices(e[:3], []float64{b0.X / l, b0.Y / l, b0.Z / l}, 1e-15) {
+			t.Fatalf("first basis vector %v, want the direction of %v", e[:3], b0)
+		}
+	}
+
+	_, err := NewCoordinateSystem("c", nil, &geometry.Point3D{}, &geometry.Vector3D{X: 1}, &geometry.Vector3D{X: -2})
+	if err != numeric.ErrVectorZeroLength {
+		t.Errorf("NewCoordinateSystem with parallel basis vectors: got %v, want %v", err, numeric.ErrVectorZeroLength)
+	}
+}
@@ -0,0 +1,29 @@
+package kinematics
+
+import (
+	e "errors"
+)
+
+// ErrFrameNotFound expresses that a named coordinate frame does not exist.
+var ErrFrameNotFound = e.New("coordinate frame not found")
+
+// ErrFrameExists expresses that a coordinate frame with the same name already exists.
+var ErrFrameExists = e.New("coordinate frame already exists")
+
+// ErrFramesNotConnected expresses that two coordinate frames do not share a common ancestor.
+var ErrFramesNotConnected = e.New("coordinate frames are not connected")
+
+// ErrFrameCycle expresses that attaching a coordinate frame to a parent would create a cycle.
+var ErrFrameCycle = e.New("coordinate frame would create a cycle")
+
+// ErrFrameParentMismatch expresses that a coordinate frame is already attached to a different parent.
+var ErrFrameParentMismatch = e.New("coordinate frame has a different parent")
+
+// ErrExtrapolation expresses that a requested time lies outside of the available samples.
+var ErrExtrapolation = e.New("requested time requires extrapolation")
+
+// ErrStaticFrame expresses that a coordinate frame cannot mix static and time-stamped poses.
+var ErrStaticFrame = e.New("coordinate frame cannot mix static and time-stamped poses")
+
+// ErrJointCount expresses that the number of joint values does not match the number of joints.
+var ErrJointCount = e.New("number of joint values does not match the number of joints")
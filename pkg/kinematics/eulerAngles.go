@@ -0,0 +1,202 @@
+package kinematics
+
+import (
+	"math"
+
+	"github.com/tab58/v1/spatial/pkg/numeric"
+)
+
+// EulerSequence is the order of the axes about which the three rotations of a set of Euler angles are applied.
+type EulerSequence int
+
+// The 6 Tait-Bryan sequences, which rotate about three distinct axes, and the 6 proper Euler sequences,
+// which rotate about the same axis first and last.
+const (
+	EulerXYZ EulerSequence = iota
+	EulerXZY
+	EulerYXZ
+	EulerYZX
+	EulerZXY
+	EulerZYX
+	EulerXYX
+	EulerXZX
+	EulerYXY
+	EulerYZY
+	EulerZXZ
+	EulerZYZ
+)
+
+// EulerFrame specifies whether Euler angle rotations are about the axes of the rotating body or of the fixed frame.
+type EulerFrame int
+
+const (
+	// Intrinsic rotations are applied about the axes of the rotated body, each rotation moving the axes of the next.
+	Intrinsic EulerFrame = iota
+	// Extrinsic rotations are applied about the axes of the fixed reference frame.
+	Extrinsic
+)
+
+// axes returns the indices (x = 0, y = 1, z = 2) of the rotation axes in the sequence.
+func (s EulerSequence) axes() ([3]int, error) {
+	switch s {
+	case EulerXYZ:
+		return [3]int{0, 1, 2}, nil
+	case EulerXZY:
+		return [3]int{0, 2, 1}, nil
+	case EulerYXZ:
+		return [3]int{1, 0, 2}, nil
+	case EulerYZX:
+		return [3]int{1, 2, 0}, nil
+	case EulerZXY:
+		return [3]int{2, 0, 1}, nil
+	case EulerZYX:
+		return [3]int{2, 1, 0}, nil
+	case EulerXYX:
+		return [3]int{0, 1, 0}, nil
+	case EulerXZX:
+		return [3]int{0, 2, 0}, nil
+	case EulerYXY:
+		return [3]int{1, 0, 1}, nil
+	case EulerYZY:
+		return [3]int{1, 2, 1}, nil
+	case EulerZXZ:
+		return [3]int{2, 0, 2}, nil
+	case EulerZYZ:
+		return [3]int{2, 1, 2}, nil
+	}
+	return [3]int{}, numeric.ErrInvalidArgument
+}
+
+// intrinsicAxes returns the sequence axes as an intrinsic sequence. An extrinsic sequence is equivalent to the
+// intrinsic sequence with the axes and the angles in reverse order.
+func intrinsicAxes(seq EulerSequence, frame EulerFrame) ([3]int, error) {
+	ax, err := seq.axes()
+	if err != nil {
+		return ax, err
+	}
+	switch frame {
+	case Intrinsic:
+		return ax, nil
+	case Extrinsic:
+		return [3]int{ax[2], ax[1], ax[0]}, nil
+	}
+	return ax, numeric.ErrInvalidArgument
+}
+
+// axisRotation returns the row-major elements of the rotation about the given coordinate axis.
+func axisRotation(axis int, angle float64) [9]float64 {
+	c, s := math.Cos(angle), math.Sin(angle)
+	switch axis {
+	case 0:
+		return [9]float64{1, 0, 0, 0, c, -s, 0, s, c}
+	case 1:
+		return [9]float64{c, 0, s, 0, 1, 0, -s, 0, c}
+	default:
+		return [9]float64{c, -s, 0, s, c, 0, 0, 0, 1}
+	}
+}
+
+// multiplyRotations returns the row-major product a * b.
+func multiplyRotations(a, b [9]float64) [9]float64 {
+	out := [9]float64{}
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			out[3*i+j] = a[3*i]*b[j] + a[3*i+1]*b[3+j] + a[3*i+2]*b[6+j]
+		}
+	}
+	return out
+}
+
+// Set3DEulerRotation sets the matrix to the rotation given by the Euler angles, where angle1, angle2 and angle3
+// are applied about the first, second and third axes of the sequence.
+func (m *Transform3D) Set3DEulerRotation(seq EulerSequence, frame EulerFrame, angle1, angle2, angle3 float64) error {
+	ax, err := seq.axes()
+	if err != nil {
+		return err
+	}
+
+	r1 := axisRotation(ax[0], angle1)
+	r2 := axisRotation(ax[1], angle2)
+	r3 := axisRotation(ax[2], angle3)
+
+	var e [9]float64
+	switch frame {
+	case Intrinsic:
+		e = multiplyRotations(multiplyRotations(r1, r2), r3)
+	case Extrinsic:
+		e = multiplyRotations(multiplyRotations(r3, r2), r1)
+	default:
+		return numeric.ErrInvalidArgument
+	}
+	return m.Matrix3D.SetElements(e[0], e[1], e[2], e[3], e[4], e[5], e[6], e[7], e[8])
+}
+
+// Get3DEulerAngles extracts the Euler angles of the rotation matrix for the given sequence, so that
+// Set3DEulerRotation with the same sequence and frame recovers the matrix. The middle angle is in [-pi/2, pi/2]
+// for Tait-Bryan sequences and [0, pi] for proper Euler sequences; the others are in [-pi, pi].
+//
+// If the rotation is within the tolerance of gimbal lock, where the cosine of the middle angle for Tait-Bryan sequences
+// or its sine for proper Euler sequences is zero at unit scale, the first and third angles are not unique. In that case
+// numeric.ErrGimbalLock is returned along with a valid set of angles where the angle of the last applied rotation
+// is zero (the third angle for intrinsic sequences, the first for extrinsic ones).
+func (m *Transform3D) Get3DEulerAngles(seq EulerSequence, frame EulerFrame, tol numeric.Tolerance) (float64, float64, float64, error) {
+	if tol.IsInvalid() {
+		return 0, 0, 0, numeric.ErrInvalidTol
+	}
+	ax, err := intrinsicAxes(seq, frame)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	e := m.Matrix3D.Elements()
+	r := func(row, col int) float64 { return e[3*row+col] }
+
+	i, j := ax[0], ax[1]
+	// sign of the permutation; even when j follows i cyclically
+	s := 1.0
+	if (j-i+3)%3 != 1 {
+		s = -1.0
+	}
+
+	var a, b, c float64
+	isLocked := false
+	if ax[2] != i {
+		// Tait-Bryan: R = R_i(a) * R_j(b) * R_k(c)
+		k := ax[2]
+		cb := math.Hypot(r(i, i), r(i, j))
+		b = math.Atan2(s*r(i, k), cb)
+		if !tol.IsEqualAtScale(cb, 0, 1) {
+			a = math.Atan2(-s*r(j, k), r(k, k))
+			c = math.Atan2(-s*r(i, j), r(i, i))
+		} else {
+			isLocked = true
+		}
+	} else {
+		// proper Euler: R = R_i(a) * R_j(b) * R_i(c)
+		k := 3 - i - j
+		sb := math.Hypot(r(i, j), r(i, k))
+		b = math.Atan2(sb, r(i, i))
+		if !tol.IsEqualAtScale(sb, 0, 1) {
+			a = math.Atan2(r(j, i), -s*r(k, i))
+			c = math.Atan2(r(i, j), s*r(i, k))
+		} else {
+			isLocked = true
+		}
+	}
+
+	if isLocked {
+		// with the last angle fixed to zero, R * R_j(-b) = R_i(a)
+		p := multiplyRotations(e, axisRotation(j, -b))
+		i1, i2 := (i+1)%3, (i+2)%3
+		a = math.Atan2(p[3*i2+i1], p[3*i1+i1])
+		c = 0
+	}
+
+	if frame == Extrinsic {
+		a, c = c, a
+	}
+	if isLocked {
+		return a, b, c, numeric.ErrGimbalLock
+	}
+	return a, b, c, nil
+}
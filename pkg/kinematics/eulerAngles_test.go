@@ -0,0 +1,122 @@
+package kinematics
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/tab58/v1/spatial/pkg/geometry"
+	"github.com/tab58/v1/spatial/pkg/numeric"
+)
+
+var eulerSequences = []EulerSequence{
+	EulerXYZ, EulerXZY, EulerYXZ, EulerYZX, EulerZXY, EulerZYX,
+	EulerXYX, EulerXZX, EulerYXY, EulerYZY, EulerZXZ, EulerZYZ,
+}
+
+// eulerMatrix composes the rotations about the axes of the sequence one at a time: intrinsic rotations multiply on
+// the right in sequence order, extrinsic ones on the left.
+func eulerMatrix(seq EulerSequence, frame EulerFrame, angles [3]float64) [9]float64 {
+	ax, err := seq.axes()
+	if err != nil {
+		panic(err)
+	}
+	r := [9]float64{1, 0, 0, 0, 1, 0, 0, 0, 1}
+	for n := 0; n < 3; n++ {
+		if frame == Intrinsic {
+			r = multiplyRotations(r, axisRotation(ax[n], angles[n]))
+		} else {
+			r = multiplyRotations(axisRotation(ax[n], angles[n]), r)
+		}
+	}
+	return r
+}
+
+func TestEulerAnglesRoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, seq := range eulerSequences {
+		ax, _ := seq.axes()
+		for _, frame := range []EulerFrame{Intrinsic, Extrinsic} {
+			for i := 0; i < 200; i++ {
+				// keep the middle angle inside its range and away from gimbal lock
+				angles := [3]float64{(2*r.Float64() - 1) * math.Pi, 3 * (r.Float64() - 0.5), (2*r.Float64() - 1) * math.Pi}
+				if ax[0] == ax[2] {
+					angles[1] = 0.1 + 2.9*r.Float64()
+				}
+
+				m := &Transform3D{Matrix3D: &geometry.Matrix3D{}}
+				if err := m.Set3DEulerRotation(seq, frame, angles[0], angles[1], angles[2]); err != nil {
+					t.Fatal(err)
+				}
+				want := eulerMatrix(seq, frame, angles)
+				got := m.Elements()
+				if !closeSlices(got[:], want[:], 1e-14) {
+					t.Fatalf("Set3DEulerRotation(%v, %v, %v) = %v, want %v", seq, frame, angles, got, want)
+				}
+
+				a, b, c, err := m.Get3DEulerAngles(seq, frame, numeric.AbsoluteTolerance(1e-12))
+				if err != nil {
+					t.Fatalf("Get3DEulerAngles(%v, %v) of %v: %v", seq, frame, angles, err)
+				}
+				if !closeSlices([]float64{a, b, c}, angles[:], 1e-12) {
+					t.Fatalf("Get3DEulerAngles(%v, %v) = %v, want %v", seq, frame, []float64{a, b, c}, angles)
+				}
+			}
+		}
+	}
+}
+
+func TestEulerAnglesGimbalLock(t *testing.T) {
+	for _, seq := range eulerSequences {
+		ax, _ := seq.axes()
+		// Tait-Bryan sequences lock when the middle angle is pi/2, proper Euler sequences when it is 0
+		middle := math.Pi / 2
+		if ax[0] == ax[2] {
+			middle = 0
+		}
+		for _, frame := range []EulerFrame{Intrinsic, Extrinsic} {
+			m := &Transform3D{Matrix3D: &geometry.Matrix3D{}}
+			if err := m.Set3DEulerRotation(seq, frame, 0.7, middle+1e-9, -0.4); err != nil {
+				t.Fatal(err)
+			}
+			want := m.Elements()
+
+			a, b, c, err := m.Get3DEulerAngles(seq, frame, numeric.AbsoluteTolerance(1e-6))
+			if err != numeric.ErrGimbalLock {
+				t.Fatalf("Get3DEulerAngles(%v, %v) near lock: got %v, want %v", seq, frame, err, numeric.ErrGimbalLock)
+			}
+			// the angle of the last applied rotation is zero and the angles still reproduce the rotation
+			last := c
+			if frame == Extrinsic {
+				last = a
+			}
+			if last != 0 {
+				t.Errorf("Get3DEulerAngles(%v, %v): last applied angle is %v, want 0", seq, frame, last)
+			}
+			got := eulerMatrix(seq, frame, [3]float64{a, b, c})
+			if !closeSlices(got[:], want[:], 1e-8) {
+				t.Errorf("Get3DEulerAngles(%v, %v) = %v at lock, which gives %v, want %v", seq, frame,
+					[]float64{a, b, c}, got, want)
+			}
+
+			// with an exact tolerance the same rotation is not at lock
+			if _, _, _, err := m.Get3DEulerAngles(seq, frame, numeric.Tolerance{}); err != nil {
+				t.Errorf("Get3DEulerAngles(%v, %v) with an exact tolerance: %v", seq, frame, err)
+			}
+		}
+	}
+}
+
+func TestEulerAnglesErrors(t *testing.T) {
+	m := &Transform3D{Matrix3D: &geometry.Matrix3D{}}
+	m.Identity()
+	if err := m.Set3DEulerRotation(EulerSequence(12), Intrinsic, 0, 0, 0); err != numeric.ErrInvalidArgument {
+		t.Errorf("Set3DEulerRotation with an invalid sequence: got %v, want %v", err, numeric.ErrInvalidArgument)
+	}
+	if _, _, _, err := m.Get3DEulerAngles(EulerXYZ, EulerFrame(2), numeric.Tolerance{}); err != numeric.ErrInvalidArgument {
+		t.Errorf("Get3DEulerAngles with an invalid frame: got %v, want %v", err, numeric.ErrInvalidArgument)
+	}
+	if _, _, _, err := m.Get3DEulerAngles(EulerXYZ, Intrinsic, numeric.AbsoluteTolerance(-1)); err != numeric.ErrInvalidTol {
+		t.Errorf("Get3DEulerAngles with a negative tolerance: got %v, want %v", err, numeric.ErrInvalidTol)
+	}
+}
@@ -0,0 +1,275 @@
+package kinematics
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/tab58/v1/spatial/pkg/geometry"
+	"github.com/tab58/v1/spatial/pkg/numeric"
+)
+
+// poseSample is a time-stamped pose of a frame relative to its parent.
+type poseSample struct {
+	stamp       time.Time
+	rotation    geometry.Quaternion
+	translation geometry.Vector3D
+}
+
+// frameHistory holds the time-ordered pose samples of a frame relative to its parent.
+type frameHistory struct {
+	parent   string
+	isStatic bool
+	samples  []poseSample
+}
+
+// FrameBuffer stores time-stamped poses of named frames relative to their parents and answers transform
+// queries between frames at arbitrary times by interpolating between samples. It is safe for concurrent use.
+type FrameBuffer struct {
+	mu      sync.RWMutex
+	history time.Duration
+	frames  map[string]*frameHistory
+}
+
+// NewFrameBuffer creates a frame buffer that keeps samples up to the given duration older than the newest sample of each frame.
+func NewFrameBuffer(history time.Duration) (*FrameBuffer, error) {
+	if history < 0 {
+		return nil, numeric.ErrInvalidArgument
+	}
+	return &FrameBuffer{
+		history: history,
+		frames:  make(map[string]*frameHistory),
+	}, nil
+}
+
+// attach finds or creates the history of the frame, checking that it keeps its parent and does not create a cycle.
+func (b *FrameBuffer) attach(frame, parent string) (*frameHistory, error) {
+	if frame == "" || parent == "" || frame == parent {
+		return nil, numeric.ErrInvalidArgument
+	}
+
+	h, ok := b.frames[frame]
+	if ok {
+		if h.parent != parent {
+			return nil, ErrFrameParentMismatch
+		}
+		return h, nil
+	}
+
+	for current := parent; current != ""; {
+		if current == frame {
+			return nil, ErrFrameCycle
+		}
+		ph, ok := b.frames[current]
+		if !ok {
+			break
+		}
+		current = ph.parent
+	}
+
+	h = &frameHistory{parent: parent}
+	b.frames[frame] = h
+	return h, nil
+}
+
+// SetTransform records the pose of the frame relative to its parent at the given time. The pose maps coordinates
+// in the frame to coordinates in the parent. Samples older than the buffer's history are discarded.
+func (b *FrameBuffer) SetTransform(frame, parent string, stamp time.Time, t *RigidTransform3D) error {
+	q, err := t.Quaternion()
+	if err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	h, err := b.attach(frame, parent)
+	if err != nil {
+		return err
+	}
+	if h.isStatic {
+		return ErrStaticFrame
+	}
+
+	s := poseSample{stamp: stamp, rotation: *q, translation: *t.Translation()}
+	i := sort.Search(len(h.samples), func(i int) bool { return !h.samples[i].stamp.Before(stamp) })
+	if i < len(h.samples) && h.samples[i].stamp.Equal(stamp) {
+		h.samples[i] = s
+	} else {
+		h.samples = append(h.samples, poseSample{})
+		copy(h.samples[i+1:], h.samples[i:])
+		h.samples[i] = s
+	}
+
+	// drop samples that have aged out of the history window
+	oldest := h.samples[len(h.samples)-1].stamp.Add(-b.history)
+	j := sort.Search(len(h.samples), func(i int) bool { return !h.samples[i].stamp.Before(oldest) })
+	h.samples = h.samples[j:]
+	return nil
+}
+
+// SetStaticTransform records a pose of the frame relative to its parent that is valid at all times.
+func (b *FrameBuffer) SetStaticTransform(frame, parent string, t *RigidTransform3D) error {
+	q, err := t.Quaternion()
+	if err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	h, err := b.attach(frame, parent)
+	if err != nil {
+		return err
+	}
+	if !h.isStatic && len(h.samples) > 0 {
+		return ErrStaticFrame
+	}
+
+	h.isStatic = true
+	h.samples = []poseSample{{rotation: *q, translation: *t.Translation()}}
+	return nil
+}
+
+// localTransformAt interpolates the pose of the frame relative to its parent at the given time.
+func (h *frameHistory) localTransformAt(at time.Time) (*RigidTransform3D, error) {
+	n := len(h.samples)
+	if n == 0 {
+		return nil, ErrExtrapolation
+	}
+	if h.isStatic {
+		s := h.samples[0]
+		return NewRigidTransform3DFromQuaternion(&s.rotation, &s.translation)
+	}
+
+	if at.Before(h.samples[0].stamp) || at.After(h.samples[n-1].stamp) {
+		return nil, ErrExtrapolation
+	}
+	i := sort.Search(n, func(i int) bool { return !h.samples[i].stamp.Before(at) })
+	s1 := h.samples[i]
+	if s1.stamp.Equal(at) {
+		return NewRigidTransform3DFromQuaternion(&s1.rotation, &s1.translation)
+	}
+	s0 := h.samples[i-1]
+
+	// SLERP the rotation and linearly interpolate the translation
+	u := float64(at.Sub(s0.stamp)) / float64(s1.stamp.Sub(s0.stamp))
+	q := s0.rotation.Clone()
+	err := q.Slerp(&s1.rotation, u)
+	if err != nil {
+		return nil, err
+	}
+	p := s0.translation.Clone()
+	err = p.Lerp(&s1.translation, u)
+	if err != nil {
+		return nil, err
+	}
+	return NewRigidTransform3DFromQuaternion(q, p)
+}
+
+// chain returns the frame names from the given frame up to its root, inclusive.
+func (b *FrameBuffer) chain(frame string) []string {
+	names := []string{frame}
+	for h, ok := b.frames[frame]; ok; h, ok = b.frames[h.parent] {
+		names = append(names, h.parent)
+	}
+	return names
+}
+
+// transformToAncestor composes the interpolated local transforms along the chain up to, but not including, the ancestor at index end.
+func (b *FrameBuffer) transformToAncestor(chain []string, end int, at time.Time) (*RigidTransform3D, error) {
+	t := &RigidTransform3D{}
+	t.Identity()
+
+	for _, name := range chain[:end] {
+		local, err := b.frames[name].localTransformAt(at)
+		if err != nil {
+			return nil, err
+		}
+		err = local.Compose(t)
+		if err != nil {
+			return nil, err
+		}
+		t = local
+	}
+	return t, nil
+}
+
+// LookupTransform returns the transform that maps coordinates in the source frame to coordinates in the target frame at the given time.
+func (b *FrameBuffer) LookupTransform(source, target string, at time.Time) (*RigidTransform3D, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if !b.hasFrame(source) || !b.hasFrame(target) {
+		return nil, ErrFrameNotFound
+	}
+
+	sc := b.chain(source)
+	tc := b.chain(target)
+
+	// walk both chains down from their roots to find the lowest common ancestor
+	i, j := len(sc)-1, len(tc)-1
+	if sc[i] != tc[j] {
+		return nil, ErrFramesNotConnected
+	}
+	for i > 0 && j > 0 && sc[i-1] == tc[j-1] {
+		i--
+		j--
+	}
+
+	// T(target <- source) = T(lca <- target)^-1 * T(lca <- source)
+	fromSource, err := b.transformToAncestor(sc, i, at)
+	if err != nil {
+		return nil, err
+	}
+	fromTarget, err := b.transformToAncestor(tc, j, at)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := fromTarget.Inverse()
+	if err != nil {
+		return nil, err
+	}
+	err = t.Compose(fromSource)
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// hasFrame returns true if the frame has samples or is the parent of a frame that does.
+func (b *FrameBuffer) hasFrame(name string) bool {
+	if _, ok := b.frames[name]; ok {
+		return true
+	}
+	for _, h := range b.frames {
+		if h.parent == name {
+			return true
+		}
+	}
+	return false
+}
+
+// HasFrame returns true if the frame is known to the buffer, false if not.
+func (b *FrameBuffer) HasFrame(name string) bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.hasFrame(name)
+}
+
+// TimeRange returns the earliest and latest sample times of the frame's pose relative to its parent.
+// Zero times are returned for static frames.
+func (b *FrameBuffer) TimeRange(frame string) (time.Time, time.Time, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	h, ok := b.frames[frame]
+	if !ok {
+		return time.Time{}, time.Time{}, ErrFrameNotFound
+	}
+	if h.isStatic || len(h.samples) == 0 {
+		return time.Time{}, time.Time{}, nil
+	}
+	return h.samples[0].stamp, h.samples[len(h.samples)-1].stamp, nil
+}
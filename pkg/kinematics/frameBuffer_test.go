@@ -0,0 +1,147 @@
+package kinematics
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/tab58/v1/spatial/pkg/geometry"
+)
+
+// zRotationTransform returns the transform that rotates about the z-axis by the angle and then translates.
+func zRotationTransform(angle, x, y, z float64) *RigidTransform3D {
+	q := &geometry.Quaternion{}
+	if err := q.SetFromAxisAngle(&geometry.Vector3D{Z: 1}, angle); err != nil {
+		panic(err)
+	}
+	t, err := NewRigidTransform3DFromQuaternion(q, &geometry.Vector3D{X: x, Y: y, Z: z})
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestFrameBufferInterpolation(t *testing.T) {
+	b, err := NewFrameBuffer(10 * time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t0 := time.Unix(100, 0)
+	if err := b.SetTransform("arm", "base", t0.Add(2*time.Second), zRotationTransform(math.Pi/2, 2, 0, 0)); err != nil {
+		t.Fatal(err)
+	}
+	// samples may arrive out of order
+	if err := b.SetTransform("arm", "base", t0, zRotationTransform(0, 0, 0, 0)); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.SetStaticTransform("sensor", "arm", zRotationTransform(0, 0, 1, 0)); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		at     time.Duration
+		source string
+		target string
+		want   *RigidTransform3D
+	}{
+		{"first sample", 0, "arm", "base", zRotationTransform(0, 0, 0, 0)},
+		{"last sample", 2 * time.Second, "arm", "base", zRotationTransform(math.Pi/2, 2, 0, 0)},
+		{"midpoint", time.Second, "arm", "base", zRotationTransform(math.Pi/4, 1, 0, 0)},
+		{"quarter", 500 * time.Millisecond, "arm", "base", zRotationTransform(math.Pi/8, 0.5, 0, 0)},
+		{"static child", time.Second, "sensor", "base", zRotationTransform(math.Pi/4, 1-math.Sqrt(0.5), math.Sqrt(0.5), 0)},
+		{"inverse", time.Second, "base", "sensor", zRotationTransform(-math.Pi/4, -math.Sqrt(0.5), math.Sqrt(0.5)-1, 0)},
+	}
+	for _, tt := range tests {
+		got, err := b.LookupTransform(tt.source, tt.target, t0.Add(tt.at))
+		if err != nil {
+			t.Fatalf("LookupTransform %s: %v", tt.name, err)
+		}
+		if !closeSlices(rigidTransformSlice(got), rigidTransformSlice(tt.want), 1e-14) {
+			t.Errorf("LookupTransform %s = %v, want %v", tt.name, rigidTransformSlice(got), rigidTransformSlice(tt.want))
+		}
+	}
+
+	for _, at := range []time.Duration{-time.Nanosecond, 2*time.Second + time.Nanosecond} {
+		if _, err := b.LookupTransform("sensor", "base", t0.Add(at)); err != ErrExtrapolation {
+			t.Errorf("LookupTransform at %v: got %v, want %v", at, err, ErrExtrapolation)
+		}
+	}
+	// a static frame alone never extrapolates
+	if _, err := b.LookupTransform("sensor", "arm", time.Time{}); err != nil {
+		t.Errorf("LookupTransform of a static frame: %v", err)
+	}
+}
+
+func TestFrameBufferHistory(t *testing.T) {
+	b, err := NewFrameBuffer(time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t0 := time.Unix(100, 0)
+	for i := 0; i < 5; i++ {
+		stamp := t0.Add(time.Duration(i) * 500 * time.Millisecond)
+		if err := b.SetTransform("arm", "base", stamp, zRotationTransform(0, float64(i), 0, 0)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	first, last, err := b.TimeRange("arm")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := t0.Add(time.Second); !first.Equal(want) {
+		t.Errorf("TimeRange start = %v, want %v", first, want)
+	}
+	if want := t0.Add(2 * time.Second); !last.Equal(want) {
+		t.Errorf("TimeRange end = %v, want %v", last, want)
+	}
+	if _, err := b.LookupTransform("arm", "base", t0.Add(500*time.Millisecond)); err != ErrExtrapolation {
+		t.Errorf("LookupTransform of a discarded sample: got %v, want %v", err, ErrExtrapolation)
+	}
+}
+
+func TestFrameBufferErrors(t *testing.T) {
+	if _, err := NewFrameBuffer(-time.Second); err == nil {
+		t.Error("NewFrameBuffer with a negative history: got nil error")
+	}
+	b, err := NewFrameBuffer(time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t0 := time.Unix(100, 0)
+	identity := zRotationTransform(0, 0, 0, 0)
+	if err := b.SetTransform("arm", "base", t0, identity); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.SetStaticTransform("tool", "arm", identity); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.SetStaticTransform("camera", "mount", identity); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"static over time-stamped", b.SetStaticTransform("arm", "base", identity), ErrStaticFrame},
+		{"time-stamped over static", b.SetTransform("tool", "arm", t0, identity), ErrStaticFrame},
+		{"different parent", b.SetTransform("arm", "world", t0, identity), ErrFrameParentMismatch},
+		{"cycle", b.SetTransform("base", "tool", t0, identity), ErrFrameCycle},
+	}
+	for _, tt := range tests {
+		if tt.err != tt.want {
+			t.Errorf("%s: got %v, want %v", tt.name, tt.err, tt.want)
+		}
+	}
+	if _, err := b.LookupTransform("tool", "missing", t0); err != ErrFrameNotFound {
+		t.Errorf("LookupTransform to a missing frame: got %v, want %v", err, ErrFrameNotFound)
+	}
+	if _, err := b.LookupTransform("tool", "camera", t0); err != ErrFramesNotConnected {
+		t.Errorf("LookupTransform between separate trees: got %v, want %v", err, ErrFramesNotConnected)
+	}
+	if !b.HasFrame("base") || !b.HasFrame("mount") || b.HasFrame("missing") {
+		t.Error("HasFrame does not report parents and children")
+	}
+}
@@ -0,0 +1,88 @@
+package kinematics
+
+import (
+	"github.com/tab58/v1/spatial/pkg/geometry"
+)
+
+// FrameTree is a collection of named coordinate systems arranged in parent-child hierarchies.
+// It is not safe for concurrent modification.
+type FrameTree struct {
+	frames map[string]*CoordinateSystem
+}
+
+// NewFrameTree creates an empty frame tree.
+func NewFrameTree() *FrameTree {
+	return &FrameTree{
+		frames: make(map[string]*CoordinateSystem),
+	}
+}
+
+// AddFrame creates a named coordinate system whose origin and basis vectors are expressed in the named parent frame.
+// An empty parent name creates a root frame.
+func (f *FrameTree) AddFrame(name, parent string, origin geometry.Point3DReader, b0, b1 geometry.Vector3DReader) (*CoordinateSystem, error) {
+	if _, ok := f.frames[name]; ok {
+		return nil, ErrFrameExists
+	}
+
+	var p *CoordinateSystem
+	if parent != "" {
+		var err error
+		p, err = f.Frame(parent)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	c, err := NewCoordinateSystem(name, p, origin, b0, b1)
+	if err != nil {
+		return nil, err
+	}
+	f.frames[name] = c
+	return c, nil
+}
+
+// Frame returns the coordinate system with the given name.
+func (f *FrameTree) Frame(name string) (*CoordinateSystem, error) {
+	c, ok := f.frames[name]
+	if !ok {
+		return nil, ErrFrameNotFound
+	}
+	return c, nil
+}
+
+// HasFrame returns true if a frame with the given name exists, false if not.
+func (f *FrameTree) HasFrame(name string) bool {
+	_, ok := f.frames[name]
+	return ok
+}
+
+// GetTransform returns the transform that maps coordinates in the source frame to coordinates in the target frame.
+func (f *FrameTree) GetTransform(source, target string) (*RigidTransform3D, error) {
+	s, err := f.Frame(source)
+	if err != nil {
+		return nil, err
+	}
+	t, err := f.Frame(target)
+	if err != nil {
+		return nil, err
+	}
+	return s.GetTransformTo(t)
+}
+
+// TransformPoint expresses a point given in the source frame in the target frame.
+func (f *FrameTree) TransformPoint(p geometry.Point3DReader, source, target string) (*geometry.Point3D, error) {
+	t, err := f.GetTransform(source, target)
+	if err != nil {
+		return nil, err
+	}
+	return t.ApplyToPoint(p)
+}
+
+// TransformVector expresses a vector given in the source frame in the target frame.
+func (f *FrameTree) TransformVector(v geometry.Vector3DReader, source, target string) (*geometry.Vector3D, error) {
+	t, err := f.GetTransform(source, target)
+	if err != nil {
+		return nil, err
+	}
+	return t.ApplyToVector(v)
+}
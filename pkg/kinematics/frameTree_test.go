@@ -0,0 +1,173 @@
+package kinematics
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/tab58/v1/spatial/pkg/geometry"
+)
+
+// frameSpec describes a frame by its parent and its orthonormal, right-handed basis expressed in the parent.
+type frameSpec struct {
+	name, parent string
+	origin       [3]float64
+	b0, b1       [3]float64
+}
+
+// frameSpecs is a tree rooted at world with a branch through a and b and another through c, plus a separate root.
+var frameSpecs = []frameSpec{
+	{"world", "", [3]float64{0, 0, 0}, [3]float64{1, 0, 0}, [3]float64{0, 1, 0}},
+	{"a", "world", [3]float64{1, 0, 0}, [3]float64{0, 1, 0}, [3]float64{-1, 0, 0}},
+	{"b", "a", [3]float64{0, 2, 0}, [3]float64{0, 0, 1}, [3]float64{1, 0, 0}},
+	{"c", "world", [3]float64{0, 0, 5}, [3]float64{0, math.Sqrt(0.5), math.Sqrt(0.5)}, [3]float64{0, -math.Sqrt(0.5), math.Sqrt(0.5)}},
+	{"other", "", [3]float64{3, 3, 3}, [3]float64{1, 0, 0}, [3]float64{0, 1, 0}},
+}
+
+func newTestFrameTree(t *testing.T) *FrameTree {
+	f := NewFrameTree()
+	for _, s := range frameSpecs {
+		o := &geometry.Point3D{X: s.origin[0], Y: s.origin[1], Z: s.origin[2]}
+		b0 := &geometry.Vector3D{X: s.b0[0], Y: s.b0[1], Z: s.b0[2]}
+		b1 := &geometry.Vector3D{X: s.b1[0], Y: s.b1[1], Z: s.b1[2]}
+		if _, err := f.AddFrame(s.name, s.parent, o, b0, b1); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return f
+}
+
+// specBasis returns the origin and the three basis vectors of the named frame spec.
+func specBasis(name string) (frameSpec, [3][3]float64) {
+	for _, s := range frameSpecs {
+		if s.name == name {
+			b0, b1 := s.b0, s.b1
+			b2 := [3]float64{b0[1]*b1[2] - b0[2]*b1[1], b0[2]*b1[0] - b0[0]*b1[2], b0[0]*b1[1] - b0[1]*b1[0]}
+			return s, [3][3]float64{b0, b1, b2}
+		}
+	}
+	panic("unknown frame " + name)
+}
+
+// toRoot expresses a point given in the named frame in the root of its tree by walking up the specs.
+func toRoot(name string, p [3]float64) [3]float64 {
+	for name != "" {
+		s, b := specBasis(name)
+		var q [3]float64
+		for k := 0; k < 3; k++ {
+			q[k] = s.origin[k] + p[0]*b[0][k] + p[1]*b[1][k] + p[2]*b[2][k]
+		}
+		p, name = q, s.parent
+	}
+	return p
+}
+
+// fromRoot expresses a point given in the root of the tree in the named frame.
+func fromRoot(name string, p [3]float64) [3]float64 {
+	s, b := specBasis(name)
+	if s.parent != "" {
+		p = fromRoot(s.parent, p)
+	}
+	var q [3]float64
+	for k := 0; k < 3; k++ {
+		q[k] = b[k][0]*(p[0]-s.origin[0]) + b[k][1]*(p[1]-s.origin[1]) + b[k][2]*(p[2]-s.origin[2])
+	}
+	return q
+}
+
+func TestFrameTreeTransformPoint(t *testing.T) {
+	f := newTestFrameTree(t)
+	r := rand.New(rand.NewSource(1))
+	names := []string{"world", "a", "b", "c"}
+	for _, source := range names {
+		for _, target := range names {
+			for i := 0; i < 20; i++ {
+				p := [3]float64{r.NormFloat64(), r.NormFloat64(), r.NormFloat64()}
+				want := fromRoot(target, toRoot(source, p))
+
+				got, err := f.TransformPoint(&geometry.Point3D{X: p[0], Y: p[1], Z: p[2]}, source, target)
+				if err != nil {
+					t.Fatalf("TransformPoint(%s, %s): %v", source, target, err)
+				}
+				if !closeSlices([]float64{got.X, got.Y, got.Z}, want[:], 1e-14) {
+					t.Fatalf("TransformPoint(%v, %s, %s) = %v, want %v", p, source, target, got, want)
+				}
+
+				// vectors are differences of points, so the origins cancel
+				o := fromRoot(target, toRoot(source, [3]float64{}))
+				v, err := f.TransformVector(&geometry.Vector3D{X: p[0], Y: p[1], Z: p[2]}, source, target)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !closeSlices([]float64{v.X, v.Y, v.Z}, []float64{want[0] - o[0], want[1] - o[1], want[2] - o[2]}, 1e-14) {
+					t.Fatalf("TransformVector(%v, %s, %s) = %v, want %v", p, source, target, v, want)
+				}
+			}
+		}
+	}
+}
+
+func TestFrameTreeGetTransformInverse(t *testing.T) {
+	f := newTestFrameTree(t)
+	identity := []float64{1, 0, 0, 0, 1, 0, 0, 0, 1, 0, 0, 0}
+
+	// b and c only share world, so the transform passes through the common ancestor
+	bc, err := f.GetTransform("b", "c")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cb, err := f.GetTransform("c", "b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := bc.Compose(cb); err != nil {
+		t.Fatal(err)
+	}
+	if got := rigidTransformSlice(bc); !closeSlices(got, identity, 1e-14) {
+		t.Errorf("T(c <- b) * T(b <- c) = %v, want the identity", got)
+	}
+
+	// the global orientation is the rotation from the root into the frame
+	b, err := f.Frame("b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	orient, err := b.GetGlobalOrientation()
+	if err != nil {
+		t.Fatal(err)
+	}
+	global, err := b.GetGlobalTransform()
+	if err != nil {
+		t.Fatal(err)
+	}
+	orient.Transpose()
+	got, want := orient.Elements(), global.Rotation().Elements()
+	if !closeSlices(got[:], want[:], 1e-15) {
+		t.Errorf("GetGlobalOrientation^T = %v, want %v", got, want)
+	}
+}
+
+func TestFrameTreeErrors(t *testing.T) {
+	f := newTestFrameTree(t)
+	o := &geometry.Point3D{}
+	x, y := &geometry.Vector3D{X: 1}, &geometry.Vector3D{Y: 1}
+
+	if _, err := f.AddFrame("a", "world", o, x, y); err != ErrFrameExists {
+		t.Errorf("AddFrame with an existing name: got %v, want %v", err, ErrFrameExists)
+	}
+	if _, err := f.AddFrame("d", "missing", o, x, y); err != ErrFrameNotFound {
+		t.Errorf("AddFrame with a missing parent: got %v, want %v", err, ErrFrameNotFound)
+	}
+	if _, err := f.AddFrame("d", "world", o, x, &geometry.Vector3D{X: 2}); err == nil {
+		t.Error("AddFrame with parallel basis vectors: got nil error")
+	}
+	if f.HasFrame("d") {
+		t.Error("HasFrame(d) = true after failed additions")
+	}
+	if _, err := f.GetTransform("b", "missing"); err != ErrFrameNotFound {
+		t.Errorf("GetTransform to a missing frame: got %v, want %v", err, ErrFrameNotFound)
+	}
+	if _, err := f.GetTransform("b", "other"); err != ErrFramesNotConnected {
+		t.Errorf("GetTransform between separate trees: got %v, want %v", err, ErrFramesNotConnected)
+	}
+}
@@ -0,0 +1,26 @@
+package kinematics
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/tab58/v1/spatial/pkg/geometry"
+)
+
+// closeSlices returns true if the corresponding elements of a and b differ by at most tol, false if not.
+func closeSlices(a, b []float64, tol float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !(math.Abs(a[i]-b[i]) <= tol) {
+			return false
+		}
+	}
+	return true
+}
+
+// randomVector3D returns a vector with standard normal components.
+func randomVector3D(r *rand.Rand) *geometry.Vector3D {
+	return &geometry.Vector3D{X: r.NormFloat64(), Y: r.NormFloat64(), Z: r.NormFloat64()}
+}
@@ -1,6 +1,10 @@
 package kinematics
 
-import "github.com/tab58/v1/spatial/pkg/geometry"
+import (
+	"math"
+
+	"github.com/tab58/v1/spatial/pkg/geometry"
+)
 
 // HomogeneousTransform4D is a 4x4 matrix that encodes a transformation.
 type HomogeneousTransform4D struct {
@@ -10,7 +14,7 @@ type HomogeneousTransform4D struct {
 // Set3DTranslation sets the matrix to a translation encoded for homogeneous coordinates.
 func (m *HomogeneousTransform4D) Set3DTranslation(v geometry.Vector3DReader) error {
 	x, y, z := v.GetX(), v.GetY(), v.GetZ()
-	return m.Matrix4D.Adjoint().SetElements(1, 0, 0, x, 0, 1, 0, y, 0, 0, 1, z, 0, 0, 0, 1)
+	return m.Matrix4D.SetElements(1, 0, 0, x, 0, 1, 0, y, 0, 0, 1, z, 0, 0, 0, 1)
 }
 
 // Set3DRotation sets the matrix to a rotation encoded for homogeneous coordinates.
@@ -25,3 +29,29 @@ func (m *HomogeneousTransform4D) Set3DTranslationRotation(axis geometry.Vector3D
 	e := rotation3DFromAxisAngle(axis, angle)
 	return m.Matrix4D.SetElements(e[0], e[1], e[2], x, e[3], e[4], e[5], y, e[6], e[7], e[8], z, 0, 0, 0, 1)
 }
+
+// SetStandardDH sets the matrix to the link transform Rot_z(theta) * Trans_z(d) * Trans_x(a) * Rot_x(alpha)
+// given by standard Denavit-Hartenberg parameters.
+func (m *HomogeneousTransform4D) SetStandardDH(a, alpha, d, theta float64) error {
+	ct, st := math.Cos(theta), math.Sin(theta)
+	ca, sa := math.Cos(alpha), math.Sin(alpha)
+	return m.Matrix4D.SetElements(
+		ct, -st*ca, st*sa, a*ct,
+		st, ct*ca, -ct*sa, a*st,
+		0, sa, ca, d,
+		0, 0, 0, 1,
+	)
+}
+
+// SetModifiedDH sets the matrix to the link transform Rot_x(alpha) * Trans_x(a) * Rot_z(theta) * Trans_z(d)
+// given by modified (Craig) Denavit-Hartenberg parameters, where a and alpha belong to the previous link.
+func (m *HomogeneousTransform4D) SetModifiedDH(a, alpha, d, theta float64) error {
+	ct, st := math.Cos(theta), math.Sin(theta)
+	ca, sa := math.Cos(alpha), math.Sin(alpha)
+	return m.Matrix4D.SetElements(
+		ct, -st, 0, a,
+		st*ca, ct*ca, -sa, -sa*d,
+		st*sa, ct*sa, ca, ca*d,
+		0, 0, 0, 1,
+	)
+}
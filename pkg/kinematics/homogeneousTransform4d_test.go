@@ -0,0 +1,18 @@
+package kinematics
+
+import (
+	"testing"
+
+	"github.com/tab58/v1/spatial/pkg/geometry"
+)
+
+func TestHomogeneousTransform4DSet3DTranslation(t *testing.T) {
+	m := &HomogeneousTransform4D{Matrix4D: &geometry.Matrix4D{}}
+	if err := m.Set3DTranslation(&geometry.Vector3D{X: 1, Y: -2, Z: 3}); err != nil {
+		t.Fatal(err)
+	}
+	want := [16]float64{1, 0, 0, 1, 0, 1, 0, -2, 0, 0, 1, 3, 0, 0, 0, 1}
+	if got := m.Elements(); got != want {
+		t.Errorf("Set3DTranslation = %v, want %v", got, want)
+	}
+}
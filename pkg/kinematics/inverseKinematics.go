@@ -0,0 +1,372 @@
+package kinematics
+
+import (
+	"math"
+
+	"github.com/tab58/v1/spatial/pkg/geometry"
+	"github.com/tab58/v1/spatial/pkg/numeric"
+	"gonum.org/v1/gonum/mat"
+)
+
+// IKMethod is the iterative method used to solve inverse kinematics.
+type IKMethod int
+
+const (
+	// DampedLeastSquares steps by J^T * (J * J^T + lambda^2 * I)^-1 * e (Levenberg-Marquardt), which stays well behaved near singularities.
+	DampedLeastSquares IKMethod = iota
+	// JacobianTranspose steps by alpha * J^T * e, with alpha chosen to minimize the linearized error.
+	JacobianTranspose
+	// CyclicCoordinateDescent updates one joint at a time, from the end link to the base, in closed form.
+	CyclicCoordinateDescent
+)
+
+// JointLimit is the allowed range of a joint value.
+type JointLimit struct {
+	Min float64
+	Max float64
+}
+
+// IKOptions configures an inverse kinematics solve.
+type IKOptions struct {
+	Method        IKMethod
+	MaxIterations int
+	// PositionTol and OrientationTol bound the residual distance and angle and must be positive; OrientationTol is
+	// ignored when PositionOnly is set. They are absolute limits rather than numeric.Tolerance values because the
+	// residuals are compared against zero with no natural scale for a relative criterion. Each residual is divided by
+	// its own limit when choosing the best iterate, so metres and radians are never compared directly.
+	PositionTol    float64
+	OrientationTol float64
+	// Damping is the damping factor lambda of the damped least squares method.
+	Damping float64
+	// PositionOnly ignores the orientation of the target.
+	PositionOnly bool
+	// JointLimits is either empty or holds one limit per joint.
+	JointLimits []JointLimit
+}
+
+// DefaultIKOptions returns the default options: damped least squares to sub-micrometer and sub-microradian accuracy.
+func DefaultIKOptions() IKOptions {
+	return IKOptions{
+		Method:         DampedLeastSquares,
+		MaxIterations:  200,
+		PositionTol:    1e-7,
+		OrientationTol: 1e-7,
+		Damping:        1e-2,
+	}
+}
+
+// IKResult is the outcome of an inverse kinematics solve.
+type IKResult struct {
+	Joints []float64
+	// Iterations is the number of solver steps taken.
+	Iterations int
+	// PositionResidual is the distance from the end link origin to the target position.
+	PositionResidual float64
+	// OrientationResidual is the angle of the rotation from the end link orientation to the target orientation.
+	OrientationResidual float64
+	Converged           bool
+}
+
+// poseError computes the error from the end link pose to the target as (dp, w), where dp is the position error and
+// w is the axis-angle vector of the rotation from the end link orientation to the target, both in the base frame.
+func poseError(end, target *RigidTransform3D) (*geometry.Vector3D, *geometry.Vector3D, error) {
+	dp := target.Translation()
+	err := dp.Sub(end.Translation())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	q, err := end.Quaternion()
+	if err != nil {
+		return nil, nil, err
+	}
+	err = q.Invert()
+	if err != nil {
+		return nil, nil, err
+	}
+	qt, err := target.Quaternion()
+	if err != nil {
+		return nil, nil, err
+	}
+	err = q.Premultiply(qt)
+	if err != nil {
+		return nil, nil, err
+	}
+	if q.W < 0 {
+		q.Negate()
+	}
+	axis, angle, err := q.ToAxisAngle()
+	if err != nil {
+		return nil, nil, err
+	}
+	err = axis.Scale(angle)
+	if err != nil {
+		return nil, nil, err
+	}
+	return dp, axis, nil
+}
+
+// validate checks the options against the chain and the initial joint values.
+func (o *IKOptions) validate(n int, initial []float64) error {
+	if len(initial) != n {
+		return ErrJointCount
+	}
+	if len(o.JointLimits) != 0 && len(o.JointLimits) != n {
+		return ErrJointCount
+	}
+	// negated comparisons so that NaN limits are rejected
+	if !(o.PositionTol > 0) || (!o.PositionOnly && !(o.OrientationTol > 0)) {
+		return numeric.ErrInvalidTol
+	}
+	if o.MaxIterations < 0 || o.Damping < 0 || math.IsNaN(o.Damping) {
+		return numeric.ErrInvalidArgument
+	}
+	for _, l := range o.JointLimits {
+		if !(l.Min <= l.Max) {
+			return numeric.ErrInvalidArgument
+		}
+	}
+	switch o.Method {
+	case DampedLeastSquares, JacobianTranspose, CyclicCoordinateDescent:
+		return nil
+	}
+	return numeric.ErrInvalidArgument
+}
+
+// clamp restricts the joint values to the joint limits.
+func (o *IKOptions) clamp(q []float64) {
+	for i, l := range o.JointLimits {
+		q[i] = math.Max(l.Min, math.Min(l.Max, q[i]))
+	}
+}
+
+// endTransform computes the end link pose as a rigid transform.
+func (c *SerialChain) endTransform(q []float64) (*RigidTransform3D, error) {
+	end, err := c.EndEffectorTransform(q)
+	if err != nil {
+		return nil, err
+	}
+	t := &RigidTransform3D{}
+	err = t.SetFromHomogeneousTransform4D(end)
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// SolveIK iteratively searches for joint values that place the end link at the target pose, starting from the
+// initial joint values. If the solve does not converge within the iteration limit, the best joint values found
+// are returned along with numeric.ErrNotConverged.
+func (c *SerialChain) SolveIK(target *RigidTransform3D, initial []float64, opts IKOptions) (*IKResult, error) {
+	n := len(c.links)
+	err := opts.validate(n, initial)
+	if err != nil {
+		return nil, err
+	}
+
+	q := make([]float64, n)
+	copy(q, initial)
+	opts.clamp(q)
+
+	res := &IKResult{Joints: make([]float64, n)}
+	best := math.Inf(1)
+	for iter := 0; ; iter++ {
+		end, err := c.endTransform(q)
+		if err != nil {
+			return nil, err
+		}
+		dp, w, err := poseError(end, target)
+		if err != nil {
+			return nil, err
+		}
+		pe, err := dp.Length()
+		if err != nil {
+			return nil, err
+		}
+		oe, err := w.Length()
+		if err != nil {
+			return nil, err
+		}
+		// keep the best iterate, measuring each residual in units of its tolerance, always accepting the first
+		score := pe / opts.PositionTol
+		if opts.PositionOnly {
+			oe = 0
+		} else {
+			score = math.Max(score, oe/opts.OrientationTol)
+		}
+		if iter == 0 || score < best {
+			best = score
+			copy(res.Joints, q)
+			res.PositionResidual = pe
+			res.OrientationResidual = oe
+		}
+
+		if pe <= opts.PositionTol && (opts.PositionOnly || oe <= opts.OrientationTol) {
+			res.Iterations = iter
+			res.Converged = true
+			return res, nil
+		}
+		if iter >= opts.MaxIterations {
+			res.Iterations = iter
+			return res, numeric.ErrNotConverged
+		}
+
+		switch opts.Method {
+		case DampedLeastSquares, JacobianTranspose:
+			err = c.jacobianStep(q, dp, w, &opts)
+		case CyclicCoordinateDescent:
+			err = c.ccdSweep(q, target, &opts)
+		}
+		if err != nil {
+			return nil, err
+		}
+		opts.clamp(q)
+	}
+}
+
+// jacobianStep updates the joint values by one damped least squares or Jacobian transpose step.
+func (c *SerialChain) jacobianStep(q []float64, dp, w *geometry.Vector3D, opts *IKOptions) error {
+	jac, err := c.GeometricJacobian(q)
+	if err != nil {
+		return err
+	}
+
+	n := len(q)
+	rows := 6
+	e := []float64{dp.X, dp.Y, dp.Z, w.X, w.Y, w.Z}
+	if opts.PositionOnly {
+		rows = 3
+		e = e[:3]
+	}
+	J := mat.NewDense(rows, n, jac.Data[:rows*n])
+	ev := mat.NewVecDense(rows, e)
+
+	var y mat.VecDense
+	switch opts.Method {
+	case DampedLeastSquares:
+		// solve (J * J^T + lambda^2 * I) * y = e
+		var A mat.Dense
+		A.Mul(J, J.T())
+		l2 := opts.Damping * opts.Damping
+		for i := 0; i < rows; i++ {
+			A.Set(i, i, A.At(i, i)+l2)
+		}
+		err = y.SolveVec(&A, ev)
+		if err != nil {
+			return numeric.ErrSingularMatrix
+		}
+	case JacobianTranspose:
+		// alpha = <e, J * J^T * e> / <J * J^T * e, J * J^T * e>
+		var jt, jjt mat.VecDense
+		jt.MulVec(J.T(), ev)
+		jjt.MulVec(J, &jt)
+		den := mat.Dot(&jjt, &jjt)
+		if den == 0 {
+			return numeric.ErrSingularMatrix
+		}
+		y.ScaleVec(mat.Dot(ev, &jjt)/den, ev)
+	}
+
+	var dq mat.VecDense
+	dq.MulVec(J.T(), &y)
+	for i := range q {
+		q[i] += dq.AtVec(i)
+	}
+	return nil
+}
+
+// ccdSweep updates each joint in turn, from the end link to the base, to best align the end link with the target.
+func (c *SerialChain) ccdSweep(q []float64, target *RigidTransform3D, opts *IKOptions) error {
+	pt := target.Translation()
+	rt := target.Rotation().Elements()
+
+	for i := len(q) - 1; i >= 0; i-- {
+		origins, axes, pe, err := c.jointAxes(q)
+		if err != nil {
+			return err
+		}
+		z := axes[i]
+
+		if c.links[i].Joint == PrismaticJoint {
+			// slide along the axis by the projection of the position error
+			d := pt.Clone()
+			err = d.Sub(pe.AsVector())
+			if err != nil {
+				return err
+			}
+			s, err := d.Dot(z)
+			if err != nil {
+				return err
+			}
+			q[i] += s
+			opts.clamp(q)
+			continue
+		}
+
+		// rotate about the axis by the angle that best aligns the weighted vector pairs (u, v):
+		// theta = atan2(sum z . (u x v), sum (u . v - (u . z) * (v . z)))
+		u := pe.AsVector()
+		err = u.Sub(origins[i].AsVector())
+		if err != nil {
+			return err
+		}
+		v := pt.Clone()
+		err = v.Sub(origins[i].AsVector())
+		if err != nil {
+			return err
+		}
+		us := []*geometry.Vector3D{u}
+		vs := []*geometry.Vector3D{v}
+
+		if !opts.PositionOnly {
+			end, err := c.endTransform(q)
+			if err != nil {
+				return err
+			}
+			re := end.Rotation().Elements()
+			// weight the orientation axes by the lever arm so both terms have length units
+			r, err := u.Length()
+			if err != nil {
+				return err
+			}
+			if r == 0 {
+				r = 1
+			}
+			for k := 0; k < 3; k++ {
+				us = append(us, &geometry.Vector3D{X: re[k] * r, Y: re[3+k] * r, Z: re[6+k] * r})
+				vs = append(vs, &geometry.Vector3D{X: rt[k] * r, Y: rt[3+k] * r, Z: rt[6+k] * r})
+			}
+		}
+
+		var num, den float64
+		for k := range us {
+			cr, err := us[k].Cross(vs[k])
+			if err != nil {
+				return err
+			}
+			s, err := z.Dot(cr)
+			if err != nil {
+				return err
+			}
+			uv, err := us[k].Dot(vs[k])
+			if err != nil {
+				return err
+			}
+			uz, err := us[k].Dot(z)
+			if err != nil {
+				return err
+			}
+			vz, err := vs[k].Dot(z)
+			if err != nil {
+				return err
+			}
+			num += s
+			den += uv - uz*vz
+		}
+		if num != 0 || den != 0 {
+			q[i] += math.Atan2(num, den)
+		}
+		opts.clamp(q)
+	}
+	return nil
+}
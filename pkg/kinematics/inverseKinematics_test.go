@@ -0,0 +1,173 @@
+package kinematics
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/tab58/v1/spatial/pkg/geometry"
+	"github.com/tab58/v1/spatial/pkg/numeric"
+)
+
+// checkIKResult checks that the result converged within the tolerances and that its joint values reach the target.
+func checkIKResult(t *testing.T, name string, c *SerialChain, target *RigidTransform3D, res *IKResult, opts IKOptions) {
+	t.Helper()
+	if !res.Converged || !(res.PositionResidual <= opts.PositionTol) {
+		t.Fatalf("%s: converged %v with position residual %v", name, res.Converged, res.PositionResidual)
+	}
+	end, err := c.endTransform(res.Joints)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dp, w, err := poseError(end, target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pe, _ := dp.Length()
+	oe, _ := w.Length()
+	if pe > opts.PositionTol || (!opts.PositionOnly && oe > opts.OrientationTol) {
+		t.Fatalf("%s: joints %v leave residuals %v and %v", name, res.Joints, pe, oe)
+	}
+}
+
+func TestSolveIKFullPose(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	opts := DefaultIKOptions()
+	for i := 0; i < 20; i++ {
+		c := randomSerialChain(r, StandardDH)
+		// reachable targets from joint values near the initial guess
+		goal := make([]float64, c.NumJoints())
+		initial := make([]float64, c.NumJoints())
+		for k := range goal {
+			goal[k] = r.NormFloat64()
+			initial[k] = goal[k] + 0.2*r.NormFloat64()
+		}
+		target, err := c.endTransform(goal)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		res, err := c.SolveIK(target, initial, opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkIKResult(t, "SolveIK", c, target, res, opts)
+	}
+}
+
+func TestSolveIKPositionOnly(t *testing.T) {
+	d1, a2, a3 := 0.5, 1.5, 0.75
+	c, err := NewSerialChain(StandardDH, elbowArm(d1, a2, a3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := rand.New(rand.NewSource(2))
+	for _, method := range []IKMethod{DampedLeastSquares, JacobianTranspose, CyclicCoordinateDescent} {
+		opts := DefaultIKOptions()
+		opts.Method = method
+		opts.MaxIterations = 5000
+		opts.PositionOnly = true
+		// the orientation limit is unused for position-only solves
+		opts.OrientationTol = 0
+		for i := 0; i < 20; i++ {
+			goal := []float64{r.NormFloat64(), 0.5 + r.Float64(), 0.5 + r.Float64()}
+			target, err := c.endTransform(goal)
+			if err != nil {
+				t.Fatal(err)
+			}
+			res, err := c.SolveIK(target, []float64{goal[0] + 0.3, 1, 1}, opts)
+			if err != nil {
+				t.Fatalf("SolveIK method %v: %v", method, err)
+			}
+			checkIKResult(t, "SolveIK", c, target, res, opts)
+			if p := elbowArmPosition(d1, a2, a3, res.Joints); !closeSlices(p, []float64{target.translation.X,
+				target.translation.Y, target.translation.Z}, 1e-7) {
+				t.Fatalf("SolveIK method %v reached %v, want %v", method, p, target.translation)
+			}
+		}
+	}
+}
+
+func TestSolveIKJointLimits(t *testing.T) {
+	c, err := NewSerialChain(StandardDH, []DHParameters{{A: 1}, {A: 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the elbow may only bend one way, so the solver must find the elbow-down solution
+	target, err := c.endTransform([]float64{0.5, -1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts := DefaultIKOptions()
+	opts.PositionOnly = true
+	opts.JointLimits = []JointLimit{{Min: -math.Pi, Max: math.Pi}, {Min: -math.Pi, Max: 0}}
+	res, err := c.SolveIK(target, []float64{0, -0.1}, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkIKResult(t, "SolveIK", c, target, res, opts)
+	if !closeSlices(res.Joints, []float64{0.5, -1}, 1e-6) {
+		t.Errorf("SolveIK with joint limits = %v, want %v", res.Joints, []float64{0.5, -1})
+	}
+
+	// a target out of reach returns the best iterate found within the limits
+	target = NewRigidTransform3D(target.Rotation(), &geometry.Vector3D{X: 3})
+	opts.MaxIterations = 50
+	res, err = c.SolveIK(target, []float64{0, -0.1}, opts)
+	if err != numeric.ErrNotConverged {
+		t.Fatalf("SolveIK of an unreachable target: got %v, want %v", err, numeric.ErrNotConverged)
+	}
+	if res.Converged || res.Iterations != 50 || res.Joints[1] > 0 || !(res.PositionResidual >= 1) {
+		t.Errorf("SolveIK of an unreachable target = %+v", res)
+	}
+	end, err := c.endTransform(res.Joints)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dp, _, err := poseError(end, target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pe, _ := dp.Length(); pe != res.PositionResidual {
+		t.Errorf("SolveIK residual = %v, but the returned joints leave %v", res.PositionResidual, pe)
+	}
+}
+
+func TestSolveIKOptionErrors(t *testing.T) {
+	c, err := NewSerialChain(StandardDH, elbowArm(0.5, 1, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	target, err := c.endTransform([]float64{0, 0, 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	initial := []float64{0.1, 0.1, 0.1}
+
+	tests := []struct {
+		name   string
+		modify func(o *IKOptions)
+		want   error
+	}{
+		{"zero position limit", func(o *IKOptions) { o.PositionTol = 0 }, numeric.ErrInvalidTol},
+		{"NaN position limit", func(o *IKOptions) { o.PositionTol = math.NaN() }, numeric.ErrInvalidTol},
+		{"zero orientation limit", func(o *IKOptions) { o.OrientationTol = 0 }, numeric.ErrInvalidTol},
+		{"NaN orientation limit", func(o *IKOptions) { o.OrientationTol = math.NaN() }, numeric.ErrInvalidTol},
+		{"negative orientation limit", func(o *IKOptions) { o.OrientationTol = -1 }, numeric.ErrInvalidTol},
+		{"NaN damping", func(o *IKOptions) { o.Damping = math.NaN() }, numeric.ErrInvalidArgument},
+		{"negative iterations", func(o *IKOptions) { o.MaxIterations = -1 }, numeric.ErrInvalidArgument},
+		{"method", func(o *IKOptions) { o.Method = IKMethod(3) }, numeric.ErrInvalidArgument},
+		{"limit count", func(o *IKOptions) { o.JointLimits = []JointLimit{{Min: -1, Max: 1}} }, ErrJointCount},
+		{"inverted limit", func(o *IKOptions) { o.JointLimits = make([]JointLimit, 3); o.JointLimits[1].Min = 1 }, numeric.ErrInvalidArgument},
+	}
+	for _, tt := range tests {
+		opts := DefaultIKOptions()
+		tt.modify(&opts)
+		if _, err := c.SolveIK(target, initial, opts); err != tt.want {
+			t.Errorf("SolveIK with %s: got %v, want %v", tt.name, err, tt.want)
+		}
+	}
+	if _, err := c.SolveIK(target, []float64{0}, DefaultIKOptions()); err != ErrJointCount {
+		t.Errorf("SolveIK with too few initial values: got %v, want %v", err, ErrJointCount)
+	}
+}
@@ -0,0 +1,220 @@
+package kinematics
+
+import (
+	"github.com/tab58/v1/spatial/pkg/geometry"
+	"github.com/tab58/v1/spatial/pkg/numeric"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/mat"
+)
+
+// jointAxes returns the position and unit z-axis of each joint, expressed in the base frame, along with the end link position.
+func (c *SerialChain) jointAxes(q []float64) ([]*geometry.Point3D, []*geometry.Vector3D, *geometry.Point3D, error) {
+	frames, err := c.ForwardKinematics(q)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	n := len(frames)
+	origins := make([]*geometry.Point3D, n)
+	axes := make([]*geometry.Vector3D, n)
+	for i := range frames {
+		// standard DH joint i moves about the z-axis of frame i-1; modified DH joint i about that of frame i
+		var e [16]float64
+		switch {
+		case c.convention == ModifiedDH:
+			e = frames[i].Elements()
+		case i == 0:
+			e = [16]float64{1, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1}
+		default:
+			e = frames[i-1].Elements()
+		}
+		origins[i] = &geometry.Point3D{X: e[3], Y: e[7], Z: e[11]}
+		axes[i] = &geometry.Vector3D{X: e[2], Y: e[6], Z: e[10]}
+	}
+
+	e := frames[n-1].Elements()
+	end := &geometry.Point3D{X: e[3], Y: e[7], Z: e[11]}
+	return origins, axes, end, nil
+}
+
+// GeometricJacobian computes the 6xN geometric Jacobian of the end link for the given joint values. The first three
+// rows map joint velocities to the linear velocity of the end link origin and the last three to its angular velocity,
+// both expressed in the base frame.
+func (c *SerialChain) GeometricJacobian(q []float64) (blas64.General, error) {
+	origins, axes, end, err := c.jointAxes(q)
+	if err != nil {
+		return blas64.General{}, err
+	}
+
+	n := len(c.links)
+	jac := blas64.General{
+		Rows:   6,
+		Cols:   n,
+		Stride: n,
+		Data:   make([]float64, 6*n),
+	}
+	for i, l := range c.links {
+		z := axes[i]
+		var lin, ang *geometry.Vector3D
+		if l.Joint == RevoluteJoint {
+			// v = z x (p_end - p_joint), w = z
+			r := end.AsVector()
+			err = r.Sub(origins[i].AsVector())
+			if err != nil {
+				return blas64.General{}, err
+			}
+			lin, err = z.Cross(r)
+			if err != nil {
+				return blas64.General{}, err
+			}
+			ang = z
+		} else {
+			// v = z, w = 0
+			lin = z
+			ang = &geometry.Vector3D{}
+		}
+
+		jac.Data[0*n+i] = lin.X
+		jac.Data[1*n+i] = lin.Y
+		jac.Data[2*n+i] = lin.Z
+		jac.Data[3*n+i] = ang.X
+		jac.Data[4*n+i] = ang.Y
+		jac.Data[5*n+i] = ang.Z
+	}
+	return jac, nil
+}
+
+// eulerRateMatrix computes the matrix B that maps Euler angle rates to angular velocity in the fixed frame, w = B * dphi.
+func eulerRateMatrix(seq EulerSequence, frame EulerFrame, a1, a2, a3 float64) (*geometry.Matrix3D, error) {
+	ax, err := intrinsicAxes(seq, frame)
+	if err != nil {
+		return nil, err
+	}
+	angles := [3]float64{a1, a2, a3}
+	if frame == Extrinsic {
+		angles = [3]float64{a3, a2, a1}
+	}
+
+	// for intrinsic rotations R_i(a) * R_j(b) * R_k(c), w = e_i * da + R_i(a) * e_j * db + R_i(a) * R_j(b) * e_k * dc
+	cols := [3][3]float64{}
+	r := [9]float64{1, 0, 0, 0, 1, 0, 0, 0, 1}
+	for n := 0; n < 3; n++ {
+		k := ax[n]
+		cols[n] = [3]float64{r[k], r[3+k], r[6+k]}
+		r = multiplyRotations(r, axisRotation(k, angles[n]))
+	}
+	if frame == Extrinsic {
+		cols[0], cols[2] = cols[2], cols[0]
+	}
+
+	b := &geometry.Matrix3D{}
+	err = b.SetElements(
+		cols[0][0], cols[1][0], cols[2][0],
+		cols[0][1], cols[1][1], cols[2][1],
+		cols[0][2], cols[1][2], cols[2][2],
+	)
+	if err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// AnalyticalJacobian computes the 6xN analytical Jacobian of the end link for the given joint values, where the
+// orientation is represented by Euler angles of the given sequence. The last three rows map joint velocities to
+// Euler angle rates, which grow without bound near gimbal lock, so numeric.ErrSingularMatrix is returned when the end
+// link orientation is within the tolerance of gimbal lock as decided by Get3DEulerAngles.
+func (c *SerialChain) AnalyticalJacobian(q []float64, seq EulerSequence, frame EulerFrame, tol numeric.Tolerance) (blas64.General, error) {
+	jac, err := c.GeometricJacobian(q)
+	if err != nil {
+		return blas64.General{}, err
+	}
+	end, err := c.EndEffectorTransform(q)
+	if err != nil {
+		return blas64.General{}, err
+	}
+
+	e := end.Elements()
+	rot := &Transform3D{Matrix3D: &geometry.Matrix3D{}}
+	err = rot.SetElements(e[0], e[1], e[2], e[4], e[5], e[6], e[8], e[9], e[10])
+	if err != nil {
+		return blas64.General{}, err
+	}
+	a1, a2, a3, err := rot.Get3DEulerAngles(seq, frame, tol)
+	if err == numeric.ErrGimbalLock {
+		return blas64.General{}, numeric.ErrSingularMatrix
+	}
+	if err != nil {
+		return blas64.General{}, err
+	}
+
+	b, err := eulerRateMatrix(seq, frame, a1, a2, a3)
+	if err != nil {
+		return blas64.General{}, err
+	}
+	err = b.Invert()
+	if err != nil {
+		return blas64.General{}, err
+	}
+
+	// dphi = B^-1 * w for each column
+	bi := b.Elements()
+	n := jac.Cols
+	for i := 0; i < n; i++ {
+		wx, wy, wz := jac.Data[3*n+i], jac.Data[4*n+i], jac.Data[5*n+i]
+		jac.Data[3*n+i] = bi[0]*wx + bi[1]*wy + bi[2]*wz
+		jac.Data[4*n+i] = bi[3]*wx + bi[4]*wy + bi[5]*wz
+		jac.Data[5*n+i] = bi[6]*wx + bi[7]*wy + bi[8]*wz
+	}
+	return jac, nil
+}
+
+// JacobianSingularValues computes the singular values of the geometric Jacobian in descending order.
+func (c *SerialChain) JacobianSingularValues(q []float64) ([]float64, error) {
+	jac, err := c.GeometricJacobian(q)
+	if err != nil {
+		return nil, err
+	}
+
+	var svd mat.SVD
+	ok := svd.Factorize(mat.NewDense(jac.Rows, jac.Cols, jac.Data), mat.SVDNone)
+	if !ok {
+		return nil, numeric.ErrSingularMatrix
+	}
+	return svd.Values(nil), nil
+}
+
+// Manipulability computes Yoshikawa's manipulability index, the product of the singular values of the geometric
+// Jacobian. It equals sqrt(det(J * J^T)) for chains with at least 6 joints and is zero at singular configurations.
+func (c *SerialChain) Manipulability(q []float64) (float64, error) {
+	s, err := c.JacobianSingularValues(q)
+	if err != nil {
+		return 0, err
+	}
+
+	w := 1.0
+	for _, v := range s {
+		w *= v
+	}
+	if numeric.IsOverflow(w) {
+		return 0, numeric.ErrOverflow
+	}
+	return w, nil
+}
+
+// CheckSingularity returns numeric.ErrSingularMatrix if the smallest singular value of the geometric Jacobian is zero
+// within the given tolerance at the scale of the largest, in which case the end link loses the ability to move in some
+// direction.
+func (c *SerialChain) CheckSingularity(q []float64, tol numeric.Tolerance) error {
+	if tol.IsInvalid() {
+		return numeric.ErrInvalidTol
+	}
+
+	s, err := c.JacobianSingularValues(q)
+	if err != nil {
+		return err
+	}
+	if len(s) == 0 || tol.IsEqualAtScale(s[len(s)-1], 0, s[0]) {
+		return numeric.ErrSingularMatrix
+	}
+	return nil
+}
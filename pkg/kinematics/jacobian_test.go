@@ -0,0 +1,207 @@
+package kinematics
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/tab58/v1/spatial/pkg/geometry"
+	"github.com/tab58/v1/spatial/pkg/numeric"
+)
+
+// randomSerialChain returns a six joint chain with random DH parameters and a prismatic third joint.
+func randomSerialChain(r *rand.Rand, convention DHConvention) *SerialChain {
+	links := make([]DHParameters, 6)
+	for i := range links {
+		links[i] = DHParameters{A: r.NormFloat64(), Alpha: r.NormFloat64(), D: r.NormFloat64(), Theta: r.NormFloat64()}
+	}
+	links[2].Joint = PrismaticJoint
+	c, err := NewSerialChain(convention, links)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// endPose returns the rotation and the position of the end link.
+func endPose(t *testing.T, c *SerialChain, q []float64) ([9]float64, []float64) {
+	t.Helper()
+	end, err := c.EndEffectorTransform(q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e := end.Elements()
+	return [9]float64{e[0], e[1], e[2], e[4], e[5], e[6], e[8], e[9], e[10]}, []float64{e[3], e[7], e[11]}
+}
+
+// centralDifference evaluates f at q plus and minus h along joint k.
+func centralDifference(q []float64, k int, h float64, f func(q []float64)) {
+	qp := append([]float64{}, q...)
+	qp[k] += h
+	f(qp)
+	qm := append([]float64{}, q...)
+	qm[k] -= h
+	f(qm)
+}
+
+// jacobianColumn returns column k of a 6xN Jacobian.
+func jacobianColumn(jac []float64, n, k int) []float64 {
+	col := make([]float64, 6)
+	for i := range col {
+		col[i] = jac[n*i+k]
+	}
+	return col
+}
+
+func TestGeometricJacobianFiniteDifferences(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	const h = 1e-6
+	for _, convention := range []DHConvention{StandardDH, ModifiedDH} {
+		for i := 0; i < 20; i++ {
+			c := randomSerialChain(r, convention)
+			q := make([]float64, c.NumJoints())
+			for k := range q {
+				q[k] = r.NormFloat64()
+			}
+			jac, err := c.GeometricJacobian(q)
+			if err != nil {
+				t.Fatal(err)
+			}
+			rot, _ := endPose(t, c, q)
+			rotT := [9]float64{rot[0], rot[3], rot[6], rot[1], rot[4], rot[7], rot[2], rot[5], rot[8]}
+
+			for k := range q {
+				// the linear velocity is the derivative of the position and the angular velocity is the axial vector
+				// of dR/dq * R^T
+				var poses [2][]float64
+				n := 0
+				centralDifference(q, k, h, func(q []float64) {
+					rq, p := endPose(t, c, q)
+					w := multiplyRotations(rq, rotT)
+					poses[n] = append(p, w[7], w[2], w[3])
+					n++
+				})
+				want := make([]float64, 6)
+				for j := range want {
+					want[j] = (poses[0][j] - poses[1][j]) / (2 * h)
+				}
+				if got := jacobianColumn(jac.Data, jac.Cols, k); !closeSlices(got, want, 1e-8) {
+					t.Fatalf("GeometricJacobian column %d = %v, want %v", k, got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestAnalyticalJacobianFiniteDifferences(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	const h = 1e-6
+	tol := numeric.AbsoluteTolerance(1e-3)
+	for _, seq := range []EulerSequence{EulerXYZ, EulerZYZ} {
+		for _, frame := range []EulerFrame{Intrinsic, Extrinsic} {
+			for i := 0; i < 20; i++ {
+				c := randomSerialChain(r, StandardDH)
+				q := make([]float64, c.NumJoints())
+				for k := range q {
+					q[k] = r.NormFloat64()
+				}
+				jac, err := c.AnalyticalJacobian(q, seq, frame, numeric.AbsoluteTolerance(0.1))
+				if err == numeric.ErrSingularMatrix {
+					// too close to gimbal lock for finite differences of the angles
+					continue
+				}
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				for k := range q {
+					var poses [2][]float64
+					n := 0
+					centralDifference(q, k, h, func(q []float64) {
+						rq, p := endPose(t, c, q)
+						m := &Transform3D{Matrix3D: &geometry.Matrix3D{}}
+						if err := m.SetElements(rq[0], rq[1], rq[2], rq[3], rq[4], rq[5], rq[6], rq[7], rq[8]); err != nil {
+							t.Fatal(err)
+						}
+						a1, a2, a3, err := m.Get3DEulerAngles(seq, frame, tol)
+						if err != nil {
+							t.Fatal(err)
+						}
+						poses[n] = append(p, a1, a2, a3)
+						n++
+					})
+					want := make([]float64, 6)
+					for j := range want {
+						d := poses[0][j] - poses[1][j]
+						// the angles may wrap around between the two samples
+						d -= 2 * math.Pi * math.Round(d/(2*math.Pi))
+						want[j] = d / (2 * h)
+					}
+					if got := jacobianColumn(jac.Data, jac.Cols, k); !closeSlices(got, want, 1e-6) {
+						t.Fatalf("AnalyticalJacobian(%v, %v) column %d = %v, want %v", seq, frame, k, got, want)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestAnalyticalJacobianGimbalLock(t *testing.T) {
+	// a single link twisted slightly about its x-axis ends close to the locked ZXZ orientations
+	c, err := NewSerialChain(StandardDH, []DHParameters{{A: 1, Alpha: 1e-8}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := []float64{0.3}
+	tol := numeric.AbsoluteTolerance(1e-6)
+	if _, err := c.AnalyticalJacobian(q, EulerZXZ, Intrinsic, tol); err != numeric.ErrSingularMatrix {
+		t.Errorf("AnalyticalJacobian near gimbal lock: got %v, want %v", err, numeric.ErrSingularMatrix)
+	}
+	if _, err := c.AnalyticalJacobian(q, EulerZXZ, Intrinsic, numeric.Tolerance{}); err != nil {
+		t.Errorf("AnalyticalJacobian with an exact tolerance: %v", err)
+	}
+	if _, err := c.AnalyticalJacobian(q, EulerZYX, Intrinsic, tol); err != nil {
+		t.Errorf("AnalyticalJacobian away from gimbal lock: %v", err)
+	}
+}
+
+func TestManipulability(t *testing.T) {
+	// a spherical ZYZ wrist, whose first and last axes line up when the middle joint is zero
+	c, err := NewSerialChain(StandardDH, []DHParameters{{Alpha: -math.Pi / 2}, {Alpha: math.Pi / 2}, {}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tol := numeric.RelativeTolerance(1e-9)
+	for _, q2 := range []float64{-2, -0.5, 0.25, 1, math.Pi / 2} {
+		q := []float64{0.3, q2, -0.7}
+		// the Jacobian columns are the three unit joint axes, so the index is the volume they span
+		w, err := c.Manipulability(q)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := math.Abs(math.Sin(q2)); math.Abs(w-want) > 1e-15 {
+			t.Errorf("Manipulability at %v = %v, want %v", q, w, want)
+		}
+		if err := c.CheckSingularity(q, tol); err != nil {
+			t.Errorf("CheckSingularity at %v: %v", q, err)
+		}
+	}
+
+	q := []float64{0.3, 0, -0.7}
+	w, err := c.Manipulability(q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w > 1e-15 {
+		t.Errorf("Manipulability at the singularity = %v, want 0", w)
+	}
+	if err := c.CheckSingularity(q, tol); err != numeric.ErrSingularMatrix {
+		t.Errorf("CheckSingularity at the singularity: got %v, want %v", err, numeric.ErrSingularMatrix)
+	}
+	if err := c.CheckSingularity(q, numeric.RelativeTolerance(-1)); err != numeric.ErrInvalidTol {
+		t.Errorf("CheckSingularity with a negative tolerance: got %v, want %v", err, numeric.ErrInvalidTol)
+	}
+	if _, err := c.Manipulability([]float64{0}); err != ErrJointCount {
+		t.Errorf("Manipulability with too few joint values: got %v, want %v", err, ErrJointCount)
+	}
+}
@@ -0,0 +1,373 @@
+package kinematics
+
+import (
+	"math"
+
+	"github.com/tab58/v1/spatial/pkg/geometry"
+	"github.com/tab58/v1/spatial/pkg/numeric"
+	"gonum.org/v1/gonum/blas/blas64"
+)
+
+// lieTaylorThreshold is the rotation angle below which the coefficients of the exp/log maps and their Jacobians are
+// evaluated by Taylor series, avoiding the cancellation in the closed forms.
+const lieTaylorThreshold = 0.05
+
+// lieCoefficients returns sin(phi)/phi, (1 - cos(phi))/phi^2 and (phi - sin(phi))/phi^3.
+func lieCoefficients(phi float64) (float64, float64, float64) {
+	if math.Abs(phi) < lieTaylorThreshold {
+		x := phi * phi
+		a := 1 - x/6*(1-x/20*(1-x/42))
+		b := 0.5 - x/24*(1-x/30*(1-x/56))
+		c := 1.0/6 - x/120*(1-x/42*(1-x/72))
+		return a, b, c
+	}
+	// 1 - cos(phi) = 2 * sin(phi/2)^2 without cancellation
+	s, h := math.Sin(phi), math.Sin(phi/2)
+	return s / phi, 2 * h * h / (phi * phi), (phi - s) / (phi * phi * phi)
+}
+
+// lieInverseCoefficient returns 1/phi^2 - (1 + cos(phi)) / (2 * phi * sin(phi)), the coefficient of the squared term in
+// the inverse Jacobian of SO(3).
+func lieInverseCoefficient(phi float64) float64 {
+	if math.Abs(phi) < lieTaylorThreshold {
+		x := phi * phi
+		return 1.0/12 + x/720*(1+x/42*(1+x/40))
+	}
+	return 1/(phi*phi) - (1+math.Cos(phi))/(2*phi*math.Sin(phi))
+}
+
+// lieSE3Coefficients returns (phi^2 + 2*cos(phi) - 2) / (2*phi^4) and (2*phi - 3*sin(phi) + phi*cos(phi)) / (2*phi^5),
+// the higher order coefficients of the translational block of the SE(3) Jacobian.
+func lieSE3Coefficients(phi float64) (float64, float64) {
+	if math.Abs(phi) < lieTaylorThreshold {
+		x := phi * phi
+		e := 1.0/24 - x/720*(1-x/56*(1-x/90))
+		f := 1.0/120 - x/2520*(1-x/48*(1-x/82.5))
+		return e, f
+	}
+	s, co := math.Sin(phi), math.Cos(phi)
+	p2 := phi * phi
+	return (p2 + 2*co - 2) / (2 * p2 * p2), (2*phi - 3*s + phi*co) / (2 * p2 * p2 * phi)
+}
+
+// so3Series computes I + p * K + q * K^2 for the row-major matrix K.
+func so3Series(k [9]float64, p, q float64) [9]float64 {
+	k2 := multiplyRotations(k, k)
+	r := [9]float64{}
+	for i := range r {
+		r[i] = p*k[i] + q*k2[i]
+	}
+	r[0]++
+	r[4]++
+	r[8]++
+	return r
+}
+
+// matrix3DFromElements creates a 3x3 matrix from the row-major elements.
+func matrix3DFromElements(e [9]float64) (*geometry.Matrix3D, error) {
+	m := &geometry.Matrix3D{}
+	err := m.SetElements(e[0], e[1], e[2], e[3], e[4], e[5], e[6], e[7], e[8])
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ExpSO3 computes the rotation matrix exp([w]) of the rotation vector w, whose direction is the rotation axis and
+// whose length is the rotation angle.
+func ExpSO3(w geometry.Vector3DReader) (*geometry.Matrix3D, error) {
+	phi, err := w.Length()
+	if err != nil {
+		return nil, err
+	}
+	if phi >= lieTaylorThreshold {
+		return matrix3DFromElements(rotation3DFromAxisAngle(w, phi))
+	}
+	a, b, _ := lieCoefficients(phi)
+	return matrix3DFromElements(so3Series(geometry.Hat(w).Elements(), a, b))
+}
+
+// LogSO3 computes the rotation vector w such that exp([w]) is the given rotation matrix, with an angle in [0, pi].
+func LogSO3(r *geometry.Matrix3D) (*geometry.Vector3D, error) {
+	q := &geometry.Quaternion{}
+	err := q.SetFromMatrix3D(r)
+	if err != nil {
+		return nil, err
+	}
+	if q.W < 0 {
+		q.Negate()
+	}
+
+	// phi = 2 * atan2(s, w) with s = sin(phi/2), so w = (phi / s) * (x, y, z)
+	v := &geometry.Vector3D{X: q.X, Y: q.Y, Z: q.Z}
+	s, err := v.Length()
+	if err != nil {
+		return nil, err
+	}
+	var f float64
+	if s < 1e-4 {
+		f = 2 / q.W * (1 - s*s/(3*q.W*q.W))
+	} else {
+		f = 2 * math.Atan2(s, q.W) / s
+	}
+	err = v.Scale(f)
+	if err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// LeftJacobianSO3 computes the left Jacobian of SO(3) at w, which satisfies exp([w + dw]) ~ exp([J * dw]) * exp([w]).
+func LeftJacobianSO3(w geometry.Vector3DReader) (*geometry.Matrix3D, error) {
+	phi, err := w.Length()
+	if err != nil {
+		return nil, err
+	}
+	_, b, c := lieCoefficients(phi)
+	return matrix3DFromElements(so3Series(geometry.Hat(w).Elements(), b, c))
+}
+
+// RightJacobianSO3 computes the right Jacobian of SO(3) at w, which satisfies exp([w + dw]) ~ exp([w]) * exp([J * dw]).
+func RightJacobianSO3(w geometry.Vector3DReader) (*geometry.Matrix3D, error) {
+	phi, err := w.Length()
+	if err != nil {
+		return nil, err
+	}
+	_, b, c := lieCoefficients(phi)
+	return matrix3DFromElements(so3Series(geometry.Hat(w).Elements(), -b, c))
+}
+
+// LeftJacobianInverseSO3 computes the inverse of the left Jacobian of SO(3) at w, for rotation angles below 2*pi.
+func LeftJacobianInverseSO3(w geometry.Vector3DReader) (*geometry.Matrix3D, error) {
+	phi, err := w.Length()
+	if err != nil {
+		return nil, err
+	}
+	return matrix3DFromElements(so3Series(geometry.Hat(w).Elements(), -0.5, lieInverseCoefficient(phi)))
+}
+
+// RightJacobianInverseSO3 computes the inverse of the right Jacobian of SO(3) at w, for rotation angles below 2*pi.
+func RightJacobianInverseSO3(w geometry.Vector3DReader) (*geometry.Matrix3D, error) {
+	phi, err := w.Length()
+	if err != nil {
+		return nil, err
+	}
+	return matrix3DFromElements(so3Series(geometry.Hat(w).Elements(), 0.5, lieInverseCoefficient(phi)))
+}
+
+// ExpSE2 computes the homogeneous transform exp(xi) of the SE(2) tangent vector xi = (x, y, theta), where (x, y) is
+// the translational part and theta the rotation angle.
+func ExpSE2(xi geometry.Vector3DReader) (*HomogeneousTransform3D, error) {
+	rx, ry, theta := xi.GetComponents()
+	a, b, _ := lieCoefficients(theta)
+
+	// t = V * rho, V = [a, -theta*b; theta*b, a]
+	tb := theta * b
+	x := a*rx - tb*ry
+	y := tb*rx + a*ry
+	if numeric.AreAnyOverflow(x, y) {
+		return nil, numeric.ErrOverflow
+	}
+	c, s := math.Cos(theta), math.Sin(theta)
+	m := &HomogeneousTransform3D{Matrix3D: &geometry.Matrix3D{}}
+	err := m.SetElements(c, -s, x, s, c, y, 0, 0, 1)
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// LogSE2 computes the SE(2) tangent vector (x, y, theta) such that exp(xi) is the given homogeneous transform, with
+// theta in (-pi, pi]. The bottom row of the matrix is assumed to be (0, 0, 1).
+func LogSE2(m *HomogeneousTransform3D) (*geometry.Vector3D, error) {
+	e := m.Elements()
+	theta := math.Atan2(e[3], e[0])
+	a, b, _ := lieCoefficients(theta)
+
+	// rho = V^-1 * t, where det(V) = a^2 + (theta*b)^2 = 2*b
+	tb := theta * b
+	det := 2 * b
+	x := (a*e[2] + tb*e[5]) / det
+	y := (-tb*e[2] + a*e[5]) / det
+	if numeric.AreAnyOverflow(x, y) {
+		return nil, numeric.ErrOverflow
+	}
+	return &geometry.Vector3D{X: x, Y: y, Z: theta}, nil
+}
+
+// se2Jacobian computes the right Jacobian of SE(2) at (x, y, theta); the left Jacobian is the right Jacobian at -xi.
+func se2Jacobian(x, y, theta float64) [9]float64 {
+	a, b, c := lieCoefficients(theta)
+	tb, tc := theta*b, theta*c
+	return [9]float64{
+		a, tb, x*tc - y*b,
+		-tb, a, x*b + y*tc,
+		0, 0, 1,
+	}
+}
+
+// se2JacobianInverse inverts a Jacobian of SE(2), which has the block form [A, r; 0, 1] with A = [a, p; -p, a].
+func se2JacobianInverse(j [9]float64) (*geometry.Matrix3D, error) {
+	det := j[0]*j[4] - j[1]*j[3]
+	if det == 0 {
+		return nil, numeric.ErrSingularMatrix
+	}
+	i00, i01 := j[4]/det, -j[1]/det
+	i10, i11 := -j[3]/det, j[0]/det
+	return matrix3DFromElements([9]float64{
+		i00, i01, -(i00*j[2] + i01*j[5]),
+		i10, i11, -(i10*j[2] + i11*j[5]),
+		0, 0, 1,
+	})
+}
+
+// LeftJacobianSE2 computes the left Jacobian of SE(2) at xi = (x, y, theta).
+func LeftJacobianSE2(xi geometry.Vector3DReader) (*geometry.Matrix3D, error) {
+	x, y, theta := xi.GetComponents()
+	return matrix3DFromElements(se2Jacobian(-x, -y, -theta))
+}
+
+// RightJacobianSE2 computes the right Jacobian of SE(2) at xi = (x, y, theta).
+func RightJacobianSE2(xi geometry.Vector3DReader) (*geometry.Matrix3D, error) {
+	return matrix3DFromElements(se2Jacobian(xi.GetComponents()))
+}
+
+// LeftJacobianInverseSE2 computes the inverse of the left Jacobian of SE(2) at xi = (x, y, theta).
+func LeftJacobianInverseSE2(xi geometry.Vector3DReader) (*geometry.Matrix3D, error) {
+	x, y, theta := xi.GetComponents()
+	return se2JacobianInverse(se2Jacobian(-x, -y, -theta))
+}
+
+// RightJacobianInverseSE2 computes the inverse of the right Jacobian of SE(2) at xi = (x, y, theta).
+func RightJacobianInverseSE2(xi geometry.Vector3DReader) (*geometry.Matrix3D, error) {
+	return se2JacobianInverse(se2Jacobian(xi.GetComponents()))
+}
+
+// ExpSE3 computes the rigid transform exp(xi) of the twist, with rotation exp([w]) and translation J_l(w) * v.
+func ExpSE3(xi *Twist) (*RigidTransform3D, error) {
+	r, err := ExpSO3(&xi.Angular)
+	if err != nil {
+		return nil, err
+	}
+	j, err := LeftJacobianSO3(&xi.Angular)
+	if err != nil {
+		return nil, err
+	}
+	x, y, z, err := rotate(j.Elements(), &xi.Linear)
+	if err != nil {
+		return nil, err
+	}
+	return NewRigidTransform3D(r, &geometry.Vector3D{X: x, Y: y, Z: z}), nil
+}
+
+// LogSE3 computes the twist xi such that exp(xi) is the given rigid transform, with a rotation angle in [0, pi].
+func LogSE3(t *RigidTransform3D) (*Twist, error) {
+	w, err := LogSO3(&t.rotation)
+	if err != nil {
+		return nil, err
+	}
+	j, err := LeftJacobianInverseSO3(w)
+	if err != nil {
+		return nil, err
+	}
+	x, y, z, err := rotate(j.Elements(), &t.translation)
+	if err != nil {
+		return nil, err
+	}
+	return &Twist{Linear: geometry.Vector3D{X: x, Y: y, Z: z}, Angular: *w}, nil
+}
+
+// se3JacobianQ computes the upper right block Q(v, w) of the left Jacobian of SE(3).
+func se3JacobianQ(v, w geometry.Vector3DReader) ([9]float64, error) {
+	phi, err := w.Length()
+	if err != nil {
+		return [9]float64{}, err
+	}
+	_, _, c := lieCoefficients(phi)
+	e, f := lieSE3Coefficients(phi)
+
+	// Q = V/2 + c * (WV + VW + WVW) + e * (WWV + VWW - 3 WVW) + f * (WVWW + WWVW), with V = [v] and W = [w]
+	vh, wh := geometry.Hat(v).Elements(), geometry.Hat(w).Elements()
+	wv := multiplyRotations(wh, vh)
+	vw := multiplyRotations(vh, wh)
+	wvw := multiplyRotations(wv, wh)
+	wwv := multiplyRotations(wh, wv)
+	vww := multiplyRotations(vw, wh)
+	wvww := multiplyRotations(wvw, wh)
+	wwvw := multiplyRotations(wh, wvw)
+
+	q := [9]float64{}
+	for i := range q {
+		q[i] = vh[i]/2 + c*(wv[i]+vw[i]+wvw[i]) + e*(wwv[i]+vww[i]-3*wvw[i]) + f*(wvww[i]+wwvw[i])
+		if numeric.IsOverflow(q[i]) {
+			return [9]float64{}, numeric.ErrOverflow
+		}
+	}
+	return q, nil
+}
+
+// se3Jacobian assembles the 6x6 matrix [J, Q; 0, J] for the twist ordering (linear, angular).
+func se3Jacobian(j, q [9]float64) blas64.General {
+	m := blas64.General{Rows: 6, Cols: 6, Stride: 6, Data: make([]float64, 36)}
+	for r := 0; r < 3; r++ {
+		for c := 0; c < 3; c++ {
+			m.Data[6*r+c] = j[3*r+c]
+			m.Data[6*r+c+3] = q[3*r+c]
+			m.Data[6*(r+3)+c+3] = j[3*r+c]
+		}
+	}
+	return m
+}
+
+// leftJacobianSE3 computes the left Jacobian of SE(3), or its inverse, at the twist (v, w).
+func leftJacobianSE3(v, w geometry.Vector3DReader, inverse bool) (blas64.General, error) {
+	q, err := se3JacobianQ(v, w)
+	if err != nil {
+		return blas64.General{}, err
+	}
+	if !inverse {
+		j, err := LeftJacobianSO3(w)
+		if err != nil {
+			return blas64.General{}, err
+		}
+		return se3Jacobian(j.Elements(), q), nil
+	}
+
+	// [J, Q; 0, J]^-1 = [J^-1, -J^-1 * Q * J^-1; 0, J^-1]
+	ji, err := LeftJacobianInverseSO3(w)
+	if err != nil {
+		return blas64.General{}, err
+	}
+	e := ji.Elements()
+	qi := multiplyRotations(multiplyRotations(e, q), e)
+	for i := range qi {
+		qi[i] = -qi[i]
+	}
+	return se3Jacobian(e, qi), nil
+}
+
+// LeftJacobianSE3 computes the 6x6 left Jacobian of SE(3) at the twist, with rows and columns ordered (linear, angular).
+func LeftJacobianSE3(xi *Twist) (blas64.General, error) {
+	return leftJacobianSE3(&xi.Linear, &xi.Angular, false)
+}
+
+// RightJacobianSE3 computes the 6x6 right Jacobian of SE(3) at the twist, with rows and columns ordered (linear, angular).
+func RightJacobianSE3(xi *Twist) (blas64.General, error) {
+	v, w := xi.Linear, xi.Angular
+	v.Negate()
+	w.Negate()
+	return leftJacobianSE3(&v, &w, false)
+}
+
+// LeftJacobianInverseSE3 computes the inverse of the left Jacobian of SE(3) at the twist.
+func LeftJacobianInverseSE3(xi *Twist) (blas64.General, error) {
+	return leftJacobianSE3(&xi.Linear, &xi.Angular, true)
+}
+
+// RightJacobianInverseSE3 computes the inverse of the right Jacobian of SE(3) at the twist.
+func RightJacobianInverseSE3(xi *Twist) (blas64.General, error) {
+	v, w := xi.Linear, xi.Angular
+	v.Negate()
+	w.Negate()
+	return leftJacobianSE3(&v, &w, true)
+}
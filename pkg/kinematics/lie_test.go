@@ -0,0 +1,347 @@
+package kinematics
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/tab58/v1/spatial/pkg/geometry"
+)
+
+// lieAngles are rotation angles on both sides of the Taylor series threshold and up to near pi.
+var lieAngles = []float64{0, 1e-8, 1e-3, 0.049, 0.051, 0.5, 2, 3.1}
+
+// randomRotationVector returns a rotation vector with a random axis and the given angle.
+func randomRotationVector(r *rand.Rand, angle float64) *geometry.Vector3D {
+	w := randomVector3D(r)
+	if err := w.Normalize(); err != nil {
+		panic(err)
+	}
+	if err := w.Scale(angle); err != nil {
+		panic(err)
+	}
+	return w
+}
+
+// tangentJacobian computes the n x n derivative of g at zero by central differences, in row-major order.
+func tangentJacobian(n int, g func(d []float64) []float64) []float64 {
+	const h = 1e-6
+	jac := make([]float64, n*n)
+	for k := 0; k < n; k++ {
+		d := make([]float64, n)
+		d[k] = h
+		gp := g(d)
+		d[k] = -h
+		gm := g(d)
+		for i := 0; i < n; i++ {
+			jac[n*i+k] = (gp[i] - gm[i]) / (2 * h)
+		}
+	}
+	return jac
+}
+
+// checkIdentityProduct checks that the row-major n x n product a * b is the identity.
+func checkIdentityProduct(t *testing.T, name string, a, b []float64, n int) {
+	t.Helper()
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			s := 0.0
+			for k := 0; k < n; k++ {
+				s += a[n*i+k] * b[n*k+j]
+			}
+			if i == j {
+				s--
+			}
+			if math.Abs(s) > 1e-13 {
+				t.Fatalf("%s is not the identity at (%d, %d): error %v", name, i, j, s)
+			}
+		}
+	}
+}
+
+func TestSO3ExpLog(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, angle := range lieAngles {
+		for i := 0; i < 50; i++ {
+			w := randomRotationVector(r, angle)
+			m, err := ExpSO3(w)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := rotation3DFromAxisAngle(&geometry.Vector3D{X: 1}, 0)
+			if angle > 0 {
+				want = rotation3DFromAxisAngle(w, angle)
+			}
+			got := m.Elements()
+			if !closeSlices(got[:], want[:], 1e-15) {
+				t.Fatalf("ExpSO3(%v) = %v, want %v", w, got, want)
+			}
+
+			v, err := LogSO3(m)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !closeSlices([]float64{v.X, v.Y, v.Z}, []float64{w.X, w.Y, w.Z}, 1e-14) {
+				t.Fatalf("LogSO3(ExpSO3(%v)) = %v", w, v)
+			}
+		}
+	}
+}
+
+func TestSO3Jacobians(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	// expPerturbed returns exp([w + d]) for the perturbation d.
+	expPerturbed := func(w *geometry.Vector3D, d []float64) *geometry.Matrix3D {
+		m, err := ExpSO3(&geometry.Vector3D{X: w.X + d[0], Y: w.Y + d[1], Z: w.Z + d[2]})
+		if err != nil {
+			panic(err)
+		}
+		return m
+	}
+	logSlice := func(m *geometry.Matrix3D) []float64 {
+		v, err := LogSO3(m)
+		if err != nil {
+			panic(err)
+		}
+		return []float64{v.X, v.Y, v.Z}
+	}
+
+	for _, angle := range lieAngles {
+		w := randomRotationVector(r, angle)
+		rt, err := ExpSO3(w)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rt.Transpose()
+
+		// exp([w + d]) = exp([J_l * d]) * exp([w]) = exp([w]) * exp([J_r * d]) to first order
+		left := tangentJacobian(3, func(d []float64) []float64 {
+			m := expPerturbed(w, d)
+			if err := m.Postmultiply(rt); err != nil {
+				panic(err)
+			}
+			return logSlice(m)
+		})
+		right := tangentJacobian(3, func(d []float64) []float64 {
+			m := expPerturbed(w, d)
+			if err := m.Premultiply(rt); err != nil {
+				panic(err)
+			}
+			return logSlice(m)
+		})
+
+		jl, err := LeftJacobianSO3(w)
+		if err != nil {
+			t.Fatal(err)
+		}
+		jr, err := RightJacobianSO3(w)
+		if err != nil {
+			t.Fatal(err)
+		}
+		jli, err := LeftJacobianInverseSO3(w)
+		if err != nil {
+			t.Fatal(err)
+		}
+		jri, err := RightJacobianInverseSO3(w)
+		if err != nil {
+			t.Fatal(err)
+		}
+		el, er, eli, eri := jl.Elements(), jr.Elements(), jli.Elements(), jri.Elements()
+		if !closeSlices(el[:], left, 1e-8) {
+			t.Fatalf("LeftJacobianSO3 at angle %v = %v, want %v", angle, el, left)
+		}
+		if !closeSlices(er[:], right, 1e-8) {
+			t.Fatalf("RightJacobianSO3 at angle %v = %v, want %v", angle, er, right)
+		}
+		checkIdentityProduct(t, "J_l * J_l^-1", el[:], eli[:], 3)
+		checkIdentityProduct(t, "J_r * J_r^-1", er[:], eri[:], 3)
+	}
+}
+
+func TestSE2ExpLog(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	for _, angle := range lieAngles {
+		for _, sign := range []float64{-1, 1} {
+			xi := &geometry.Vector3D{X: r.NormFloat64(), Y: r.NormFloat64(), Z: sign * angle}
+			m, err := ExpSE2(xi)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := LogSE2(m)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !closeSlices([]float64{got.X, got.Y, got.Z}, []float64{xi.X, xi.Y, xi.Z}, 1e-14) {
+				t.Fatalf("LogSE2(ExpSE2(%v)) = %v", xi, got)
+			}
+
+			// moving along a constant twist in n steps reaches the exponential
+			step, err := ExpSE2(&geometry.Vector3D{X: xi.X / 8, Y: xi.Y / 8, Z: xi.Z / 8})
+			if err != nil {
+				t.Fatal(err)
+			}
+			p := step.Clone()
+			for k := 1; k < 8; k++ {
+				if err := p.Postmultiply(step.Matrix3D); err != nil {
+					t.Fatal(err)
+				}
+			}
+			pe, me := p.Elements(), m.Elements()
+			if !closeSlices(pe[:], me[:], 1e-14) {
+				t.Fatalf("ExpSE2(xi / 8)^8 = %v, want %v", pe, me)
+			}
+		}
+	}
+}
+
+func TestSE2Jacobians(t *testing.T) {
+	r := rand.New(rand.NewSource(4))
+	for _, angle := range lieAngles {
+		xi := &geometry.Vector3D{X: r.NormFloat64(), Y: r.NormFloat64(), Z: angle}
+		m, err := ExpSE2(xi)
+		if err != nil {
+			t.Fatal(err)
+		}
+		inv := m.Clone()
+		if err := inv.Invert(); err != nil {
+			t.Fatal(err)
+		}
+		perturbed := func(d []float64, left bool) []float64 {
+			p, err := ExpSE2(&geometry.Vector3D{X: xi.X + d[0], Y: xi.Y + d[1], Z: xi.Z + d[2]})
+			if err != nil {
+				panic(err)
+			}
+			if left {
+				err = p.Postmultiply(inv)
+			} else {
+				err = p.Premultiply(inv)
+			}
+			if err != nil {
+				panic(err)
+			}
+			v, err := LogSE2(&HomogeneousTransform3D{Matrix3D: p.Matrix3D})
+			if err != nil {
+				panic(err)
+			}
+			return []float64{v.X, v.Y, v.Z}
+		}
+		left := tangentJacobian(3, func(d []float64) []float64 { return perturbed(d, true) })
+		right := tangentJacobian(3, func(d []float64) []float64 { return perturbed(d, false) })
+
+		jl, err := LeftJacobianSE2(xi)
+		if err != nil {
+			t.Fatal(err)
+		}
+		jr, err := RightJacobianSE2(xi)
+		if err != nil {
+			t.Fatal(err)
+		}
+		jli, err := LeftJacobianInverseSE2(xi)
+		if err != nil {
+			t.Fatal(err)
+		}
+		jri, err := RightJacobianInverseSE2(xi)
+		if err != nil {
+			t.Fatal(err)
+		}
+		el, er, eli, eri := jl.Elements(), jr.Elements(), jli.Elements(), jri.Elements()
+		if !closeSlices(el[:], left, 1e-8) {
+			t.Fatalf("LeftJacobianSE2 at %v = %v, want %v", xi, el, left)
+		}
+		if !closeSlices(er[:], right, 1e-8) {
+			t.Fatalf("RightJacobianSE2 at %v = %v, want %v", xi, er, right)
+		}
+		checkIdentityProduct(t, "J_l * J_l^-1", el[:], eli[:], 3)
+		checkIdentityProduct(t, "J_r * J_r^-1", er[:], eri[:], 3)
+	}
+}
+
+func TestSE3ExpLog(t *testing.T) {
+	r := rand.New(rand.NewSource(5))
+	for _, angle := range lieAngles {
+		for i := 0; i < 50; i++ {
+			xi := &Twist{Linear: *randomVector3D(r), Angular: *randomRotationVector(r, angle)}
+			e, err := ExpSE3(xi)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := LogSE3(e)
+			if err != nil {
+				t.Fatal(err)
+			}
+			g, w := got.Elements(), xi.Elements()
+			if !closeSlices(g[:], w[:], 1e-13) {
+				t.Fatalf("LogSE3(ExpSE3(%v)) = %v", w, g)
+			}
+		}
+	}
+}
+
+func TestSE3Jacobians(t *testing.T) {
+	r := rand.New(rand.NewSource(6))
+	for _, angle := range lieAngles {
+		xi := &Twist{Linear: *randomVector3D(r), Angular: *randomRotationVector(r, angle)}
+		e, err := ExpSE3(xi)
+		if err != nil {
+			t.Fatal(err)
+		}
+		inv, err := e.Inverse()
+		if err != nil {
+			t.Fatal(err)
+		}
+		perturbed := func(d []float64, left bool) []float64 {
+			x := xi.Elements()
+			s := &Twist{}
+			if err := s.SetElements(x[0]+d[0], x[1]+d[1], x[2]+d[2], x[3]+d[3], x[4]+d[4], x[5]+d[5]); err != nil {
+				panic(err)
+			}
+			p, err := ExpSE3(s)
+			if err != nil {
+				panic(err)
+			}
+			if left {
+				err = p.Compose(inv)
+			} else {
+				q := inv.Clone()
+				err = q.Compose(p)
+				p = q
+			}
+			if err != nil {
+				panic(err)
+			}
+			v, err := LogSE3(p)
+			if err != nil {
+				panic(err)
+			}
+			ve := v.Elements()
+			return ve[:]
+		}
+		left := tangentJacobian(6, func(d []float64) []float64 { return perturbed(d, true) })
+		right := tangentJacobian(6, func(d []float64) []float64 { return perturbed(d, false) })
+
+		jl, err := LeftJacobianSE3(xi)
+		if err != nil {
+			t.Fatal(err)
+		}
+		jr, err := RightJacobianSE3(xi)
+		if err != nil {
+			t.Fatal(err)
+		}
+		jli, err := LeftJacobianInverseSE3(xi)
+		if err != nil {
+			t.Fatal(err)
+		}
+		jri, err := RightJacobianInverseSE3(xi)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !closeSlices(jl.Data, left, 1e-8) {
+			t.Fatalf("LeftJacobianSE3 at %v = %v, want %v", xi.Elements(), jl.Data, left)
+		}
+		if !closeSlices(jr.Data, right, 1e-8) {
+			t.Fatalf("RightJacobianSE3 at %v = %v, want %v", xi.Elements(), jr.Data, right)
+		}
+		checkIdentityProduct(t, "J_l * J_l^-1", jl.Data, jli.Data, 6)
+		checkIdentityProduct(t, "J_r * J_r^-1", jr.Data, jri.Data, 6)
+	}
+}
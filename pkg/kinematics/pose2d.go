@@ -0,0 +1,148 @@
+package kinematics
+
+import (
+	"math"
+
+	"github.com/tab58/v1/spatial/pkg/geometry"
+	"github.com/tab58/v1/spatial/pkg/numeric"
+)
+
+// WrapAngle returns the angle wrapped to the interval (-pi, pi].
+func WrapAngle(angle float64) float64 {
+	a := math.Remainder(angle, 2*math.Pi)
+	if a <= -math.Pi {
+		a += 2 * math.Pi
+	}
+	return a
+}
+
+// Pose2D is a rigid transformation in the plane: a rotation by Theta followed by a translation by (X, Y).
+// Equivalently, it is the position and heading of a frame, such as a mobile robot, relative to a reference frame.
+type Pose2D struct {
+	X     float64
+	Y     float64
+	Theta float64
+}
+
+// NewPose2D creates a pose from the position and heading, wrapping the heading to (-pi, pi].
+func NewPose2D(x, y, theta float64) (*Pose2D, error) {
+	if numeric.AreAnyOverflow(x, y, theta) {
+		return nil, numeric.ErrOverflow
+	}
+	return &Pose2D{X: x, Y: y, Theta: WrapAngle(theta)}, nil
+}
+
+// Identity sets the pose to the identity transform.
+func (p *Pose2D) Identity() {
+	p.X, p.Y, p.Theta = 0, 0, 0
+}
+
+// Clone returns a copy of the pose.
+func (p *Pose2D) Clone() *Pose2D {
+	return &Pose2D{X: p.X, Y: p.Y, Theta: p.Theta}
+}
+
+// IsEqualTo returns true if the positions are equal and the headings equal modulo 2*pi, within a tolerance. The heading
+// difference is compared at the scale of one radian.
+func (p *Pose2D) IsEqualTo(q *Pose2D, tol numeric.Tolerance) (bool, error) {
+	if tol.IsInvalid() {
+		return false, numeric.ErrInvalidTol
+	}
+	scale := math.Max(math.Max(math.Abs(p.X), math.Abs(q.X)), math.Max(math.Abs(p.Y), math.Abs(q.Y)))
+	isEqual := tol.IsEqualAtScale(p.X, q.X, scale) && tol.IsEqualAtScale(p.Y, q.Y, scale) &&
+		tol.IsEqualAtScale(WrapAngle(p.Theta-q.Theta), 0, 1)
+	return isEqual, nil
+}
+
+// Compose sets this pose to the composition of this pose with the given one, so that the result applies u first
+// and then the original pose. For odometry, this appends the motion u, expressed in the robot frame, to the pose.
+func (p *Pose2D) Compose(u *Pose2D) error {
+	c, s := math.Cos(p.Theta), math.Sin(p.Theta)
+	x := p.X + c*u.X - s*u.Y
+	y := p.Y + s*u.X + c*u.Y
+	theta := p.Theta + u.Theta
+	if numeric.AreAnyOverflow(x, y, theta) {
+		return numeric.ErrOverflow
+	}
+	p.X, p.Y, p.Theta = x, y, WrapAngle(theta)
+	return nil
+}
+
+// Inverse returns the inverse pose, (-R^T * t, -theta).
+func (p *Pose2D) Inverse() (*Pose2D, error) {
+	c, s := math.Cos(p.Theta), math.Sin(p.Theta)
+	x := -c*p.X - s*p.Y
+	y := s*p.X - c*p.Y
+	if numeric.AreAnyOverflow(x, y) {
+		return nil, numeric.ErrOverflow
+	}
+	return &Pose2D{X: x, Y: y, Theta: WrapAngle(-p.Theta)}, nil
+}
+
+// RelativeTo returns the pose expressed in the frame of the reference pose, q^-1 * p. For odometry, this is the motion
+// from the reference pose to this one.
+func (p *Pose2D) RelativeTo(q *Pose2D) (*Pose2D, error) {
+	r, err := q.Inverse()
+	if err != nil {
+		return nil, err
+	}
+	err = r.Compose(p)
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// ApplyToPoint returns the point transformed by the rotation and then the translation.
+func (p *Pose2D) ApplyToPoint(pt geometry.Point2DReader) (*geometry.Point2D, error) {
+	c, s := math.Cos(p.Theta), math.Sin(p.Theta)
+	px, py := pt.GetX(), pt.GetY()
+	x := c*px - s*py + p.X
+	y := s*px + c*py + p.Y
+	if numeric.AreAnyOverflow(x, y) {
+		return nil, numeric.ErrOverflow
+	}
+	return &geometry.Point2D{X: x, Y: y}, nil
+}
+
+// ApplyToVector returns the vector transformed by the rotation only; displacement vectors are unaffected by translation.
+func (p *Pose2D) ApplyToVector(v geometry.Vector2DReader) (*geometry.Vector2D, error) {
+	c, s := math.Cos(p.Theta), math.Sin(p.Theta)
+	vx, vy := v.GetComponents()
+	x := c*vx - s*vy
+	y := s*vx + c*vy
+	if numeric.AreAnyOverflow(x, y) {
+		return nil, numeric.ErrOverflow
+	}
+	return &geometry.Vector2D{X: x, Y: y}, nil
+}
+
+// ToHomogeneousTransform3D returns the pose encoded as a 3x3 homogeneous matrix.
+func (p *Pose2D) ToHomogeneousTransform3D() (*HomogeneousTransform3D, error) {
+	m := &HomogeneousTransform3D{Matrix3D: &geometry.Matrix3D{}}
+	err := m.Set2DTranslationRotation(&geometry.Vector2D{X: p.X, Y: p.Y}, p.Theta)
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// SetFromHomogeneousTransform3D sets the pose from a 3x3 homogeneous matrix encoding a 2D rotation and translation.
+// The bottom row of the matrix is assumed to be (0, 0, 1).
+func (p *Pose2D) SetFromHomogeneousTransform3D(m *HomogeneousTransform3D) error {
+	e := m.Elements()
+	if numeric.AreAnyOverflow(e[0], e[3], e[2], e[5]) {
+		return numeric.ErrOverflow
+	}
+	p.X, p.Y, p.Theta = e[2], e[5], math.Atan2(e[3], e[0])
+	return nil
+}
+
+// Log returns the SE(2) tangent vector (x, y, theta) of the pose, the constant velocity that reaches the pose in unit time.
+func (p *Pose2D) Log() (*geometry.Vector3D, error) {
+	m, err := p.ToHomogeneousTransform3D()
+	if err != nil {
+		return nil, err
+	}
+	return LogSE2(m)
+}
@@ -0,0 +1,178 @@
+package kinematics
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/tab58/v1/spatial/pkg/geometry"
+	"github.com/tab58/v1/spatial/pkg/numeric"
+)
+
+// randomPose2D returns a pose with a standard normal position and a uniformly distributed heading.
+func randomPose2D(r *rand.Rand) *Pose2D {
+	p, err := NewPose2D(r.NormFloat64(), r.NormFloat64(), (2*r.Float64()-1)*math.Pi)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// poseSlice returns the position and heading of the pose.
+func poseSlice(p *Pose2D) []float64 {
+	return []float64{p.X, p.Y, p.Theta}
+}
+
+func TestWrapAngle(t *testing.T) {
+	tests := []struct {
+		angle, want float64
+	}{
+		{0, 0},
+		{math.Pi, math.Pi},
+		{-math.Pi, math.Pi},
+		{3 * math.Pi, math.Pi},
+		{2*math.Pi + 0.5, 0.5},
+		{-1.5 * math.Pi, 0.5 * math.Pi},
+		{-0.25, -0.25},
+	}
+	for _, tt := range tests {
+		if got := WrapAngle(tt.angle); math.Abs(got-tt.want) > 1e-15 {
+			t.Errorf("WrapAngle(%v) = %v, want %v", tt.angle, got, tt.want)
+		}
+	}
+}
+
+func TestPose2DComposeInverse(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		p, u := randomPose2D(r), randomPose2D(r)
+		pt := &geometry.Point2D{X: r.NormFloat64(), Y: r.NormFloat64()}
+
+		// p * u applies u first
+		pu := p.Clone()
+		if err := pu.Compose(u); err != nil {
+			t.Fatal(err)
+		}
+		got, err := pu.ApplyToPoint(pt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		upt, err := u.ApplyToPoint(pt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want, err := p.ApplyToPoint(upt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !closeSlices([]float64{got.X, got.Y}, []float64{want.X, want.Y}, 1e-14) {
+			t.Fatalf("(p * u) x = %v, want %v", got, want)
+		}
+
+		// the homogeneous matrices compose the same way
+		mp, err := p.ToHomogeneousTransform3D()
+		if err != nil {
+			t.Fatal(err)
+		}
+		mu, err := u.ToHomogeneousTransform3D()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := mp.Postmultiply(mu.Matrix3D); err != nil {
+			t.Fatal(err)
+		}
+		m := &Pose2D{}
+		if err := m.SetFromHomogeneousTransform3D(mp); err != nil {
+			t.Fatal(err)
+		}
+		if ok, err := m.IsEqualTo(pu, numeric.AbsoluteTolerance(1e-14)); err != nil || !ok {
+			t.Fatalf("homogeneous p * u = %v, want %v", m, pu)
+		}
+
+		inv, err := p.Inverse()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := inv.Compose(p); err != nil {
+			t.Fatal(err)
+		}
+		if !closeSlices(poseSlice(inv), []float64{0, 0, 0}, 1e-14) {
+			t.Fatalf("p^-1 * p = %v, want the identity", inv)
+		}
+
+		// the reference pose followed by the relative motion reaches the pose
+		rel, err := pu.RelativeTo(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ok, err := rel.IsEqualTo(u, numeric.AbsoluteTolerance(1e-14)); err != nil || !ok {
+			t.Fatalf("(p * u).RelativeTo(p) = %v, want %v", rel, u)
+		}
+	}
+}
+
+func TestPose2DLog(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	for i := 0; i < 1000; i++ {
+		p := randomPose2D(r)
+		xi, err := p.Log()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if math.Abs(xi.Z-p.Theta) > 1e-15 {
+			t.Fatalf("Log(%v) has angle %v", p, xi.Z)
+		}
+		m, err := ExpSE2(xi)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := &Pose2D{}
+		if err := got.SetFromHomogeneousTransform3D(m); err != nil {
+			t.Fatal(err)
+		}
+		if ok, err := got.IsEqualTo(p, numeric.AbsoluteTolerance(1e-14)); err != nil || !ok {
+			t.Fatalf("ExpSE2(Log(p)) = %v, want %v", got, p)
+		}
+	}
+}
+
+func TestPose2DIsEqualTo(t *testing.T) {
+	tol := numeric.AbsoluteTolerance(1e-9)
+	tests := []struct {
+		name string
+		p, q Pose2D
+		want bool
+	}{
+		{"identical", Pose2D{1, 2, 3}, Pose2D{1, 2, 3}, true},
+		{"heading across pi", Pose2D{1, 2, math.Pi - 1e-12}, Pose2D{1, 2, -math.Pi + 1e-12}, true},
+		{"full turn", Pose2D{1, 2, 0.5}, Pose2D{1, 2, 0.5 + 2*math.Pi}, true},
+		{"heading", Pose2D{1, 2, 0.5}, Pose2D{1, 2, 0.5 + 1e-6}, false},
+		{"position", Pose2D{1, 2, 0.5}, Pose2D{1, 2 + 1e-6, 0.5}, false},
+	}
+	for _, tt := range tests {
+		got, err := tt.p.IsEqualTo(&tt.q, tol)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tt.want {
+			t.Errorf("IsEqualTo %s = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	// a relative tolerance measures the heading difference at the scale of one radian rather than of zero
+	rel := numeric.RelativeTolerance(1e-9)
+	p := &Pose2D{1e6, 2e6, 0.5}
+	if got, err := p.IsEqualTo(&Pose2D{1e6, 2e6 + 1e-4, 0.5 + 1e-12}, rel); err != nil || !got {
+		t.Errorf("IsEqualTo with a relative tolerance = %v, %v, want true", got, err)
+	}
+	if got, err := p.IsEqualTo(&Pose2D{1e6, 2e6, 0.5 + 1e-6}, rel); err != nil || got {
+		t.Errorf("IsEqualTo with a relative tolerance and a different heading = %v, %v, want false", got, err)
+	}
+
+	if _, err := (&Pose2D{}).IsEqualTo(&Pose2D{}, numeric.AbsoluteTolerance(-1)); err != numeric.ErrInvalidTol {
+		t.Errorf("IsEqualTo with a negative tolerance: got %v, want %v", err, numeric.ErrInvalidTol)
+	}
+	if _, err := NewPose2D(math.Inf(1), 0, 0); err != numeric.ErrOverflow {
+		t.Errorf("NewPose2D with an infinite position: got %v, want %v", err, numeric.ErrOverflow)
+	}
+}
@@ -0,0 +1,173 @@
+package kinematics
+
+import (
+	"github.com/tab58/v1/spatial/pkg/geometry"
+	"github.com/tab58/v1/spatial/pkg/numeric"
+)
+
+// RigidTransform3D is a proper rigid transformation in 3D space: a rotation followed by a translation.
+// The zero value is not a valid transform; use NewRigidTransform3D or Identity.
+type RigidTransform3D struct {
+	rotation    geometry.Matrix3D
+	translation geometry.Vector3D
+}
+
+// NewRigidTransform3D creates a rigid transform from the given rotation matrix and translation vector.
+// The matrix is assumed to be a proper rotation.
+func NewRigidTransform3D(rotation *geometry.Matrix3D, translation geometry.Vector3DReader) *RigidTransform3D {
+	t := &RigidTransform3D{}
+	t.rotation.Copy(rotation)
+	t.translation.SetComponents(translation.GetComponents())
+	return t
+}
+
+// NewRigidTransform3DFromQuaternion creates a rigid transform from the rotation encoded by the quaternion and the translation vector.
+func NewRigidTransform3DFromQuaternion(q geometry.QuaternionReader, translation geometry.Vector3DReader) (*RigidTransform3D, error) {
+	r, err := q.ToMatrix3D()
+	if err != nil {
+		return nil, err
+	}
+	return NewRigidTransform3D(r, translation), nil
+}
+
+// Identity sets the transform to the identity transform.
+func (t *RigidTransform3D) Identity() {
+	t.rotation.Identity()
+	t.translation.SetComponents(0, 0, 0)
+}
+
+// Clone returns a deep copy of the transform.
+func (t *RigidTransform3D) Clone() *RigidTransform3D {
+	return NewRigidTransform3D(&t.rotation, &t.translation)
+}
+
+// Copy copies the rotation and translation of the given transform to this one.
+func (t *RigidTransform3D) Copy(u *RigidTransform3D) {
+	t.rotation.Copy(&u.rotation)
+	t.translation.SetComponents(u.translation.GetComponents())
+}
+
+// Rotation returns a copy of the rotation matrix of the transform.
+func (t *RigidTransform3D) Rotation() *geometry.Matrix3D {
+	return t.rotation.Clone()
+}
+
+// Translation returns a copy of the translation vector of the transform.
+func (t *RigidTransform3D) Translation() *geometry.Vector3D {
+	return t.translation.Clone()
+}
+
+// Quaternion returns the rotation of the transform as a unit quaternion.
+func (t *RigidTransform3D) Quaternion() (*geometry.Quaternion, error) {
+	q := &geometry.Quaternion{}
+	err := q.SetFromMatrix3D(&t.rotation)
+	if err != nil {
+		return nil, err
+	}
+	return q, nil
+}
+
+// rotate computes R * v for the row-major rotation elements.
+func rotate(e [9]float64, v geometry.Vector3DReader) (float64, float64, float64, error) {
+	x, y, z := v.GetComponents()
+	rx := e[0]*x + e[1]*y + e[2]*z
+	ry := e[3]*x + e[4]*y + e[5]*z
+	rz := e[6]*x + e[7]*y + e[8]*z
+	if numeric.AreAnyOverflow(rx, ry, rz) {
+		return 0, 0, 0, numeric.ErrOverflow
+	}
+	return rx, ry, rz, nil
+}
+
+// Compose sets this transform to the composition of this transform with the given one, so that the result
+// applies u first and then the original transform.
+func (t *RigidTransform3D) Compose(u *RigidTransform3D) error {
+	// (R1, p1) * (R2, p2) = (R1 * R2, R1 * p2 + p1)
+	e := t.rotation.Elements()
+	x, y, z, err := rotate(e, &u.translation)
+	if err != nil {
+		return err
+	}
+	p := &geometry.Vector3D{X: x, Y: y, Z: z}
+	err = p.Add(&t.translation)
+	if err != nil {
+		return err
+	}
+
+	r := t.rotation.Clone()
+	err = r.Postmultiply(&u.rotation)
+	if err != nil {
+		return err
+	}
+
+	t.rotation.Copy(r)
+	t.translation.SetComponents(p.GetComponents())
+	return nil
+}
+
+// Inverse returns the inverse transform, computed in closed form as (R^T, -R^T * p).
+func (t *RigidTransform3D) Inverse() (*RigidTransform3D, error) {
+	r := t.rotation.Clone()
+	r.Transpose()
+
+	x, y, z, err := rotate(r.Elements(), &t.translation)
+	if err != nil {
+		return nil, err
+	}
+
+	inv := &RigidTransform3D{}
+	inv.rotation.Copy(r)
+	inv.translation.SetComponents(-x, -y, -z)
+	return inv, nil
+}
+
+// ApplyToPoint returns the point transformed by the rotation and then the translation.
+func (t *RigidTransform3D) ApplyToPoint(p geometry.Point3DReader) (*geometry.Point3D, error) {
+	x, y, z, err := rotate(t.rotation.Elements(), p.AsVector())
+	if err != nil {
+		return nil, err
+	}
+	tx, ty, tz := t.translation.GetComponents()
+
+	newX := x + tx
+	newY := y + ty
+	newZ := z + tz
+	if numeric.AreAnyOverflow(newX, newY, newZ) {
+		return nil, numeric.ErrOverflow
+	}
+	return &geometry.Point3D{X: newX, Y: newY, Z: newZ}, nil
+}
+
+// ApplyToVector returns the vector transformed by the rotation only; displacement vectors are unaffected by translation.
+func (t *RigidTransform3D) ApplyToVector(v geometry.Vector3DReader) (*geometry.Vector3D, error) {
+	x, y, z, err := rotate(t.rotation.Elements(), v)
+	if err != nil {
+		return nil, err
+	}
+	return &geometry.Vector3D{X: x, Y: y, Z: z}, nil
+}
+
+// ToHomogeneousTransform4D returns the transform encoded as a 4x4 homogeneous matrix.
+func (t *RigidTransform3D) ToHomogeneousTransform4D() (*HomogeneousTransform4D, error) {
+	e := t.rotation.Elements()
+	x, y, z := t.translation.GetComponents()
+
+	m := &HomogeneousTransform4D{Matrix4D: &geometry.Matrix4D{}}
+	err := m.SetElements(e[0], e[1], e[2], x, e[3], e[4], e[5], y, e[6], e[7], e[8], z, 0, 0, 0, 1)
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// SetFromHomogeneousTransform4D sets the transform from the rotation and translation blocks of a 4x4 homogeneous matrix.
+// The bottom row of the matrix is assumed to be (0, 0, 0, 1).
+func (t *RigidTransform3D) SetFromHomogeneousTransform4D(m *HomogeneousTransform4D) error {
+	e := m.Elements()
+	err := t.rotation.SetElements(e[0], e[1], e[2], e[4], e[5], e[6], e[8], e[9], e[10])
+	if err != nil {
+		return err
+	}
+	t.translation.SetComponents(e[3], e[7], e[11])
+	return nil
+}
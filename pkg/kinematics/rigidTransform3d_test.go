@@ -0,0 +1,119 @@
+package kinematics
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/tab58/v1/spatial/pkg/geometry"
+)
+
+// randomRigidTransform3D returns a transform with a uniformly distributed rotation and a standard normal translation.
+func randomRigidTransform3D(r *rand.Rand) *RigidTransform3D {
+	q := &geometry.Quaternion{X: r.NormFloat64(), Y: r.NormFloat64(), Z: r.NormFloat64(), W: r.NormFloat64()}
+	if err := q.Normalize(); err != nil {
+		panic(err)
+	}
+	t, err := NewRigidTransform3DFromQuaternion(q, randomVector3D(r))
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// rigidTransformSlice returns the rotation elements followed by the translation components of the transform.
+func rigidTransformSlice(t *RigidTransform3D) []float64 {
+	e := t.Rotation().Elements()
+	x, y, z := t.Translation().GetComponents()
+	return append(e[:], x, y, z)
+}
+
+func TestRigidTransform3DInverse(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	identity := []float64{1, 0, 0, 0, 1, 0, 0, 0, 1, 0, 0, 0}
+	for i := 0; i < 1000; i++ {
+		a := randomRigidTransform3D(r)
+		inv, err := a.Inverse()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		m := a.Clone()
+		if err := m.Compose(inv); err != nil {
+			t.Fatal(err)
+		}
+		if got := rigidTransformSlice(m); !closeSlices(got, identity, 1e-14) {
+			t.Fatalf("a * inverse(a) = %v, want the identity", got)
+		}
+		m = inv.Clone()
+		if err := m.Compose(a); err != nil {
+			t.Fatal(err)
+		}
+		if got := rigidTransformSlice(m); !closeSlices(got, identity, 1e-14) {
+			t.Fatalf("inverse(a) * a = %v, want the identity", got)
+		}
+	}
+}
+
+func TestRigidTransform3DCompose(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	for i := 0; i < 1000; i++ {
+		a, b := randomRigidTransform3D(r), randomRigidTransform3D(r)
+		p := &geometry.Point3D{X: r.NormFloat64(), Y: r.NormFloat64(), Z: r.NormFloat64()}
+
+		// a * b applies b first
+		ab := a.Clone()
+		if err := ab.Compose(b); err != nil {
+			t.Fatal(err)
+		}
+		got, err := ab.ApplyToPoint(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		bp, err := b.ApplyToPoint(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want, err := a.ApplyToPoint(bp)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !closeSlices([]float64{got.X, got.Y, got.Z}, []float64{want.X, want.Y, want.Z}, 1e-14) {
+			t.Fatalf("(a * b) p = %v, want %v", got, want)
+		}
+
+		// the homogeneous matrices compose the same way
+		ma, err := a.ToHomogeneousTransform4D()
+		if err != nil {
+			t.Fatal(err)
+		}
+		mb, err := b.ToHomogeneousTransform4D()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := ma.Postmultiply(mb.Matrix4D); err != nil {
+			t.Fatal(err)
+		}
+		c := &RigidTransform3D{}
+		if err := c.SetFromHomogeneousTransform4D(ma); err != nil {
+			t.Fatal(err)
+		}
+		if !closeSlices(rigidTransformSlice(c), rigidTransformSlice(ab), 1e-14) {
+			t.Fatalf("homogeneous a * b = %v, want %v", rigidTransformSlice(c), rigidTransformSlice(ab))
+		}
+
+		// vectors are rotated but not translated
+		v := &geometry.Vector3D{X: p.X, Y: p.Y, Z: p.Z}
+		rv, err := a.ApplyToVector(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ap, err := a.ApplyToPoint(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		x, y, z := a.Translation().GetComponents()
+		if !closeSlices([]float64{rv.X + x, rv.Y + y, rv.Z + z}, []float64{ap.X, ap.Y, ap.Z}, 1e-14) {
+			t.Fatalf("ApplyToVector = %v, ApplyToPoint = %v", rv, ap)
+		}
+	}
+}
@@ -0,0 +1,282 @@
+package kinematics
+
+import (
+	"math"
+
+	"github.com/tab58/v1/spatial/pkg/geometry"
+	"github.com/tab58/v1/spatial/pkg/numeric"
+	"gonum.org/v1/gonum/blas/blas64"
+)
+
+// Twist is a spatial velocity: the angular velocity of a body and the linear velocity of the body-fixed point
+// currently at the frame origin. As a 6-vector, it is ordered (linear, angular) to match the rows of GeometricJacobian.
+// A twist with a unit angular (or zero angular and unit linear) part is a screw axis.
+type Twist struct {
+	Linear  geometry.Vector3D
+	Angular geometry.Vector3D
+}
+
+// Wrench is a spatial force: a force and the moment about the frame origin. As a 6-vector, it is ordered (force, moment).
+type Wrench struct {
+	Force  geometry.Vector3D
+	Moment geometry.Vector3D
+}
+
+// NewRevoluteScrew creates the unit screw axis of a rotation about the line through the point along the axis.
+func NewRevoluteScrew(axis geometry.Vector3DReader, point geometry.Point3DReader) (*Twist, error) {
+	w := axis.Clone()
+	err := w.Normalize()
+	if err != nil {
+		return nil, err
+	}
+
+	// v = -w x q = q x w
+	v, err := point.AsVector().Cross(w)
+	if err != nil {
+		return nil, err
+	}
+	return &Twist{Linear: *v, Angular: *w}, nil
+}
+
+// NewPrismaticScrew creates the unit screw axis of a translation along the direction.
+func NewPrismaticScrew(direction geometry.Vector3DReader) (*Twist, error) {
+	v := direction.Clone()
+	err := v.Normalize()
+	if err != nil {
+		return nil, err
+	}
+	return &Twist{Linear: *v}, nil
+}
+
+// Clone returns a copy of the twist.
+func (s *Twist) Clone() *Twist {
+	return &Twist{Linear: s.Linear, Angular: s.Angular}
+}
+
+// Elements returns the twist as the 6-vector (linear, angular).
+func (s *Twist) Elements() [6]float64 {
+	return [6]float64{s.Linear.X, s.Linear.Y, s.Linear.Z, s.Angular.X, s.Angular.Y, s.Angular.Z}
+}
+
+// SetElements sets the twist from the 6-vector (linear, angular).
+func (s *Twist) SetElements(vx, vy, vz, wx, wy, wz float64) error {
+	if numeric.AreAnyOverflow(vx, vy, vz, wx, wy, wz) {
+		return numeric.ErrOverflow
+	}
+	s.Linear.SetComponents(vx, vy, vz)
+	s.Angular.SetComponents(wx, wy, wz)
+	return nil
+}
+
+// Scale scales the twist by the given factor.
+func (s *Twist) Scale(f float64) error {
+	lin := s.Linear.Clone()
+	err := lin.Scale(f)
+	if err != nil {
+		return err
+	}
+	ang := s.Angular.Clone()
+	err = ang.Scale(f)
+	if err != nil {
+		return err
+	}
+	s.Linear, s.Angular = *lin, *ang
+	return nil
+}
+
+// Transform changes the frame the twist is expressed in, where t maps coordinates of the current frame to the new
+// one. This applies the adjoint map: w' = R * w, v' = R * v + p x (R * w).
+func (s *Twist) Transform(t *RigidTransform3D) error {
+	lin, ang, err := adjointTransform(t, &s.Linear, &s.Angular)
+	if err != nil {
+		return err
+	}
+	s.Linear, s.Angular = *lin, *ang
+	return nil
+}
+
+// Dot computes the power of the wrench acting on a body moving with the twist, when both are expressed in the same frame.
+func (s *Twist) Dot(w *Wrench) (float64, error) {
+	a, err := s.Linear.Dot(&w.Force)
+	if err != nil {
+		return 0, err
+	}
+	b, err := s.Angular.Dot(&w.Moment)
+	if err != nil {
+		return 0, err
+	}
+	p := a + b
+	if numeric.IsOverflow(p) {
+		return 0, numeric.ErrOverflow
+	}
+	return p, nil
+}
+
+// Clone returns a copy of the wrench.
+func (w *Wrench) Clone() *Wrench {
+	return &Wrench{Force: w.Force, Moment: w.Moment}
+}
+
+// Elements returns the wrench as the 6-vector (force, moment).
+func (w *Wrench) Elements() [6]float64 {
+	return [6]float64{w.Force.X, w.Force.Y, w.Force.Z, w.Moment.X, w.Moment.Y, w.Moment.Z}
+}
+
+// SetElements sets the wrench from the 6-vector (force, moment).
+func (w *Wrench) SetElements(fx, fy, fz, mx, my, mz float64) error {
+	if numeric.AreAnyOverflow(fx, fy, fz, mx, my, mz) {
+		return numeric.ErrOverflow
+	}
+	w.Force.SetComponents(fx, fy, fz)
+	w.Moment.SetComponents(mx, my, mz)
+	return nil
+}
+
+// Transform changes the frame the wrench is expressed in, where t maps coordinates of the current frame to the new
+// one. This applies the dual adjoint map: f' = R * f, m' = R * m + p x (R * f), which preserves power.
+func (w *Wrench) Transform(t *RigidTransform3D) error {
+	m, f, err := adjointTransform(t, &w.Moment, &w.Force)
+	if err != nil {
+		return err
+	}
+	w.Force, w.Moment = *f, *m
+	return nil
+}
+
+// adjointTransform computes (R * a + p x (R * b), R * b), the common form of the twist and wrench frame changes.
+func adjointTransform(t *RigidTransform3D, a, b geometry.Vector3DReader) (*geometry.Vector3D, *geometry.Vector3D, error) {
+	rb, err := t.ApplyToVector(b)
+	if err != nil {
+		return nil, nil, err
+	}
+	ra, err := t.ApplyToVector(a)
+	if err != nil {
+		return nil, nil, err
+	}
+	pxrb, err := t.translation.Cross(rb)
+	if err != nil {
+		return nil, nil, err
+	}
+	err = ra.Add(pxrb)
+	if err != nil {
+		return nil, nil, err
+	}
+	return ra, rb, nil
+}
+
+// Adjoint returns the 6x6 adjoint matrix [R, [p]R; 0, R] of the transform, which maps twists ordered (linear, angular)
+// to the new frame.
+func (t *RigidTransform3D) Adjoint() blas64.General {
+	r := t.rotation.Elements()
+	pr := multiplyRotations(geometry.Hat(&t.translation).Elements(), r)
+
+	ad := blas64.General{Rows: 6, Cols: 6, Stride: 6, Data: make([]float64, 36)}
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			ad.Data[6*i+j] = r[3*i+j]
+			ad.Data[6*i+j+3] = pr[3*i+j]
+			ad.Data[6*(i+3)+j+3] = r[3*i+j]
+		}
+	}
+	return ad
+}
+
+// ExpTwist computes the rigid transform exp([s] * theta) produced by moving along the twist for the amount theta,
+// such as the displacement of a joint with screw axis s and joint value theta.
+func ExpTwist(s *Twist, theta float64) (*RigidTransform3D, error) {
+	if math.IsNaN(theta) || math.IsInf(theta, 0) {
+		return nil, numeric.ErrInvalidArgument
+	}
+	xi := s.Clone()
+	err := xi.Scale(theta)
+	if err != nil {
+		return nil, err
+	}
+	return ExpSE3(xi)
+}
+
+// Log returns the twist s such that ExpTwist(s, 1) reproduces the transform, with a rotation angle in [0, pi].
+func (t *RigidTransform3D) Log() (*Twist, error) {
+	return LogSE3(t)
+}
+
+// ProductOfExponentials describes the forward kinematics of a serial chain by the screw axes of its joints,
+// expressed in the base frame at the home configuration, and the home pose of the end link.
+type ProductOfExponentials struct {
+	home   *RigidTransform3D
+	screws []Twist
+}
+
+// NewProductOfExponentials creates a product-of-exponentials description from the home pose of the end link and the
+// base frame screw axes of the joints.
+func NewProductOfExponentials(home *RigidTransform3D, screws []Twist) (*ProductOfExponentials, error) {
+	if len(screws) == 0 {
+		return nil, numeric.ErrEmptyArray
+	}
+	tmp := make([]Twist, len(screws))
+	copy(tmp, screws)
+	return &ProductOfExponentials{
+		home:   home.Clone(),
+		screws: tmp,
+	}, nil
+}
+
+// NumJoints returns the number of joints.
+func (p *ProductOfExponentials) NumJoints() int {
+	return len(p.screws)
+}
+
+// ForwardKinematics computes the pose of the end link for the given joint values as
+// exp([S1] * theta1) * ... * exp([Sn] * thetan) * M.
+func (p *ProductOfExponentials) ForwardKinematics(theta []float64) (*RigidTransform3D, error) {
+	if len(theta) != len(p.screws) {
+		return nil, ErrJointCount
+	}
+
+	t := &RigidTransform3D{}
+	t.Identity()
+	for i := range p.screws {
+		e, err := ExpTwist(&p.screws[i], theta[i])
+		if err != nil {
+			return nil, err
+		}
+		err = t.Compose(e)
+		if err != nil {
+			return nil, err
+		}
+	}
+	err := t.Compose(p.home)
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// ToProductOfExponentials converts the chain to its product-of-exponentials description, taking the configuration
+// with all joint values zero as the home configuration.
+func (c *SerialChain) ToProductOfExponentials() (*ProductOfExponentials, error) {
+	q := make([]float64, len(c.links))
+	origins, axes, _, err := c.jointAxes(q)
+	if err != nil {
+		return nil, err
+	}
+	home, err := c.endTransform(q)
+	if err != nil {
+		return nil, err
+	}
+
+	screws := make([]Twist, len(c.links))
+	for i, l := range c.links {
+		var s *Twist
+		if l.Joint == RevoluteJoint {
+			s, err = NewRevoluteScrew(axes[i], origins[i])
+		} else {
+			s, err = NewPrismaticScrew(axes[i])
+		}
+		if err != nil {
+			return nil, err
+		}
+		screws[i] = *s
+	}
+	return NewProductOfExponentials(home, screws)
+}
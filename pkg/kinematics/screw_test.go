@@ -0,0 +1,187 @@
+package kinematics
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/tab58/v1/spatial/pkg/geometry"
+	"github.com/tab58/v1/spatial/pkg/numeric"
+)
+
+// randomTwist returns a twist with standard normal components.
+func randomTwist(r *rand.Rand) *Twist {
+	s := &Twist{}
+	if err := s.SetElements(r.NormFloat64(), r.NormFloat64(), r.NormFloat64(), r.NormFloat64(), r.NormFloat64(), r.NormFloat64()); err != nil {
+		panic(err)
+	}
+	return s
+}
+
+func TestExpTwistScrews(t *testing.T) {
+	// rotating about the vertical line through (1, 0, 0) swings (2, 0, 0) around a unit circle
+	s, err := NewRevoluteScrew(&geometry.Vector3D{Z: 2}, &geometry.Point3D{X: 1, Z: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, theta := range []float64{-2, 0, 0.5, math.Pi / 2, 3} {
+		e, err := ExpTwist(s, theta)
+		if err != nil {
+			t.Fatal(err)
+		}
+		p, err := e.ApplyToPoint(&geometry.Point3D{X: 2, Z: 1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []float64{1 + math.Cos(theta), math.Sin(theta), 1}
+		if !closeSlices([]float64{p.X, p.Y, p.Z}, want, 1e-15) {
+			t.Errorf("ExpTwist(revolute, %v) moves the point to %v, want %v", theta, p, want)
+		}
+	}
+
+	s, err = NewPrismaticScrew(&geometry.Vector3D{X: 3, Y: 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	e, err := ExpTwist(s, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := rigidTransformSlice(e), []float64{1, 0, 0, 0, 1, 0, 0, 0, 1, 6, 8, 0}; !closeSlices(got, want, 1e-15) {
+		t.Errorf("ExpTwist(prismatic, 10) = %v, want %v", got, want)
+	}
+
+	if _, err := ExpTwist(s, math.NaN()); err != numeric.ErrInvalidArgument {
+		t.Errorf("ExpTwist with a NaN amount: got %v, want %v", err, numeric.ErrInvalidArgument)
+	}
+	if _, err := NewRevoluteScrew(&geometry.Vector3D{}, &geometry.Point3D{}); err == nil {
+		t.Error("NewRevoluteScrew with a zero axis: got nil error")
+	}
+}
+
+func TestTwistLogRoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		s, err := NewRevoluteScrew(randomVector3D(r), &geometry.Point3D{X: r.NormFloat64(), Y: r.NormFloat64(), Z: r.NormFloat64()})
+		if err != nil {
+			t.Fatal(err)
+		}
+		theta := 0.01 + 3.1*r.Float64()
+		e, err := ExpTwist(s, theta)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := e.Log()
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := s.Elements()
+		for k := range want {
+			want[k] *= theta
+		}
+		g := got.Elements()
+		if !closeSlices(g[:], want[:], 1e-12) {
+			t.Fatalf("Log(ExpTwist(s, %v)) = %v, want %v", theta, g, want)
+		}
+	}
+}
+
+func TestProductOfExponentials(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	for _, convention := range []DHConvention{StandardDH, ModifiedDH} {
+		for i := 0; i < 20; i++ {
+			c := randomSerialChain(r, convention)
+			poe, err := c.ToProductOfExponentials()
+			if err != nil {
+				t.Fatal(err)
+			}
+			q := make([]float64, c.NumJoints())
+			for k := range q {
+				q[k] = r.NormFloat64()
+			}
+			got, err := poe.ForwardKinematics(q)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want, err := c.endTransform(q)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !closeSlices(rigidTransformSlice(got), rigidTransformSlice(want), 1e-12) {
+				t.Fatalf("product of exponentials at %v = %v, want %v", q, rigidTransformSlice(got), rigidTransformSlice(want))
+			}
+		}
+	}
+
+	if _, err := NewProductOfExponentials(&RigidTransform3D{}, nil); err != numeric.ErrEmptyArray {
+		t.Errorf("NewProductOfExponentials without screws: got %v, want %v", err, numeric.ErrEmptyArray)
+	}
+	poe, err := NewProductOfExponentials(&RigidTransform3D{}, []Twist{{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := poe.ForwardKinematics(nil); err != ErrJointCount {
+		t.Errorf("ForwardKinematics without joint values: got %v, want %v", err, ErrJointCount)
+	}
+}
+
+func TestTwistWrenchTransform(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	for i := 0; i < 1000; i++ {
+		a := randomRigidTransform3D(r)
+		s := randomTwist(r)
+		w := &Wrench{}
+		if err := w.SetElements(r.NormFloat64(), r.NormFloat64(), r.NormFloat64(), r.NormFloat64(), r.NormFloat64(), r.NormFloat64()); err != nil {
+			t.Fatal(err)
+		}
+		power, err := s.Dot(w)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// the transformed twist is the adjoint matrix times the twist
+		ad := a.Adjoint()
+		e := s.Elements()
+		want := make([]float64, 6)
+		for j := 0; j < 6; j++ {
+			for k := 0; k < 6; k++ {
+				want[j] += ad.Data[6*j+k] * e[k]
+			}
+		}
+		ts := s.Clone()
+		if err := ts.Transform(a); err != nil {
+			t.Fatal(err)
+		}
+		got := ts.Elements()
+		if !closeSlices(got[:], want, 1e-14) {
+			t.Fatalf("Transform = %v, want %v", got, want)
+		}
+
+		// moving the twist along its own exponential leaves it unchanged
+		e2, err := ExpTwist(s, r.NormFloat64())
+		if err != nil {
+			t.Fatal(err)
+		}
+		fixed := s.Clone()
+		if err := fixed.Transform(e2); err != nil {
+			t.Fatal(err)
+		}
+		f, g := fixed.Elements(), s.Elements()
+		if !closeSlices(f[:], g[:], 1e-13) {
+			t.Fatalf("Transform by its own exponential = %v, want %v", f, g)
+		}
+
+		// power does not depend on the frame
+		tw := w.Clone()
+		if err := tw.Transform(a); err != nil {
+			t.Fatal(err)
+		}
+		p, err := ts.Dot(tw)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if math.Abs(p-power) > 1e-13*(1+math.Abs(power)) {
+			t.Fatalf("power after Transform = %v, want %v", p, power)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package kinematics
+
+import (
+	"math"
+
+	"github.com/tab58/v1/spatial/pkg/geometry"
+	"github.com/tab58/v1/spatial/pkg/numeric"
+)
+
+// DHConvention is the Denavit-Hartenberg convention used to interpret a parameter table.
+type DHConvention int
+
+const (
+	// StandardDH places the frame of link i at the distal end of the link: A_i = Rot_z(theta_i) * Trans_z(d_i) * Trans_x(a_i) * Rot_x(alpha_i).
+	StandardDH DHConvention = iota
+	// ModifiedDH places the frame of link i at its proximal joint: A_i = Rot_x(alpha_i-1) * Trans_x(a_i-1) * Rot_z(theta_i) * Trans_z(d_i).
+	ModifiedDH
+)
+
+// JointType is the kind of motion a joint allows.
+type JointType int
+
+const (
+	// RevoluteJoint rotates about the joint's z-axis; the joint variable is added to theta.
+	RevoluteJoint JointType = iota
+	// PrismaticJoint translates along the joint's z-axis; the joint variable is added to d.
+	PrismaticJoint
+)
+
+// DHParameters is one row of a Denavit-Hartenberg parameter table. For the modified convention, A and Alpha
+// are those of the previous link. Theta and D act as offsets for the joint variable.
+type DHParameters struct {
+	A     float64
+	Alpha float64
+	D     float64
+	Theta float64
+	Joint JointType
+}
+
+// SerialChain is a serial manipulator described by a Denavit-Hartenberg parameter table, with one joint per link.
+type SerialChain struct {
+	convention DHConvention
+	links      []DHParameters
+}
+
+// NewSerialChain creates a serial chain from a Denavit-Hartenberg parameter table.
+func NewSerialChain(convention DHConvention, links []DHParameters) (*SerialChain, error) {
+	if convention != StandardDH && convention != ModifiedDH {
+		return nil, numeric.ErrInvalidArgument
+	}
+	if len(links) == 0 {
+		return nil, numeric.ErrEmptyArray
+	}
+	for _, l := range links {
+		if l.Joint != RevoluteJoint && l.Joint != PrismaticJoint {
+			return nil, numeric.ErrInvalidArgument
+		}
+		if math.IsNaN(l.A) || math.IsNaN(l.Alpha) || math.IsNaN(l.D) || math.IsNaN(l.Theta) {
+			return nil, numeric.ErrInvalidArgument
+		}
+	}
+
+	tmp := make([]DHParameters, len(links))
+	copy(tmp, links)
+	return &SerialChain{
+		convention: convention,
+		links:      tmp,
+	}, nil
+}
+
+// Convention returns the Denavit-Hartenberg convention of the chain.
+func (c *SerialChain) Convention() DHConvention {
+	return c.convention
+}
+
+// NumJoints returns the number of joints in the chain.
+func (c *SerialChain) NumJoints() int {
+	return len(c.links)
+}
+
+// Links returns a copy of the Denavit-Hartenberg parameter table of the chain.
+func (c *SerialChain) Links() []DHParameters {
+	tmp := make([]DHParameters, len(c.links))
+	copy(tmp, c.links)
+	return tmp
+}
+
+// LinkTransform returns the transform of link i relative to link i-1 for the joint value q.
+func (c *SerialChain) LinkTransform(i int, q float64) (*HomogeneousTransform4D, error) {
+	if i < 0 || i >= len(c.links) {
+		return nil, numeric.ErrInvalidArgument
+	}
+	if math.IsNaN(q) || math.IsInf(q, 0) {
+		return nil, numeric.ErrInvalidArgument
+	}
+
+	l := c.links[i]
+	theta, d := l.Theta, l.D
+	if l.Joint == RevoluteJoint {
+		theta += q
+	} else {
+		d += q
+	}
+
+	m := &HomogeneousTransform4D{Matrix4D: &geometry.Matrix4D{}}
+	var err error
+	if c.convention == StandardDH {
+		err = m.SetStandardDH(l.A, l.Alpha, d, theta)
+	} else {
+		err = m.SetModifiedDH(l.A, l.Alpha, d, theta)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ForwardKinematics computes the transform of every link frame relative to the base frame for the given joint values.
+// The last transform is the pose of the end link.
+func (c *SerialChain) ForwardKinematics(q []float64) ([]*HomogeneousTransform4D, error) {
+	if len(q) != len(c.links) {
+		return nil, ErrJointCount
+	}
+
+	frames := make([]*HomogeneousTransform4D, len(c.links))
+	current := &geometry.Matrix4D{}
+	current.Identity()
+	for i := range c.links {
+		a, err := c.LinkTransform(i, q[i])
+		if err != nil {
+			return nil, err
+		}
+		err = current.Postmultiply(a.Matrix4D)
+		if err != nil {
+			return nil, err
+		}
+		frames[i] = &HomogeneousTransform4D{Matrix4D: current.Clone()}
+	}
+	return frames, nil
+}
+
+// EndEffectorTransform computes the transform of the end link relative to the base frame for the given joint values.
+func (c *SerialChain) EndEffectorTransform(q []float64) (*HomogeneousTransform4D, error) {
+	frames, err := c.ForwardKinematics(q)
+	if err != nil {
+		return nil, err
+	}
+	return frames[len(frames)-1], nil
+}
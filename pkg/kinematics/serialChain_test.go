@@ -0,0 +1,141 @@
+package kinematics
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/tab58/v1/spatial/pkg/numeric"
+)
+
+// elbowArm returns the standard DH table of an anthropomorphic arm with a vertical base joint of height d1
+// and two parallel links of lengths a2 and a3.
+func elbowArm(d1, a2, a3 float64) []DHParameters {
+	return []DHParameters{
+		{Alpha: math.Pi / 2, D: d1},
+		{A: a2},
+		{A: a3},
+	}
+}
+
+// elbowArmPosition returns the closed-form end position of the elbow arm.
+func elbowArmPosition(d1, a2, a3 float64, q []float64) []float64 {
+	r := a2*math.Cos(q[1]) + a3*math.Cos(q[1]+q[2])
+	z := d1 + a2*math.Sin(q[1]) + a3*math.Sin(q[1]+q[2])
+	return []float64{r * math.Cos(q[0]), r * math.Sin(q[0]), z}
+}
+
+// translationSlice returns the translation column of a homogeneous transform.
+func translationSlice(m *HomogeneousTransform4D) []float64 {
+	e := m.Elements()
+	return []float64{e[3], e[7], e[11]}
+}
+
+func TestSerialChainPlanarArm(t *testing.T) {
+	standard, err := NewSerialChain(StandardDH, []DHParameters{{A: 2}, {A: 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the modified table places the frames at the joints, so a fixed third frame marks the end of the second link
+	modified, err := NewSerialChain(ModifiedDH, []DHParameters{{}, {A: 2}, {A: 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		q1, q2 := (2*r.Float64()-1)*math.Pi, (2*r.Float64()-1)*math.Pi
+		c12, s12 := math.Cos(q1+q2), math.Sin(q1+q2)
+		want := [16]float64{
+			c12, -s12, 0, 2*math.Cos(q1) + c12,
+			s12, c12, 0, 2*math.Sin(q1) + s12,
+			0, 0, 1, 0,
+			0, 0, 0, 1,
+		}
+
+		frames, err := standard.ForwardKinematics([]float64{q1, q2})
+		if err != nil {
+			t.Fatal(err)
+		}
+		elbow := []float64{2 * math.Cos(q1), 2 * math.Sin(q1), 0}
+		if got := translationSlice(frames[0]); !closeSlices(got, elbow, 1e-14) {
+			t.Fatalf("standard elbow frame at %v = %v, want %v", []float64{q1, q2}, got, elbow)
+		}
+		got := frames[1].Elements()
+		if !closeSlices(got[:], want[:], 1e-14) {
+			t.Fatalf("standard end frame at %v = %v, want %v", []float64{q1, q2}, got, want)
+		}
+
+		end, err := modified.EndEffectorTransform([]float64{q1, q2, 0})
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = end.Elements()
+		if !closeSlices(got[:], want[:], 1e-14) {
+			t.Fatalf("modified end frame at %v = %v, want %v", []float64{q1, q2}, got, want)
+		}
+	}
+}
+
+func TestSerialChainElbowArm(t *testing.T) {
+	d1, a2, a3 := 0.5, 1.5, 0.75
+	c, err := NewSerialChain(StandardDH, elbowArm(d1, a2, a3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := rand.New(rand.NewSource(2))
+	for i := 0; i < 100; i++ {
+		q := []float64{(2*r.Float64() - 1) * math.Pi, (2*r.Float64() - 1) * math.Pi, (2*r.Float64() - 1) * math.Pi}
+		end, err := c.EndEffectorTransform(q)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := translationSlice(end), elbowArmPosition(d1, a2, a3, q); !closeSlices(got, want, 1e-14) {
+			t.Fatalf("end position at %v = %v, want %v", q, got, want)
+		}
+	}
+}
+
+func TestSerialChainPrismaticJoint(t *testing.T) {
+	// a revolute base with a vertical slide at the end of its link
+	c, err := NewSerialChain(StandardDH, []DHParameters{{A: 1}, {D: 0.5, Joint: PrismaticJoint}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	end, err := c.EndEffectorTransform([]float64{math.Pi / 2, 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := translationSlice(end), []float64{0, 1, 2.5}; !closeSlices(got, want, 1e-15) {
+		t.Errorf("end position = %v, want %v", got, want)
+	}
+}
+
+func TestSerialChainErrors(t *testing.T) {
+	if _, err := NewSerialChain(DHConvention(2), []DHParameters{{}}); err != numeric.ErrInvalidArgument {
+		t.Errorf("NewSerialChain with an invalid convention: got %v, want %v", err, numeric.ErrInvalidArgument)
+	}
+	if _, err := NewSerialChain(StandardDH, nil); err != numeric.ErrEmptyArray {
+		t.Errorf("NewSerialChain without links: got %v, want %v", err, numeric.ErrEmptyArray)
+	}
+	if _, err := NewSerialChain(StandardDH, []DHParameters{{Joint: JointType(2)}}); err != numeric.ErrInvalidArgument {
+		t.Errorf("NewSerialChain with an invalid joint: got %v, want %v", err, numeric.ErrInvalidArgument)
+	}
+	if _, err := NewSerialChain(StandardDH, []DHParameters{{A: math.NaN()}}); err != numeric.ErrInvalidArgument {
+		t.Errorf("NewSerialChain with a NaN parameter: got %v, want %v", err, numeric.ErrInvalidArgument)
+	}
+
+	c, err := NewSerialChain(StandardDH, elbowArm(0.5, 1, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.ForwardKinematics([]float64{0, 0}); err != ErrJointCount {
+		t.Errorf("ForwardKinematics with too few joint values: got %v, want %v", err, ErrJointCount)
+	}
+	if _, err := c.ForwardKinematics([]float64{0, math.NaN(), 0}); err != numeric.ErrInvalidArgument {
+		t.Errorf("ForwardKinematics with a NaN joint value: got %v, want %v", err, numeric.ErrInvalidArgument)
+	}
+	if _, err := c.LinkTransform(3, 0); err != numeric.ErrInvalidArgument {
+		t.Errorf("LinkTransform out of range: got %v, want %v", err, numeric.ErrInvalidArgument)
+	}
+}
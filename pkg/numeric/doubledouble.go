@@ -0,0 +1,145 @@
+package numeric
+
+import (
+	"math"
+)
+
+// DoubleDouble is an extended precision number represented as the unevaluated sum of two float64 values, Hi + Lo,
+// where |Lo| is at most half a unit in the last place of Hi. It carries about 106 bits of significand.
+type DoubleDouble struct {
+	Hi float64
+	Lo float64
+}
+
+// NewDoubleDouble creates a double-double number with the value of x.
+func NewDoubleDouble(x float64) DoubleDouble {
+	return DoubleDouble{Hi: x}
+}
+
+// Float64 returns the value of the number rounded to a float64.
+func (a DoubleDouble) Float64() float64 {
+	return a.Hi + a.Lo
+}
+
+// IsOverflow returns true if the number has overflowed, false if not. An overflow leaves the high part infinite, or
+// NaN once the infinite error terms of the operations cancel.
+func (a DoubleDouble) IsOverflow() bool {
+	return IsOverflow(a.Hi) || math.IsNaN(a.Hi)
+}
+
+// Sign returns the sign of the number.
+func (a DoubleDouble) Sign() (int, error) {
+	if a.Hi == 0 {
+		return Signum(a.Lo)
+	}
+	return Signum(a.Hi)
+}
+
+// Neg returns the negation of the number.
+func (a DoubleDouble) Neg() DoubleDouble {
+	return DoubleDouble{Hi: -a.Hi, Lo: -a.Lo}
+}
+
+// Abs returns the absolute value of the number.
+func (a DoubleDouble) Abs() DoubleDouble {
+	if a.Hi < 0 || a.Hi == 0 && a.Lo < 0 {
+		return a.Neg()
+	}
+	return a
+}
+
+// Add returns the sum a + b.
+func (a DoubleDouble) Add(b DoubleDouble) DoubleDouble {
+	s, e := TwoSum(a.Hi, b.Hi)
+	t, f := TwoSum(a.Lo, b.Lo)
+	s, e = FastTwoSum(s, e+t)
+	s, e = FastTwoSum(s, e+f)
+	return DoubleDouble{Hi: s, Lo: e}
+}
+
+// AddFloat64 returns the sum a + b.
+func (a DoubleDouble) AddFloat64(b float64) DoubleDouble {
+	s, e := TwoSum(a.Hi, b)
+	s, e = FastTwoSum(s, e+a.Lo)
+	return DoubleDouble{Hi: s, Lo: e}
+}
+
+// Sub returns the difference a - b.
+func (a DoubleDouble) Sub(b DoubleDouble) DoubleDouble {
+	return a.Add(b.Neg())
+}
+
+// Mul returns the product a * b.
+func (a DoubleDouble) Mul(b DoubleDouble) DoubleDouble {
+	p, e := TwoProduct(a.Hi, b.Hi)
+	e += a.Hi*b.Lo + a.Lo*b.Hi
+	p, e = FastTwoSum(p, e)
+	return DoubleDouble{Hi: p, Lo: e}
+}
+
+// MulFloat64 returns the product a * b.
+func (a DoubleDouble) MulFloat64(b float64) DoubleDouble {
+	p, e := TwoProduct(a.Hi, b)
+	p, e = FastTwoSum(p, e+a.Lo*b)
+	return DoubleDouble{Hi: p, Lo: e}
+}
+
+// Div returns the quotient a / b.
+func (a DoubleDouble) Div(b DoubleDouble) (DoubleDouble, error) {
+	if b.Hi == 0 {
+		return DoubleDouble{}, ErrDivideByZero
+	}
+	// long division, refining the quotient with the exact remainder
+	q1 := a.Hi / b.Hi
+	r := a.Sub(b.MulFloat64(q1))
+	q2 := r.Hi / b.Hi
+	r = r.Sub(b.MulFloat64(q2))
+	q3 := r.Hi / b.Hi
+	q1, q2 = FastTwoSum(q1, q2)
+	return DoubleDouble{Hi: q1, Lo: q2}.AddFloat64(q3), nil
+}
+
+// Sqrt returns the square root of the number.
+func (a DoubleDouble) Sqrt() (DoubleDouble, error) {
+	if a.Hi < 0 {
+		return DoubleDouble{}, ErrNaN
+	}
+	if a.Hi == 0 {
+		return DoubleDouble{}, nil
+	}
+	// one Newton step from the float64 root, x + (a - x^2) / 2x
+	x := math.Sqrt(a.Hi)
+	p, e := TwoProduct(x, x)
+	r := a.Sub(DoubleDouble{Hi: p, Lo: e})
+	s, t := FastTwoSum(x, r.Hi/(2*x))
+	return DoubleDouble{Hi: s, Lo: t}, nil
+}
+
+// DotExtended computes the dot product of the vectors a and b in double-double precision.
+func DotExtended(a, b []float64) (DoubleDouble, error) {
+	if len(a) != len(b) {
+		return DoubleDouble{}, ErrVectorDims
+	}
+	var sum DoubleDouble
+	for i := range a {
+		if math.IsNaN(a[i]) || math.IsNaN(b[i]) {
+			return DoubleDouble{}, ErrNaN
+		}
+		p, e := TwoProduct(a[i], b[i])
+		if IsOverflow(p) {
+			return DoubleDouble{}, ErrOverflow
+		}
+		sum = sum.Add(DoubleDouble{Hi: p, Lo: e})
+	}
+	if sum.IsOverflow() {
+		return DoubleDouble{}, ErrOverflow
+	}
+	return sum, nil
+}
+
+// DifferenceOfProducts computes a * b - c * d in double-double precision.
+func DifferenceOfProducts(a, b, c, d float64) DoubleDouble {
+	p, e := TwoProduct(a, b)
+	q, f := TwoProduct(c, d)
+	return DoubleDouble{Hi: p, Lo: e}.Sub(DoubleDouble{Hi: q, Lo: f})
+}
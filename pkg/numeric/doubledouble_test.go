@@ -0,0 +1,125 @@
+package numeric
+
+import (
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+// doubleDoubleTol is the relative error allowed in the tests of double-double arithmetic, a few units of 2^-104.
+const doubleDoubleTol = 0x1p-100
+
+// ratDoubleDouble returns the exact value of a double-double number.
+func ratDoubleDouble(a DoubleDouble) *big.Rat {
+	r := new(big.Rat).SetFloat64(a.Hi)
+	return r.Add(r, new(big.Rat).SetFloat64(a.Lo))
+}
+
+// randomDoubleDouble returns a normalized double-double number with a random low part.
+func randomDoubleDouble(r *rand.Rand) DoubleDouble {
+	hi := r.NormFloat64() * math.Pow(2, float64(r.Intn(40)-20))
+	s, e := FastTwoSum(hi, hi*r.Float64()*0x1p-53)
+	return DoubleDouble{Hi: s, Lo: e}
+}
+
+// checkRelativeError checks that got is within doubleDoubleTol of want relative to scale.
+func checkRelativeError(t *testing.T, name string, got DoubleDouble, want *big.Rat, scale float64) {
+	t.Helper()
+	d := new(big.Rat).Sub(ratDoubleDouble(got), want)
+	err, _ := d.Abs(d).Float64()
+	if err > doubleDoubleTol*scale {
+		t.Fatalf("%s = %v, want %v: error %g exceeds %g", name, got, want.FloatString(40), err, doubleDoubleTol*scale)
+	}
+}
+
+func TestDoubleDoubleRoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 5000; i++ {
+		a, b := randomDoubleDouble(r), randomDoubleDouble(r)
+		scale := math.Max(math.Abs(a.Hi), math.Abs(b.Hi))
+		checkRelativeError(t, "a + b - b", a.Add(b).Sub(b), ratDoubleDouble(a), scale)
+
+		q, err := a.Mul(b).Div(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkRelativeError(t, "a * b / b", q, ratDoubleDouble(a), math.Abs(a.Hi))
+
+		s, err := a.Abs().Sqrt()
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkRelativeError(t, "sqrt(|a|)^2", s.Mul(s), ratDoubleDouble(a.Abs()), math.Abs(a.Hi))
+	}
+}
+
+func TestDoubleDoubleExactOperations(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	for i := 0; i < 5000; i++ {
+		x, y := r.NormFloat64(), r.NormFloat64()
+		// sums and products of two float64 values are exact in double-double precision
+		want := new(big.Rat).SetFloat64(x)
+		want.Add(want, new(big.Rat).SetFloat64(y))
+		if got := NewDoubleDouble(x).AddFloat64(y); ratDoubleDouble(got).Cmp(want) != 0 {
+			t.Fatalf("%v + %v = %v, want %v", x, y, got, want.FloatString(40))
+		}
+		want.SetFloat64(x)
+		want.Mul(want, new(big.Rat).SetFloat64(y))
+		if got := NewDoubleDouble(x).MulFloat64(y); ratDoubleDouble(got).Cmp(want) != 0 {
+			t.Fatalf("%v * %v = %v, want %v", x, y, got, want.FloatString(40))
+		}
+	}
+}
+
+func TestDotExtended(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	for i := 0; i < 1000; i++ {
+		n := 1 + r.Intn(8)
+		a, b := make([]float64, n), make([]float64, n)
+		want := new(big.Rat)
+		scale := 0.0
+		for k := range a {
+			a[k], b[k] = r.NormFloat64(), r.NormFloat64()
+			p := new(big.Rat).SetFloat64(a[k])
+			want.Add(want, p.Mul(p, new(big.Rat).SetFloat64(b[k])))
+			scale += math.Abs(a[k] * b[k])
+		}
+		got, err := DotExtended(a, b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkRelativeError(t, "DotExtended", got, want, scale)
+	}
+}
+
+func TestDoubleDoubleOverflow(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b []float64
+		want error
+	}{
+		{"product", []float64{1e200}, []float64{1e200}, ErrOverflow},
+		{"sum", []float64{1e308, 1e308}, []float64{1, 1}, ErrOverflow},
+		{"cancelling", []float64{1e300, 1e300}, []float64{1e10, -1e10}, ErrOverflow},
+		{"infinite", []float64{math.Inf(1)}, []float64{1}, ErrOverflow},
+		{"NaN", []float64{1, math.NaN()}, []float64{1, 1}, ErrNaN},
+		{"dimensions", []float64{1}, []float64{1, 1}, ErrVectorDims},
+	}
+	for _, tt := range tests {
+		if _, err := DotExtended(tt.a, tt.b); err != tt.want {
+			t.Errorf("DotExtended %s: got %v, want %v", tt.name, err, tt.want)
+		}
+	}
+
+	if d := DifferenceOfProducts(1e200, 1e200, 1, 1); !d.IsOverflow() {
+		t.Errorf("DifferenceOfProducts = %v, want overflow", d)
+	}
+	// the infinite products cancel to NaN, which must still count as an overflow
+	if d := DifferenceOfProducts(1e300, 1e10, 1e300, 1e10); !d.IsOverflow() {
+		t.Errorf("DifferenceOfProducts = %v, want overflow", d)
+	}
+	if d := DifferenceOfProducts(1e150, 1e150, 1e150, 1e150); d.IsOverflow() || d.Float64() != 0 {
+		t.Errorf("DifferenceOfProducts = %v, want 0", d)
+	}
+}
@@ -39,3 +39,28 @@ var ErrInvalidArgument = e.New("argument is invalid")
 
 // ErrInvalidTol expresses that a tolerance value is invalid.
 var ErrInvalidTol = e.New("invalid value for tolerance; must be nonnegative")
+
+// ErrGimbalLock expresses that a rotation is at or near a gimbal lock configuration, where its angles are not unique.
+var ErrGimbalLock = e.New("rotation is at or near gimbal lock")
+
+// ErrNotConverged expresses that an iterative method did not converge within its iteration limit.
+var ErrNotConverged = e.New("iteration did not converge")
+
+// ErrNotPositiveDefinite expresses that a matrix is not symmetric positive definite.
+var ErrNotPositiveDefinite = e.New("matrix is not positive definite")
+
+// ErrNegativeEigenvalue expresses that a matrix has a real negative eigenvalue, so a real principal logarithm or
+// square root does not exist.
+var ErrNegativeEigenvalue = e.New("matrix has a negative real eigenvalue")
+
+// ErrVectorOutOfRange expresses that the index of a vector is out of range.
+var ErrVectorOutOfRange = e.New("vector index is out of range")
+
+// ErrVectorDims expresses that the vector dimensions for a specific operation don't match.
+var ErrVectorDims = e.New("vector dimensions do not match")
+
+// ErrInvalidInterval expresses that the lower bound of an interval exceeds its upper bound.
+var ErrInvalidInterval = e.New("interval lower bound exceeds upper bound")
+
+// ErrIntervalContainsZero expresses that an interval contains zero where a nonzero interval is required.
+var ErrIntervalContainsZero = e.New("interval contains zero")
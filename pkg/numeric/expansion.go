@@ -0,0 +1,136 @@
+package numeric
+
+import (
+	"math"
+)
+
+// Expansions represent a real number exactly as the sum of float64 components, stored in order of increasing magnitude
+// with no two components overlapping in their significant bits. Zero components are eliminated, so the zero
+// expansion is empty. The arithmetic is exact as long as no component overflows or underflows.
+
+// TwoSum computes the sum a + b exactly as the rounded sum s and the roundoff error e, so that a + b = s + e.
+func TwoSum(a, b float64) (float64, float64) {
+	s := a + b
+	bv := s - a
+	av := s - bv
+	return s, (a - av) + (b - bv)
+}
+
+// FastTwoSum computes the sum a + b exactly as the rounded sum s and the roundoff error e, so that a + b = s + e. It
+// requires |a| >= |b|.
+func FastTwoSum(a, b float64) (float64, float64) {
+	s := a + b
+	return s, b - (s - a)
+}
+
+// TwoDiff computes the difference a - b exactly as the rounded difference d and the roundoff error e, so that
+// a - b = d + e.
+func TwoDiff(a, b float64) (float64, float64) {
+	return TwoSum(a, -b)
+}
+
+// TwoProduct computes the product a * b exactly as the rounded product p and the roundoff error e, so that
+// a * b = p + e.
+func TwoProduct(a, b float64) (float64, float64) {
+	p := a * b
+	return p, math.FMA(a, b, -p)
+}
+
+// GrowExpansion adds the number b to the expansion e and returns the sum as a new expansion.
+func GrowExpansion(e []float64, b float64) []float64 {
+	h := make([]float64, 0, len(e)+1)
+	q := b
+	for _, c := range e {
+		var r float64
+		q, r = TwoSum(q, c)
+		if r != 0 {
+			h = append(h, r)
+		}
+	}
+	if q != 0 {
+		h = append(h, q)
+	}
+	return h
+}
+
+// ExpansionSum adds the expansions e and f and returns the sum as a new expansion.
+func ExpansionSum(e, f []float64) []float64 {
+	if len(e) < len(f) {
+		e, f = f, e
+	}
+	h := append([]float64(nil), e...)
+	for _, c := range f {
+		h = GrowExpansion(h, c)
+	}
+	return h
+}
+
+// ScaleExpansion multiplies the expansion e by the number b and returns the product as a new expansion.
+func ScaleExpansion(e []float64, b float64) []float64 {
+	h := make([]float64, 0, 2*len(e))
+	if len(e) == 0 || b == 0 {
+		return h
+	}
+	q, r := TwoProduct(e[0], b)
+	if r != 0 {
+		h = append(h, r)
+	}
+	for _, c := range e[1:] {
+		p1, p0 := TwoProduct(c, b)
+		var sum float64
+		sum, r = TwoSum(q, p0)
+		if r != 0 {
+			h = append(h, r)
+		}
+		q, r = FastTwoSum(p1, sum)
+		if r != 0 {
+			h = append(h, r)
+		}
+	}
+	if q != 0 {
+		h = append(h, q)
+	}
+	return h
+}
+
+// ExpansionProduct multiplies the expansions e and f and returns the product as a new expansion.
+func ExpansionProduct(e, f []float64) []float64 {
+	if len(e) < len(f) {
+		e, f = f, e
+	}
+	var h []float64
+	for _, c := range f {
+		h = ExpansionSum(h, ScaleExpansion(e, c))
+	}
+	return h
+}
+
+// NegateExpansion returns the negation of the expansion e as a new expansion.
+func NegateExpansion(e []float64) []float64 {
+	h := make([]float64, len(e))
+	for i, c := range e {
+		h[i] = -c
+	}
+	return h
+}
+
+// ExpansionEstimate returns an approximation of the value of the expansion e.
+func ExpansionEstimate(e []float64) float64 {
+	s := 0.0
+	for _, c := range e {
+		s += c
+	}
+	return s
+}
+
+// ExpansionSign returns the exact sign of the value of the expansion e, which is the sign of its largest component.
+func ExpansionSign(e []float64) int {
+	for i := len(e) - 1; i >= 0; i-- {
+		if e[i] > 0 {
+			return 1
+		} else if e[i] < 0 {
+			return -1
+		}
+	}
+	return 0
+}
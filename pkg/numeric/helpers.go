@@ -34,12 +34,55 @@ func Signum(a float64) (int, error) {
 
 // Nrm2 computes the 2-norm of a vector in a numerically-stable way.
 func Nrm2(a float64, b float64) float64 {
-	if a == 0 && b == 0 {
+	return NrmN(a, b)
+}
+
+// NrmN computes the 2-norm of a vector of any length in a numerically-stable way, scaling the components as in
+// LAPACK's dnrm2 so that no intermediate result overflows or underflows. As with math.Hypot, the norm is infinite if
+// any component is infinite, even if another is NaN.
+func NrmN(x ...float64) float64 {
+	scale := 0.0
+	ssq := 1.0
+	isNaN := false
+	for _, v := range x {
+		if math.IsInf(v, 0) {
+			return math.Inf(1)
+		}
+		if math.IsNaN(v) {
+			isNaN = true
+		}
+		if isNaN || v == 0 {
+			continue
+		}
+		a := math.Abs(v)
+		if scale < a {
+			t := scale / a
+			ssq = 1 + ssq*t*t
+			scale = a
+		} else {
+			t := a / scale
+			ssq += t * t
+		}
+	}
+	if isNaN {
+		return math.NaN()
+	}
+	return scale * math.Sqrt(ssq)
+}
+
+// Hypot3 computes sqrt(x*x + y*y + z*z) without intermediate overflow or underflow.
+func Hypot3(x, y, z float64) float64 {
+	if math.IsInf(x, 0) || math.IsInf(y, 0) || math.IsInf(z, 0) {
+		return math.Inf(1)
+	}
+	if math.IsNaN(x) || math.IsNaN(y) || math.IsNaN(z) {
+		return math.NaN()
+	}
+	x, y, z = math.Abs(x), math.Abs(y), math.Abs(z)
+	m := math.Max(x, math.Max(y, z))
+	if m == 0 {
 		return 0
 	}
-	x := math.Abs(a)
-	y := math.Abs(b)
-	u := math.Max(x, y)
-	t := math.Min(x, y) / u
-	return u * math.Sqrt(1+t*t)
+	x, y, z = x/m, y/m, z/m
+	return m * math.Sqrt(x*x+y*y+z*z)
 }
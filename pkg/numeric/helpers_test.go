@@ -0,0 +1,48 @@
+package numeric
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNrm2(t *testing.T) {
+	// the squares of these components overflow or underflow
+	for _, s := range []float64{1e200, 1e-200} {
+		if got := Nrm2(3*s, 4*s); math.Abs(got-5*s) > 5*s*0x1p-51 {
+			t.Errorf("Nrm2(%v, %v) = %v, want %v", 3*s, 4*s, got, 5*s)
+		}
+	}
+	inf, nan := math.Inf(1), math.NaN()
+	// as with math.Hypot, an infinite component wins over NaN regardless of the order of the components
+	if got := Nrm2(nan, inf); !math.IsInf(got, 1) {
+		t.Errorf("Nrm2(NaN, +Inf) = %v, want +Inf", got)
+	}
+	if got := Nrm2(0, nan); !math.IsNaN(got) {
+		t.Errorf("Nrm2(0, NaN) = %v, want NaN", got)
+	}
+	if got := Nrm2(0, 0); got != 0 {
+		t.Errorf("Nrm2(0, 0) = %v, want 0", got)
+	}
+}
+
+func TestNrmN(t *testing.T) {
+	for _, s := range []float64{1, 1e200, 1e-200} {
+		if got := NrmN(3*s, 0, 4*s, 12*s); math.Abs(got-13*s) > 13*s*0x1p-51 {
+			t.Errorf("NrmN(%v, 0, %v, %v) = %v, want %v", 3*s, 4*s, 12*s, got, 13*s)
+		}
+	}
+	if got := NrmN(); got != 0 {
+		t.Errorf("NrmN() = %v, want 0", got)
+	}
+	inf, nan := math.Inf(1), math.NaN()
+	for _, x := range [][]float64{{nan, inf}, {inf, nan}, {1, nan, math.Inf(-1)}} {
+		if got := NrmN(x...); !math.IsInf(got, 1) {
+			t.Errorf("NrmN(%v) = %v, want +Inf", x, got)
+		}
+	}
+	for _, x := range [][]float64{{nan, 1}, {1, nan}, {0, nan}} {
+		if got := NrmN(x...); !math.IsNaN(got) {
+			t.Errorf("NrmN(%v) = %v, want NaN", x, got)
+		}
+	}
+}
@@ -0,0 +1,216 @@
+package numeric
+
+import (
+	"math"
+)
+
+// Interval is a closed interval [Lo, Hi] of real numbers. The operations on intervals round their bounds outward, so
+// the result of an operation contains every value obtained by applying the operation to members of its operands.
+type Interval struct {
+	Lo float64
+	Hi float64
+}
+
+// NewInterval creates the interval [lo, hi].
+func NewInterval(lo, hi float64) (Interval, error) {
+	if math.IsNaN(lo) || math.IsNaN(hi) {
+		return Interval{}, ErrNaN
+	}
+	if lo > hi {
+		return Interval{}, ErrInvalidInterval
+	}
+	return Interval{Lo: lo, Hi: hi}, nil
+}
+
+// NewIntervalPoint creates the degenerate interval [x, x].
+func NewIntervalPoint(x float64) Interval {
+	return Interval{Lo: x, Hi: x}
+}
+
+// errorUnderflowBound is the magnitude below which the roundoff error of a product or quotient may underflow, so it
+// is no longer known exactly.
+const errorUnderflowBound = 0x1p-969
+
+// roundedBounds returns the nearest floats below and above the exact value s + e, where s is the rounded result of an
+// operation and e its exact roundoff error. Both bounds are widened when the error is unknown.
+func roundedBounds(s, e float64) (float64, float64) {
+	switch {
+	case math.IsNaN(e) || math.IsInf(s, 0):
+		return math.Nextafter(s, math.Inf(-1)), math.Nextafter(s, math.Inf(1))
+	case e < 0:
+		return math.Nextafter(s, math.Inf(-1)), s
+	case e > 0:
+		return s, math.Nextafter(s, math.Inf(1))
+	}
+	return s, s
+}
+
+// sumDown returns a + b rounded towards negative infinity.
+func sumDown(a, b float64) float64 {
+	lo, _ := roundedBounds(TwoSum(a, b))
+	return lo
+}
+
+// sumUp returns a + b rounded towards positive infinity.
+func sumUp(a, b float64) float64 {
+	_, hi := roundedBounds(TwoSum(a, b))
+	return hi
+}
+
+// productBounds returns a * b rounded towards negative and positive infinity, treating 0 * inf as 0.
+func productBounds(a, b float64) (float64, float64) {
+	if a == 0 || b == 0 {
+		return 0, 0
+	}
+	p, e := TwoProduct(a, b)
+	if math.Abs(p) < errorUnderflowBound {
+		e = math.NaN()
+	}
+	return roundedBounds(p, e)
+}
+
+// Midpoint returns the midpoint of the interval.
+func (a Interval) Midpoint() float64 {
+	// halving first avoids overflow for wide finite intervals
+	m := a.Lo/2 + a.Hi/2
+	if math.IsNaN(m) {
+		return 0
+	}
+	return m
+}
+
+// Width returns the width of the interval, rounded upward.
+func (a Interval) Width() float64 {
+	return sumUp(a.Hi, -a.Lo)
+}
+
+// Contains returns true if x lies in the interval, false if not.
+func (a Interval) Contains(x float64) bool {
+	return a.Lo <= x && x <= a.Hi
+}
+
+// ContainsInterval returns true if b is a subset of the interval, false if not.
+func (a Interval) ContainsInterval(b Interval) bool {
+	return a.Lo <= b.Lo && b.Hi <= a.Hi
+}
+
+// ContainsZero returns true if the interval contains zero, false if not.
+func (a Interval) ContainsZero() bool {
+	return a.Contains(0)
+}
+
+// IsOverflow returns true if either bound of the interval has overflowed, false if not.
+func (a Interval) IsOverflow() bool {
+	return AreAnyOverflow(a.Lo, a.Hi)
+}
+
+// Sign returns the sign shared by every member of the interval. The second return value is false if the sign is not
+// certain, which is when the interval contains zero and some nonzero number.
+func (a Interval) Sign() (int, bool) {
+	switch {
+	case a.Lo > 0:
+		return 1, true
+	case a.Hi < 0:
+		return -1, true
+	case a.Lo == 0 && a.Hi == 0:
+		return 0, true
+	}
+	return 0, false
+}
+
+// Union returns the smallest interval containing both intervals.
+func (a Interval) Union(b Interval) Interval {
+	return Interval{Lo: math.Min(a.Lo, b.Lo), Hi: math.Max(a.Hi, b.Hi)}
+}
+
+// Neg returns the negation of the interval.
+func (a Interval) Neg() Interval {
+	return Interval{Lo: -a.Hi, Hi: -a.Lo}
+}
+
+// Abs returns the interval of absolute values of the members of the interval.
+func (a Interval) Abs() Interval {
+	switch {
+	case a.Lo >= 0:
+		return a
+	case a.Hi <= 0:
+		return a.Neg()
+	}
+	return Interval{Lo: 0, Hi: math.Max(-a.Lo, a.Hi)}
+}
+
+// Add returns the sum a + b.
+func (a Interval) Add(b Interval) Interval {
+	return Interval{Lo: sumDown(a.Lo, b.Lo), Hi: sumUp(a.Hi, b.Hi)}
+}
+
+// Sub returns the difference a - b.
+func (a Interval) Sub(b Interval) Interval {
+	return a.Add(b.Neg())
+}
+
+// Mul returns the product a * b.
+func (a Interval) Mul(b Interval) Interval {
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, x := range [2]float64{a.Lo, a.Hi} {
+		for _, y := range [2]float64{b.Lo, b.Hi} {
+			l, h := productBounds(x, y)
+			lo, hi = math.Min(lo, l), math.Max(hi, h)
+		}
+	}
+	return Interval{Lo: lo, Hi: hi}
+}
+
+// MulFloat64 returns the product a * b.
+func (a Interval) MulFloat64(b float64) Interval {
+	return a.Mul(NewIntervalPoint(b))
+}
+
+// Sqr returns the interval of squares of the members of the interval, which is tighter than a.Mul(a).
+func (a Interval) Sqr() Interval {
+	b := a.Abs()
+	lo, _ := productBounds(b.Lo, b.Lo)
+	_, hi := productBounds(b.Hi, b.Hi)
+	return Interval{Lo: lo, Hi: hi}
+}
+
+// Div returns the quotient a / b. The divisor must not contain zero.
+func (a Interval) Div(b Interval) (Interval, error) {
+	if b.ContainsZero() {
+		return Interval{}, ErrIntervalContainsZero
+	}
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, x := range [2]float64{a.Lo, a.Hi} {
+		for _, y := range [2]float64{b.Lo, b.Hi} {
+			q := x / y
+			// the remainder x - q * y is exact and has the sign of the roundoff error times y
+			r := -math.FMA(q, y, -x)
+			if math.Signbit(y) {
+				r = -r
+			}
+			if math.Abs(q) < errorUnderflowBound {
+				r = math.NaN()
+			}
+			l, h := roundedBounds(q, r)
+			lo, hi = math.Min(lo, l), math.Max(hi, h)
+		}
+	}
+	return Interval{Lo: lo, Hi: hi}, nil
+}
+
+// Sqrt returns the interval of square roots of the members of the interval. The interval must not contain negative
+// numbers.
+func (a Interval) Sqrt() (Interval, error) {
+	if a.Lo < 0 {
+		return Interval{}, ErrNaN
+	}
+	lo, hi := math.Sqrt(a.Lo), math.Sqrt(a.Hi)
+	// the square root is correctly rounded, so step outward unless the root is known to be exact
+	if p, e := TwoProduct(lo, lo); p > a.Lo || p == a.Lo && e > 0 || p < errorUnderflowBound {
+		lo = math.Nextafter(lo, 0)
+	}
+	if p, e := TwoProduct(hi, hi); p < a.Hi || p == a.Hi && e < 0 || p < errorUnderflowBound {
+		hi = math.Nextafter(hi, math.Inf(1))
+	}
+	return Interval{Lo: lo, Hi: hi}, nil
+}
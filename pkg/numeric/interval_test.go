@@ -0,0 +1,103 @@
+package numeric
+
+import (
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+// randomInterval returns an interval with random bounds spread over many orders of magnitude.
+func randomInterval(r *rand.Rand) Interval {
+	x := r.NormFloat64() * math.Pow(2, float64(r.Intn(200)-100))
+	y := x + math.Abs(r.NormFloat64()*x)*math.Pow(2, -float64(r.Intn(60)))
+	if r.Intn(8) == 0 {
+		y = x
+	}
+	return Interval{Lo: x, Hi: y}
+}
+
+// containsRat returns true if the interval contains the rational number x, false if not.
+func containsRat(a Interval, x *big.Rat) bool {
+	return new(big.Rat).SetFloat64(a.Lo).Cmp(x) <= 0 && x.Cmp(new(big.Rat).SetFloat64(a.Hi)) <= 0
+}
+
+// checkContainsBounds checks that the result of an interval operation contains the exact result of the operation on
+// every pair of bounds of its operands, which are the extremes of the operation over the operands.
+func checkContainsBounds(t *testing.T, name string, a, b, got Interval, op func(x, y *big.Rat) *big.Rat) {
+	t.Helper()
+	if got.IsOverflow() {
+		return
+	}
+	for _, x := range [2]float64{a.Lo, a.Hi} {
+		for _, y := range [2]float64{b.Lo, b.Hi} {
+			exact := op(new(big.Rat).SetFloat64(x), new(big.Rat).SetFloat64(y))
+			if !containsRat(got, exact) {
+				t.Fatalf("%s(%v, %v) = %v does not contain %v", name, a, b, got, exact.FloatString(20))
+			}
+		}
+	}
+}
+
+func TestIntervalArithmeticContainment(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 5000; i++ {
+		a, b := randomInterval(r), randomInterval(r)
+		checkContainsBounds(t, "Add", a, b, a.Add(b), func(x, y *big.Rat) *big.Rat { return x.Add(x, y) })
+		checkContainsBounds(t, "Sub", a, b, a.Sub(b), func(x, y *big.Rat) *big.Rat { return x.Sub(x, y) })
+		checkContainsBounds(t, "Mul", a, b, a.Mul(b), func(x, y *big.Rat) *big.Rat { return x.Mul(x, y) })
+		checkContainsBounds(t, "Sqr", a, a, a.Sqr(), func(x, y *big.Rat) *big.Rat { return x.Mul(x, x) })
+
+		q, err := a.Div(b)
+		if b.ContainsZero() {
+			if err != ErrIntervalContainsZero {
+				t.Fatalf("Div(%v, %v): got error %v, want %v", a, b, err, ErrIntervalContainsZero)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkContainsBounds(t, "Div", a, b, q, func(x, y *big.Rat) *big.Rat { return x.Quo(x, y) })
+	}
+}
+
+func TestIntervalSqrtContainment(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	for i := 0; i < 5000; i++ {
+		a := randomInterval(r).Abs()
+		s, err := a.Sqrt()
+		if err != nil {
+			t.Fatal(err)
+		}
+		lo, hi := new(big.Rat).SetFloat64(s.Lo), new(big.Rat).SetFloat64(s.Hi)
+		if lo.Mul(lo, lo).Cmp(new(big.Rat).SetFloat64(a.Lo)) > 0 || hi.Mul(hi, hi).Cmp(new(big.Rat).SetFloat64(a.Hi)) < 0 {
+			t.Fatalf("Sqrt(%v) = %v does not contain the square roots of its bounds", a, s)
+		}
+	}
+	if _, err := (Interval{Lo: -1, Hi: 1}).Sqrt(); err != ErrNaN {
+		t.Errorf("Sqrt of negative interval: got %v, want %v", err, ErrNaN)
+	}
+}
+
+func TestIntervalUnderflowContainment(t *testing.T) {
+	// the product and quotient underflow to zero, so their bounds must be widened to keep the exact results
+	a := NewIntervalPoint(0x1p-540 * 3)
+	b := NewIntervalPoint(0x1p-540 * 5)
+	checkContainsBounds(t, "Mul", a, b, a.Mul(b), func(x, y *big.Rat) *big.Rat { return x.Mul(x, y) })
+	c := NewIntervalPoint(0x1p+540 * 7)
+	q, err := a.Div(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkContainsBounds(t, "Div", a, c, q, func(x, y *big.Rat) *big.Rat { return x.Quo(x, y) })
+}
+
+func TestNewInterval(t *testing.T) {
+	if _, err := NewInterval(2, 1); err != ErrInvalidInterval {
+		t.Errorf("NewInterval(2, 1): got %v, want %v", err, ErrInvalidInterval)
+	}
+	if _, err := NewInterval(math.NaN(), 1); err != ErrNaN {
+		t.Errorf("NewInterval(NaN, 1): got %v, want %v", err, ErrNaN)
+	}
+}
@@ -0,0 +1,64 @@
+package numeric
+
+import (
+	"math"
+)
+
+// KahanSum computes the sum of the numbers with Kahan's compensated summation, which carries the roundoff error of
+// each addition into the next.
+func KahanSum(x []float64) float64 {
+	sum := 0.0
+	c := 0.0
+	for _, v := range x {
+		y := v - c
+		t := sum + y
+		c = (t - sum) - y
+		sum = t
+	}
+	return sum
+}
+
+// NeumaierSum computes the sum of the numbers with Neumaier's improvement of Kahan summation, which remains accurate
+// when a term is larger in magnitude than the running sum.
+func NeumaierSum(x []float64) float64 {
+	sum := 0.0
+	c := 0.0
+	for _, v := range x {
+		t := sum + v
+		if math.Abs(sum) >= math.Abs(v) {
+			c += (sum - t) + v
+		} else {
+			c += (v - t) + sum
+		}
+		sum = t
+	}
+	return sum + c
+}
+
+// AccurateDot computes the dot product of the vectors a and b as accurately as if it were evaluated in twice the
+// working precision and then rounded, using the Dot2 algorithm of Ogita, Rump and Oishi.
+func AccurateDot(a, b []float64) (float64, error) {
+	if len(a) != len(b) {
+		return 0, ErrVectorDims
+	}
+	p := 0.0
+	s := 0.0
+	for i := range a {
+		if math.IsNaN(a[i]) || math.IsNaN(b[i]) {
+			return 0, ErrNaN
+		}
+		h, r := TwoProduct(a[i], b[i])
+		var q float64
+		p, q = TwoSum(p, h)
+		// an infinite product or partial sum turns the error terms into NaN
+		if AreAnyOverflow(h, p) {
+			return 0, ErrOverflow
+		}
+		s += q + r
+	}
+	res := p + s
+	if IsOverflow(res) {
+		return 0, ErrOverflow
+	}
+	return res, nil
+}
@@ -0,0 +1,55 @@
+package numeric
+
+import (
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+func TestAccurateDot(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		// ill-conditioned dot products: large terms that cancel, leaving a small exact result
+		n := 2 + r.Intn(6)
+		a, b := make([]float64, 2*n), make([]float64, 2*n)
+		for k := 0; k < n; k++ {
+			a[k], b[k] = r.NormFloat64()*1e8, r.NormFloat64()
+			a[n+k], b[n+k] = -a[k], b[k]*(1+r.NormFloat64()*1e-12)
+		}
+		want := new(big.Rat)
+		for k := range a {
+			p := new(big.Rat).SetFloat64(a[k])
+			want.Add(want, p.Mul(p, new(big.Rat).SetFloat64(b[k])))
+		}
+		exact, _ := want.Float64()
+
+		got, err := AccurateDot(a, b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// twice the working precision gives about one unit of error in the rounded result for these condition numbers
+		if math.Abs(got-exact) > 4*math.Abs(exact)*0x1p-53 {
+			t.Fatalf("AccurateDot = %v, want %v", got, exact)
+		}
+	}
+}
+
+func TestAccurateDotErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b []float64
+		want error
+	}{
+		{"product", []float64{1e200}, []float64{1e200}, ErrOverflow},
+		{"sum", []float64{1e308, 1e308}, []float64{1, 1}, ErrOverflow},
+		{"cancelling", []float64{1e300, 1e300}, []float64{1e10, -1e10}, ErrOverflow},
+		{"NaN", []float64{math.NaN(), 1}, []float64{1, 1}, ErrNaN},
+		{"dimensions", []float64{1}, []float64{1, 1}, ErrVectorDims},
+	}
+	for _, tt := range tests {
+		if _, err := AccurateDot(tt.a, tt.b); err != tt.want {
+			t.Errorf("AccurateDot %s: got %v, want %v", tt.name, err, tt.want)
+		}
+	}
+}
@@ -1,6 +1,131 @@
 package numeric
 
+import (
+	"math"
+)
+
 // IsInvalidTolerance returns true if the tolerance value is invalid, false if valid.
 func IsInvalidTolerance(tol float64) bool {
 	return tol < 0
 }
+
+// Tolerance describes when two numbers are considered equal. Two numbers are equal if they are identical or if any
+// enabled criterion holds: their absolute difference is at most Absolute, their absolute difference is at most
+// Relative times the larger magnitude, or they are at most ULPs representable float64 values apart. A zero field
+// disables its criterion. Comparisons against zero are effectively absolute, since the relative and ULP criteria
+// shrink with the magnitude of the numbers.
+type Tolerance struct {
+	Absolute float64
+	Relative float64
+	ULPs     uint64
+}
+
+// DefaultTolerance is the tolerance used when no other is specific to the problem, combining an absolute tolerance
+// for values near zero with a relative tolerance that holds across model scales.
+var DefaultTolerance = Tolerance{Absolute: 1e-12, Relative: 1e-9}
+
+// AbsoluteTolerance creates a tolerance that compares the absolute difference of two numbers against tol.
+func AbsoluteTolerance(tol float64) Tolerance {
+	return Tolerance{Absolute: tol}
+}
+
+// RelativeTolerance creates a tolerance that compares the absolute difference of two numbers against rel times the
+// larger of their magnitudes.
+func RelativeTolerance(rel float64) Tolerance {
+	return Tolerance{Relative: rel}
+}
+
+// CombinedTolerance creates a tolerance that is satisfied by either an absolute or a relative comparison.
+func CombinedTolerance(abs, rel float64) Tolerance {
+	return Tolerance{Absolute: abs, Relative: rel}
+}
+
+// ULPTolerance creates a tolerance that is satisfied when two numbers are at most n representable values apart.
+func ULPTolerance(n uint64) Tolerance {
+	return Tolerance{ULPs: n}
+}
+
+// IsInvalid returns true if the tolerance has a negative or NaN component, false if valid.
+func (t Tolerance) IsInvalid() bool {
+	return IsInvalidTolerance(t.Absolute) || IsInvalidTolerance(t.Relative) ||
+		math.IsNaN(t.Absolute) || math.IsNaN(t.Relative)
+}
+
+// IsEqual returns true if a and b are equal within the tolerance, false if not.
+func (t Tolerance) IsEqual(a, b float64) bool {
+	return t.IsEqualAtScale(a, b, math.Max(math.Abs(a), math.Abs(b)))
+}
+
+// IsEqualAtScale returns true if a and b are equal within the tolerance, with the relative criterion measured against
+// the given scale rather than the magnitudes of a and b. It compares the components of vectors and matrices relative
+// to their overall size.
+func (t Tolerance) IsEqualAtScale(a, b, scale float64) bool {
+	if a == b || t.isWithin(math.Abs(a-b), scale) {
+		return true
+	}
+	return t.ULPs > 0 && ULPDistance(a, b) <= t.ULPs
+}
+
+// IsEqualFloat32 returns true if a and b are equal within the tolerance, false if not. The ULP criterion counts
+// representable float32 values.
+func (t Tolerance) IsEqualFloat32(a, b float32) bool {
+	return t.IsEqualAtScaleFloat32(a, b, math.Max(math.Abs(float64(a)), math.Abs(float64(b))))
+}
+
+// IsEqualAtScaleFloat32 is IsEqualAtScale for float32 values, where the ULP criterion counts representable float32
+// values.
+func (t Tolerance) IsEqualAtScaleFloat32(a, b float32, scale float64) bool {
+	if a == b || t.isWithin(math.Abs(float64(a)-float64(b)), scale) {
+		return true
+	}
+	return t.ULPs > 0 && ULPDistanceFloat32(a, b) <= t.ULPs
+}
+
+// isWithin returns true if the absolute difference d satisfies the absolute or relative criterion at the given scale.
+func (t Tolerance) isWithin(d, scale float64) bool {
+	return d <= t.Absolute || d <= t.Relative*scale
+}
+
+// ULPDistance returns the number of representable float64 values between a and b, counting both zeros as one value.
+// It is math.MaxUint64 if either is NaN.
+func ULPDistance(a, b float64) uint64 {
+	if math.IsNaN(a) || math.IsNaN(b) {
+		return math.MaxUint64
+	}
+	ia, ib := orderedBits(math.Float64bits(a)), orderedBits(math.Float64bits(b))
+	if ia > ib {
+		return uint64(ia) - uint64(ib)
+	}
+	return uint64(ib) - uint64(ia)
+}
+
+// ULPDistanceFloat32 returns the number of representable float32 values between a and b, counting both zeros as one
+// value. It is math.MaxUint64 if either is NaN.
+func ULPDistanceFloat32(a, b float32) uint64 {
+	if math.IsNaN(float64(a)) || math.IsNaN(float64(b)) {
+		return math.MaxUint64
+	}
+	ia, ib := orderedBits32(math.Float32bits(a)), orderedBits32(math.Float32bits(b))
+	if ia > ib {
+		return uint64(ia - ib)
+	}
+	return uint64(ib - ia)
+}
+
+// orderedBits maps the bits of a float64 to an integer with the same ordering as the floats.
+func orderedBits(bits uint64) int64 {
+	i := int64(bits)
+	if i < 0 {
+		return math.MinInt64 - i
+	}
+	return i
+}
+
+// orderedBits32 maps the bits of a float32 to an integer with the same ordering as the floats.
+func orderedBits32(bits uint32) int64 {
+	i := int64(int32(bits))
+	if i < 0 {
+		return math.MinInt32 - i
+	}
+	return i
+}